@@ -0,0 +1,208 @@
+package gosnmp
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// testAgent is a minimal SNMP agent serving a fixed MIB view over UDP on the
+// loopback interface. Its behaviour can be altered to mimic buggy agents.
+type testAgent struct {
+	t    *testing.T
+	conn *net.UDPConn
+	oids []OID
+	mib  map[string]Value
+
+	// mu guards the fields below and the MIB view, and is held while a
+	// request is handled
+	mu       sync.Mutex
+	requests []*SnmpPacket
+	// next overrides the GetNext lookup when set
+	next func(oid OID) VarBind
+	// respond overrides the whole response when set; returning nil drops
+	// the request
+	respond func(req *SnmpPacket) *SnmpPacket
+	// maxVarbinds makes the agent answer tooBig when a response would hold
+	// more variables
+	maxVarbinds int
+}
+
+func newTestAgent(t *testing.T, mib map[string]Value) *testAgent {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("Unable to start test agent: %s", err)
+	}
+
+	a := &testAgent{t: t, conn: conn, mib: make(map[string]Value)}
+	for name, value := range mib {
+		oid := MustParseOID(name)
+		a.oids = append(a.oids, oid)
+		a.mib[oid.String()] = value
+	}
+	sort.Slice(a.oids, func(i, j int) bool { return a.oids[i].Compare(a.oids[j]) < 0 })
+
+	go a.serve()
+	t.Cleanup(func() { conn.Close() })
+	return a
+}
+
+// client returns a v2c client connected to the agent
+func (a *testAgent) client() *GoSNMP {
+	s, err := NewGoSNMP(a.conn.LocalAddr().String(), "public", Version2c, 2)
+	if err != nil {
+		a.t.Fatalf("Unable to connect to test agent: %s", err)
+	}
+	return s
+}
+
+// requestCount returns the number of requests received so far
+func (a *testAgent) requestCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.requests)
+}
+
+// received returns the requests received so far
+func (a *testAgent) received() []*SnmpPacket {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*SnmpPacket(nil), a.requests...)
+}
+
+// setRespond overrides the whole response, see respond. Overrides run with
+// the agent locked, so they may read requests and call handle.
+func (a *testAgent) setRespond(respond func(req *SnmpPacket) *SnmpPacket) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.respond = respond
+}
+
+// setNext overrides the GetNext lookup, see next
+func (a *testAgent) setNext(next func(oid OID) VarBind) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.next = next
+}
+
+// setMaxVarbinds sets the number of variables above which the agent answers
+// tooBig, or lifts the limit if zero
+func (a *testAgent) setMaxVarbinds(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.maxVarbinds = n
+}
+
+func (a *testAgent) serve() {
+	buf := make([]byte, 65536)
+	for {
+		n, addr, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		req, err := UnmarshalTyped(buf[:n])
+		if err != nil {
+			continue
+		}
+
+		a.mu.Lock()
+		a.requests = append(a.requests, req)
+		var resp *SnmpPacket
+		if a.respond != nil {
+			resp = a.respond(req)
+		} else {
+			resp = a.handle(req)
+		}
+		a.mu.Unlock()
+		if resp == nil {
+			continue
+		}
+		resp.RequestID = req.RequestID
+		resp.Version = req.Version
+		resp.Community = req.Community
+		resp.RequestType = GetResponse
+
+		raw, err := resp.marshal()
+		if err != nil {
+			a.t.Errorf("Test agent unable to marshal response: %s", err)
+			continue
+		}
+		a.conn.WriteToUDP(raw, addr)
+	}
+}
+
+// handle answers a request from the MIB view. The caller holds mu.
+func (a *testAgent) handle(req *SnmpPacket) *SnmpPacket {
+	resp := &SnmpPacket{}
+
+	switch req.RequestType {
+	case GetRequest:
+		for _, vb := range req.VarBinds {
+			resp.VarBinds = append(resp.VarBinds, a.get(vb.Name))
+		}
+	case GetNextRequest:
+		for _, vb := range req.VarBinds {
+			resp.VarBinds = append(resp.VarBinds, a.getNext(vb.Name))
+		}
+	case SetRequest:
+		// Only existing variables can be set
+		for i, vb := range req.VarBinds {
+			if _, ok := a.mib[vb.Name.String()]; !ok {
+				return &SnmpPacket{Error: uint8(NoCreation), ErrorIndex: uint8(i + 1), VarBinds: req.VarBinds}
+			}
+		}
+		for _, vb := range req.VarBinds {
+			a.mib[vb.Name.String()] = vb.Value
+		}
+		resp.VarBinds = req.VarBinds
+	case GetBulkRequest:
+		// Non-repeaters and max-repetitions are decoded as error and index
+		nonRepeaters, maxRepetitions := int(req.Error), int(req.ErrorIndex)
+		for i, vb := range req.VarBinds {
+			if i < nonRepeaters {
+				resp.VarBinds = append(resp.VarBinds, a.getNext(vb.Name))
+			}
+		}
+		if nonRepeaters < len(req.VarBinds) {
+			cursors := make([]OID, 0, len(req.VarBinds))
+			for _, vb := range req.VarBinds[nonRepeaters:] {
+				cursors = append(cursors, vb.Name)
+			}
+			for r := 0; r < maxRepetitions; r++ {
+				for i, cursor := range cursors {
+					vb := a.getNext(cursor)
+					resp.VarBinds = append(resp.VarBinds, vb)
+					cursors[i] = vb.Name
+				}
+			}
+		}
+	}
+
+	if a.maxVarbinds > 0 && len(resp.VarBinds) > a.maxVarbinds {
+		return &SnmpPacket{Error: uint8(TooBig), VarBinds: req.VarBinds}
+	}
+	return resp
+}
+
+func (a *testAgent) get(oid OID) VarBind {
+	if value, ok := a.mib[oid.String()]; ok {
+		return VarBind{oid, value}
+	}
+	return VarBind{oid, NoSuchInstanceValue{}}
+}
+
+func (a *testAgent) getNext(oid OID) VarBind {
+	if a.next != nil {
+		return a.next(oid)
+	}
+	return a.lookupNext(oid)
+}
+
+func (a *testAgent) lookupNext(oid OID) VarBind {
+	i := sort.Search(len(a.oids), func(i int) bool { return a.oids[i].Compare(oid) > 0 })
+	if i == len(a.oids) {
+		return VarBind{oid, EndOfMibViewValue{}}
+	}
+	return VarBind{a.oids[i], a.mib[a.oids[i].String()]}
+}
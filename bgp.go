@@ -0,0 +1,148 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+var oidBgpPeerEntry = MustParseOID(".1.3.6.1.2.1.15.3.1")
+
+// Columns of bgpPeerTable (RFC 4273)
+const (
+	bgpPeerIdentifier                = 1
+	bgpPeerState                     = 2
+	bgpPeerAdminStatus               = 3
+	bgpPeerNegotiatedVersion         = 4
+	bgpPeerLocalAddr                 = 5
+	bgpPeerLocalPort                 = 6
+	bgpPeerRemotePort                = 8
+	bgpPeerRemoteAs                  = 9
+	bgpPeerInUpdates                 = 10
+	bgpPeerOutUpdates                = 11
+	bgpPeerInTotalMessages           = 12
+	bgpPeerOutTotalMessages          = 13
+	bgpPeerLastError                 = 14
+	bgpPeerFsmEstablishedTransitions = 15
+	bgpPeerFsmEstablishedTime        = 16
+	bgpPeerHoldTime                  = 18
+	bgpPeerKeepAlive                 = 19
+	bgpPeerInUpdateElapsedTime       = 24
+)
+
+// BGPPeerState is the state of the BGP finite state machine of a peer
+type BGPPeerState int
+
+const (
+	BGPIdle        BGPPeerState = 1
+	BGPConnect     BGPPeerState = 2
+	BGPActive      BGPPeerState = 3
+	BGPOpenSent    BGPPeerState = 4
+	BGPOpenConfirm BGPPeerState = 5
+	BGPEstablished BGPPeerState = 6
+)
+
+// String returns the label of the state, such as "established"
+func (s BGPPeerState) String() string {
+	if name, ok := BuiltinMIBs().syntaxOf(oidBgpPeerEntry.Append(bgpPeerState)).EnumName(int64(s)); ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// BGPPeer is a BGP session of a router, from a row of bgpPeerTable
+type BGPPeer struct {
+	RemoteAddr net.IP
+	// Identifier is the BGP identifier of the peer, 0.0.0.0 unless the
+	// session reached BGPOpenConfirm
+	Identifier net.IP
+	State      BGPPeerState
+	// AdminStatus is stop(1) or start(2)
+	AdminStatus       int
+	NegotiatedVersion int
+	LocalAddr         net.IP
+	LocalPort         int
+	RemotePort        int
+	// RemoteAS is the AS of the peer. bgpPeerRemoteAs only holds 2-octet
+	// numbers, so peers with 4-octet numbers are usually reported with
+	// AS_TRANS, 23456.
+	RemoteAS         uint32
+	InUpdates        uint64
+	OutUpdates       uint64
+	InTotalMessages  uint64
+	OutTotalMessages uint64
+	// LastErrorCode and LastErrorSubcode are from the last NOTIFICATION
+	// message of the session, or zero if there was none
+	LastErrorCode    int
+	LastErrorSubcode int
+	// EstablishedTransitions is the number of times the session went to
+	// BGPEstablished
+	EstablishedTransitions uint64
+	// EstablishedTime is the uptime of the session while it is established,
+	// or else the time since it was last established
+	EstablishedTime time.Duration
+	HoldTime        time.Duration
+	KeepAlive       time.Duration
+	// InUpdateElapsedTime is the time since the last UPDATE message was
+	// received
+	InUpdateElapsedTime time.Duration
+}
+
+// Established returns true if the session is up
+func (p *BGPPeer) Established() bool {
+	return p.State == BGPEstablished
+}
+
+// BGPPeers retrieves the BGP sessions of the target from BGP4-MIB
+// bgpPeerTable, which only lists IPv4 peers. Agents without BGP4-MIB return
+// no peers.
+//
+// If a request fails, the peers retrieved so far are returned along with the
+// error.
+func (x *GoSNMP) BGPPeers() ([]*BGPPeer, error) {
+	t, err := x.BulkGetTableOID(0, oidBgpPeerEntry.Parent(),
+		bgpPeerIdentifier, bgpPeerState, bgpPeerAdminStatus, bgpPeerNegotiatedVersion, bgpPeerLocalAddr,
+		bgpPeerLocalPort, bgpPeerRemotePort, bgpPeerRemoteAs, bgpPeerInUpdates, bgpPeerOutUpdates,
+		bgpPeerInTotalMessages, bgpPeerOutTotalMessages, bgpPeerLastError, bgpPeerFsmEstablishedTransitions,
+		bgpPeerFsmEstablishedTime, bgpPeerHoldTime, bgpPeerKeepAlive, bgpPeerInUpdateElapsedTime)
+	if t == nil {
+		return nil, err
+	}
+	var peers []*BGPPeer
+	// Indexed by bgpPeerRemoteAddr
+	for _, row := range t.Rows {
+		if len(row.Index) != 4 {
+			continue
+		}
+		p := &BGPPeer{RemoteAddr: oidIP(row.Index)}
+		p.Identifier = tableIP(t, row, bgpPeerIdentifier)
+		p.State = BGPPeerState(tableInt(t, row, bgpPeerState))
+		p.AdminStatus = tableInt(t, row, bgpPeerAdminStatus)
+		p.NegotiatedVersion = tableInt(t, row, bgpPeerNegotiatedVersion)
+		p.LocalAddr = tableIP(t, row, bgpPeerLocalAddr)
+		p.LocalPort = tableInt(t, row, bgpPeerLocalPort)
+		p.RemotePort = tableInt(t, row, bgpPeerRemotePort)
+		p.RemoteAS = uint32(tableInt(t, row, bgpPeerRemoteAs))
+		p.InUpdates, _ = tableUint(t, row, bgpPeerInUpdates)
+		p.OutUpdates, _ = tableUint(t, row, bgpPeerOutUpdates)
+		p.InTotalMessages, _ = tableUint(t, row, bgpPeerInTotalMessages)
+		p.OutTotalMessages, _ = tableUint(t, row, bgpPeerOutTotalMessages)
+		if lastError, _ := tableString(t, row, bgpPeerLastError); len(lastError) == 2 {
+			p.LastErrorCode = int(lastError[0])
+			p.LastErrorSubcode = int(lastError[1])
+		}
+		p.EstablishedTransitions, _ = tableUint(t, row, bgpPeerFsmEstablishedTransitions)
+		established, _ := tableUint(t, row, bgpPeerFsmEstablishedTime)
+		p.EstablishedTime = time.Duration(established) * time.Second
+		p.HoldTime = time.Duration(tableInt(t, row, bgpPeerHoldTime)) * time.Second
+		p.KeepAlive = time.Duration(tableInt(t, row, bgpPeerKeepAlive)) * time.Second
+		elapsed, _ := tableUint(t, row, bgpPeerInUpdateElapsedTime)
+		p.InUpdateElapsedTime = time.Duration(elapsed) * time.Second
+		peers = append(peers, p)
+	}
+	return peers, err
+}
@@ -0,0 +1,50 @@
+package gosnmp
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// Test reading BGP peers from bgpPeerTable
+func TestBGPPeers(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.15.3.1.1.192.0.2.1":  IPAddressValue(net.ParseIP("198.51.100.1")),
+		".1.3.6.1.2.1.15.3.1.2.192.0.2.1":  Integer32Value(6),
+		".1.3.6.1.2.1.15.3.1.3.192.0.2.1":  Integer32Value(2),
+		".1.3.6.1.2.1.15.3.1.4.192.0.2.1":  Integer32Value(4),
+		".1.3.6.1.2.1.15.3.1.5.192.0.2.1":  IPAddressValue(net.ParseIP("192.0.2.2")),
+		".1.3.6.1.2.1.15.3.1.6.192.0.2.1":  Integer32Value(179),
+		".1.3.6.1.2.1.15.3.1.8.192.0.2.1":  Integer32Value(51234),
+		".1.3.6.1.2.1.15.3.1.9.192.0.2.1":  Integer32Value(64512),
+		".1.3.6.1.2.1.15.3.1.10.192.0.2.1": Counter32Value(120),
+		".1.3.6.1.2.1.15.3.1.11.192.0.2.1": Counter32Value(80),
+		".1.3.6.1.2.1.15.3.1.14.192.0.2.1": OctetStringValue{6, 2},
+		".1.3.6.1.2.1.15.3.1.15.192.0.2.1": Counter32Value(3),
+		".1.3.6.1.2.1.15.3.1.16.192.0.2.1": Gauge32Value(3600),
+		".1.3.6.1.2.1.15.3.1.18.192.0.2.1": Integer32Value(90),
+		".1.3.6.1.2.1.15.3.1.19.192.0.2.1": Integer32Value(30),
+		".1.3.6.1.2.1.15.3.1.2.192.0.2.5":  Integer32Value(3),
+		".1.3.6.1.2.1.15.3.1.9.192.0.2.5":  Integer32Value(23456),
+	})
+	peers, err := agent.client().BGPPeers()
+	if err != nil {
+		t.Fatalf("BGPPeers: %s", err)
+	}
+	if len(peers) != 2 {
+		t.Fatalf("BGPPeers: %+v", peers)
+	}
+	p := peers[0]
+	if p.RemoteAddr.String() != "192.0.2.1" || p.Identifier.String() != "198.51.100.1" || !p.Established() ||
+		p.State.String() != "established" || p.AdminStatus != 2 || p.NegotiatedVersion != 4 ||
+		p.LocalAddr.String() != "192.0.2.2" || p.LocalPort != 179 || p.RemotePort != 51234 || p.RemoteAS != 64512 {
+		t.Errorf("Peer: %+v", p)
+	}
+	if p.InUpdates != 120 || p.OutUpdates != 80 || p.LastErrorCode != 6 || p.LastErrorSubcode != 2 ||
+		p.EstablishedTransitions != 3 || p.EstablishedTime != time.Hour || p.HoldTime != 90*time.Second || p.KeepAlive != 30*time.Second {
+		t.Errorf("Peer counters: %+v", p)
+	}
+	if peers[1].State != BGPActive || peers[1].State.String() != "active" || peers[1].RemoteAS != 23456 {
+		t.Errorf("Second peer: %+v", peers[1])
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"context"
+	"fmt"
+)
+
+// WalkStrategy selects the requests used to walk a subtree
+type WalkStrategy uint8
+
+const (
+	// WalkGetNext walks with one GetNext request per variable
+	WalkGetNext WalkStrategy = iota
+	// WalkGetBulk walks with GetBulk requests
+	WalkGetBulk
+)
+
+func (s WalkStrategy) String() string {
+	if s == WalkGetBulk {
+		return "getbulk"
+	}
+	return "getnext"
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s WalkStrategy) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *WalkStrategy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "getnext":
+		*s = WalkGetNext
+	case "getbulk":
+		*s = WalkGetBulk
+	default:
+		return fmt.Errorf("Unknown walk strategy %q", text)
+	}
+	return nil
+}
+
+// WalkCheckpoint records the progress of a walk. It can be serialised (for
+// example with encoding/json) and later passed to ResumeWalk to continue the
+// walk after the last variable that was handled, even from another process.
+type WalkCheckpoint struct {
+	Root           OID          `json:"root"`
+	Last           OID          `json:"last"`
+	Strategy       WalkStrategy `json:"strategy"`
+	MaxRepetitions uint8        `json:"max_repetitions,omitempty"`
+	// Varbinds is the number of variables handled so far
+	Varbinds int `json:"varbinds"`
+	// Done is set once the walk has reached the end of the subtree
+	Done bool `json:"done"`
+}
+
+// NewWalkCheckpoint returns a checkpoint at the start of a walk of the
+// subtree rooted at oid. Symbolic names are resolved with the MIBs of the
+// client, as for walks.
+func (x *GoSNMP) NewWalkCheckpoint(oid string, strategy WalkStrategy, maxRepetitions uint8) (*WalkCheckpoint, error) {
+	root, err := walkRoot(x.mibs(), oid)
+	if err != nil {
+		return nil, err
+	}
+	return NewWalkCheckpointOID(root, strategy, maxRepetitions), nil
+}
+
+// NewWalkCheckpointOID returns a checkpoint at the start of a walk of the
+// subtree rooted at root
+func NewWalkCheckpointOID(root OID, strategy WalkStrategy, maxRepetitions uint8) *WalkCheckpoint {
+	return &WalkCheckpoint{
+		Root:           root.Copy(),
+		Last:           root.Copy(),
+		Strategy:       strategy,
+		MaxRepetitions: maxRepetitions,
+	}
+}
+
+// ResumeWalk continues the walk recorded in cp, calling fn for every variable
+// after cp.Last. The checkpoint is advanced after each call to fn that
+// returns nil or StopWalk, so when ResumeWalk fails (a timeout, say) the
+// checkpoint can be saved and the walk resumed later without fetching the
+// handled variables again.
+func (x *GoSNMP) ResumeWalk(ctx context.Context, cp *WalkCheckpoint, fn func(pdu SnmpPDU) error) error {
+	w, err := x.resumeWalker(ctx, cp)
+	if w == nil {
+		return err
+	}
+	return cp.finish(w.run(func(pdu SnmpPDU) error {
+		return cp.handled(pdu.OID(), fn(pdu))
+	}))
+}
+
+// ResumeWalkValues is like ResumeWalk, but calls fn with typed VarBinds
+func (x *GoSNMP) ResumeWalkValues(ctx context.Context, cp *WalkCheckpoint, fn func(vb VarBind) error) error {
+	w, err := x.resumeWalker(ctx, cp)
+	if w == nil {
+		return err
+	}
+	return cp.finish(w.runValues(func(vb VarBind) error {
+		return cp.handled(vb.Name, fn(vb))
+	}))
+}
+
+// resumeWalker returns a walker continuing after cp.Last, or nil if the walk
+// is done or the checkpoint is invalid
+func (x *GoSNMP) resumeWalker(ctx context.Context, cp *WalkCheckpoint) (*walker, error) {
+	if cp.Done {
+		return nil, nil
+	}
+	if len(cp.Root) == 0 {
+		return nil, fmt.Errorf("No OID given\n")
+	}
+	if len(cp.Last) == 0 || !cp.Last.HasPrefix(cp.Root) {
+		return nil, fmt.Errorf("Checkpoint OID %s is outside of %s", cp.Last, cp.Root)
+	}
+
+	w := x.newWalker(ctx, cp.Root, cp.Strategy == WalkGetBulk, cp.MaxRepetitions)
+	w.last = cp.Last.Copy()
+	w.next = cp.Last.Copy()
+	return w, nil
+}
+
+// handled advances the checkpoint past oid unless fn failed with err
+func (cp *WalkCheckpoint) handled(oid OID, err error) error {
+	if err == nil || err == StopWalk {
+		cp.Last = oid
+		cp.Varbinds++
+	}
+	return err
+}
+
+// finish marks the checkpoint done if the walk completed
+func (cp *WalkCheckpoint) finish(err error) error {
+	if err == nil {
+		cp.Done = true
+	}
+	return stopped(err)
+}
@@ -0,0 +1,97 @@
+package gosnmp
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test a walk interrupted by timeouts can be resumed from its checkpoint
+func TestResumeWalk(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+	s.Timeout = 100 * time.Millisecond
+
+	// The agent stops answering after three requests
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if len(agent.requests) > 3 {
+			return nil
+		}
+		return agent.handle(req)
+	})
+
+	cp, err := s.NewWalkCheckpoint(".1.3.6.1.2.1.2", WalkGetNext, 0)
+	if err != nil {
+		t.Fatalf("Unable to create checkpoint: %s", err)
+	}
+
+	var names []string
+	collect := func(pdu SnmpPDU) error {
+		names = append(names, pdu.Name)
+		return nil
+	}
+
+	if err := s.ResumeWalk(context.Background(), cp, collect); err == nil {
+		t.Fatalf("Expected error when the agent stops responding")
+	}
+	if cp.Done || cp.Varbinds != 3 || cp.Last.String() != ".1.3.6.1.2.1.2.2.1.1.2" {
+		t.Fatalf("Checkpoint after failure: %+v", cp)
+	}
+
+	// Save and restore the checkpoint, as after a restart
+	saved, err := json.Marshal(cp)
+	if err != nil {
+		t.Fatalf("Unable to marshal checkpoint: %s", err)
+	}
+	restored := new(WalkCheckpoint)
+	if err := json.Unmarshal(saved, restored); err != nil {
+		t.Fatalf("Unable to unmarshal checkpoint %s: %s", saved, err)
+	}
+	if !reflect.DeepEqual(restored, cp) {
+		t.Fatalf("Checkpoint round trip:\n\twant: %+v\n\tgot : %+v", cp, restored)
+	}
+
+	agent.setRespond(nil)
+	restored.Strategy = WalkGetBulk
+	if err := s.ResumeWalk(context.Background(), restored, collect); err != nil {
+		t.Fatalf("Unable to resume walk: %s", err)
+	}
+	if !restored.Done || restored.Varbinds != 9 || len(names) != 9 {
+		t.Errorf("Resumed walk:\n\twant: 9 variables\n\tgot : %v (%+v)", names, restored)
+	}
+}
+
+// Test resuming a walk from an OID checkpoint with typed variables
+func TestResumeWalkValues(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	root := MustParseOID(".1.3.6.1.2.1.2")
+	want, err := s.WalkValues(root)
+	if err != nil {
+		t.Fatalf("Unable to perform walk: %s", err)
+	}
+
+	cp := NewWalkCheckpointOID(root, WalkGetBulk, 2)
+	var got []VarBind
+	stopAfter := func(n int) func(vb VarBind) error {
+		return func(vb VarBind) error {
+			got = append(got, vb)
+			if len(got) == n {
+				return StopWalk
+			}
+			return nil
+		}
+	}
+	if err := s.ResumeWalkValues(context.Background(), cp, stopAfter(3)); err != nil || cp.Done {
+		t.Fatalf("Stopped walk: %+v %v", cp, err)
+	}
+	if err := s.ResumeWalkValues(context.Background(), cp, stopAfter(0)); err != nil || !cp.Done {
+		t.Fatalf("Resumed walk: %+v %v", cp, err)
+	}
+	if !reflect.DeepEqual(got, want) || cp.Varbinds != len(want) {
+		t.Errorf("ResumeWalkValues:\n\twant: %v\n\tgot : %v", want, got)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+var (
+	oidDot1qVlanCurrentTable = MustParseOID(".1.3.6.1.2.1.17.7.1.4.2")
+	// CISCO-VTP-MIB::vtpVlanState, indexed by management domain and VLAN
+	oidVtpVlanState = MustParseOID(".1.3.6.1.4.1.9.9.46.1.3.1.1.2")
+)
+
+// dot1qVlanStatus column of dot1qVlanCurrentTable (RFC 4363)
+const dot1qVlanStatus = 6
+
+// ContextPDU is a variable retrieved from a context of the agent
+type ContextPDU struct {
+	Context string
+	SnmpPDU
+}
+
+// WithContext returns a copy of the client that queries a context of the
+// agent, such as "20" for the BRIDGE-MIB instance of VLAN 20. The copy
+// shares the connection of the client, so the two must not be used
+// concurrently.
+func (x *GoSNMP) WithContext(context string) *GoSNMP {
+	c := *x
+	c.Context = context
+	return &c
+}
+
+// VLANs returns the VLANs of a switch from Q-BRIDGE-MIB dot1qVlanCurrentTable,
+// or from CISCO-VTP-MIB on Cisco switches, which only have the former per
+// VLAN. The default VLANs 1002 to 1005 of CISCO-VTP-MIB, which have no
+// context, and VLANs that are not operational are left out.
+func (x *GoSNMP) VLANs() ([]int, error) {
+	seen := make(map[int]bool)
+	var vlans []int
+
+	// Indexed by dot1qVlanTimeMark and dot1qVlanIndex
+	current, err := x.BulkGetTableOID(0, oidDot1qVlanCurrentTable, dot1qVlanStatus)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range current.Rows {
+		if len(row.Index) == 2 && !seen[int(row.Index[1])] {
+			seen[int(row.Index[1])] = true
+			vlans = append(vlans, int(row.Index[1]))
+		}
+	}
+	if len(vlans) > 0 {
+		sort.Ints(vlans)
+		return vlans, nil
+	}
+
+	err = x.BulkWalkValuesFunc(0, oidVtpVlanState, func(vb VarBind) error {
+		suffix := vb.Name[len(oidVtpVlanState):]
+		// vtpVlanState operational(1)
+		if len(suffix) != 2 || vb.Value != Integer32Value(1) {
+			return nil
+		}
+		if vlan := int(suffix[1]); !seen[vlan] && (vlan < 1002 || vlan > 1005) {
+			seen[vlan] = true
+			vlans = append(vlans, vlan)
+		}
+		return nil
+	})
+	sort.Ints(vlans)
+	return vlans, err
+}
+
+// WalkContexts walks oid in each of the contexts with GetBulk requests
+// (GetNext for SNMPv1), tagging the variables with their context. A context
+// that fails does not stop the others; the first error is returned after
+// all contexts were walked, along with the variables retrieved.
+func (x *GoSNMP) WalkContexts(maxRepetitions uint8, oid string, contexts ...string) ([]ContextPDU, error) {
+	var results []ContextPDU
+	var first error
+	for _, context := range contexts {
+		err := x.WithContext(context).BulkWalkFunc(maxRepetitions, oid, func(pdu SnmpPDU) error {
+			results = append(results, ContextPDU{context, pdu})
+			return nil
+		})
+		if err != nil && first == nil {
+			first = fmt.Errorf("Context %s: %s", context, err)
+		}
+	}
+	return results, first
+}
+
+// WalkVLANs walks oid in the context of each VLAN returned by VLANs
+func (x *GoSNMP) WalkVLANs(maxRepetitions uint8, oid string) ([]ContextPDU, error) {
+	vlans, err := x.VLANs()
+	if err != nil {
+		return nil, err
+	}
+	return x.WalkContexts(maxRepetitions, oid, vlanContexts(vlans)...)
+}
+
+// VLANMACTable retrieves the forwarding database of each VLAN from its
+// context, for switches that only have BRIDGE-MIB per VLAN. The FDBID of the
+// entries is set to their VLAN. A VLAN that fails does not stop the others;
+// the first error is returned after all VLANs were tried.
+func (x *GoSNMP) VLANMACTable() ([]*FDBEntry, error) {
+	vlans, err := x.VLANs()
+	if err != nil {
+		return nil, err
+	}
+	var entries []*FDBEntry
+	var first error
+	for _, vlan := range vlans {
+		context := strconv.Itoa(vlan)
+		vlanEntries, err := x.WithContext(context).MACTable()
+		for _, e := range vlanEntries {
+			if e.FDBID == 0 {
+				e.FDBID = uint32(vlan)
+			}
+		}
+		entries = append(entries, vlanEntries...)
+		if err != nil && first == nil {
+			first = fmt.Errorf("Context %s: %s", context, err)
+		}
+	}
+	return entries, first
+}
+
+func vlanContexts(vlans []int) []string {
+	contexts := make([]string, len(vlans))
+	for i, vlan := range vlans {
+		contexts[i] = strconv.Itoa(vlan)
+	}
+	return contexts
+}
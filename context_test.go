@@ -0,0 +1,104 @@
+package gosnmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newContextAgent returns an agent serving the default MIB view to the plain
+// community and a view per context to "public@context"
+func newContextAgent(t *testing.T, mib map[string]Value, contexts map[string]map[string]Value) *testAgent {
+	agent := newTestAgent(t, mib)
+	views := make(map[string]*testAgent)
+	for context, view := range contexts {
+		views["public@"+context] = newTestAgent(t, view)
+	}
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if view := views[req.Community]; view != nil {
+			return view.handle(req)
+		}
+		if req.Community != "public" {
+			return nil
+		}
+		return agent.handle(req)
+	})
+	return agent
+}
+
+// Test sending requests to a context with community string indexing
+func TestWithContext(t *testing.T) {
+	agent := newContextAgent(t, map[string]Value{
+		".1.3.6.1.2.1.1.5.0": OctetStringValue("switch"),
+	}, map[string]map[string]Value{
+		"20": {".1.3.6.1.2.1.1.5.0": OctetStringValue("vlan 20")},
+	})
+	s := agent.client()
+
+	vlan := s.WithContext("20")
+	if res, err := vlan.GetValues(oidSysName); err != nil || res.VarBinds[0].Value.String() != "vlan 20" {
+		t.Errorf("Context 20: %v %v", res, err)
+	}
+	if res, err := s.GetValues(oidSysName); err != nil || res.VarBinds[0].Value.String() != "switch" {
+		t.Errorf("Default context: %v %v", res, err)
+	}
+	if s.Context != "" || vlan.Community != "public" {
+		t.Errorf("WithContext altered the client: %+v", s)
+	}
+}
+
+// Test enumerating VLANs from Q-BRIDGE-MIB and CISCO-VTP-MIB
+func TestVLANs(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.17.7.1.4.2.1.6.0.20": Integer32Value(2),
+		".1.3.6.1.2.1.17.7.1.4.2.1.6.0.1":  Integer32Value(2),
+	})
+	if vlans, err := agent.client().VLANs(); err != nil || !reflect.DeepEqual(vlans, []int{1, 20}) {
+		t.Errorf("Q-BRIDGE-MIB VLANs: %v %v", vlans, err)
+	}
+
+	agent = newTestAgent(t, map[string]Value{
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.1":    Integer32Value(1),
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.30":   Integer32Value(1),
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.40":   Integer32Value(2),
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.1002": Integer32Value(1),
+	})
+	if vlans, err := agent.client().VLANs(); err != nil || !reflect.DeepEqual(vlans, []int{1, 30}) {
+		t.Errorf("CISCO-VTP-MIB VLANs: %v %v", vlans, err)
+	}
+}
+
+// Test walking a table in each VLAN context
+func TestWalkVLANs(t *testing.T) {
+	agent := newContextAgent(t, map[string]Value{
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.10": Integer32Value(1),
+		".1.3.6.1.4.1.9.9.46.1.3.1.1.2.1.20": Integer32Value(1),
+	}, map[string]map[string]Value{
+		"10": {
+			".1.3.6.1.2.1.17.1.4.1.2.1":             Integer32Value(101),
+			".1.3.6.1.2.1.17.4.3.1.2.0.27.33.1.2.3": Integer32Value(1),
+			".1.3.6.1.2.1.17.4.3.1.3.0.27.33.1.2.3": Integer32Value(3),
+		},
+		"20": {
+			".1.3.6.1.2.1.17.1.4.1.2.2":             Integer32Value(102),
+			".1.3.6.1.2.1.17.4.3.1.2.0.27.33.1.2.4": Integer32Value(2),
+			".1.3.6.1.2.1.17.4.3.1.3.0.27.33.1.2.4": Integer32Value(3),
+		},
+	})
+	s := agent.client()
+
+	results, err := s.WalkVLANs(0, ".1.3.6.1.2.1.17.1.4.1.2")
+	if err != nil {
+		t.Fatalf("WalkVLANs: %s", err)
+	}
+	if len(results) != 2 || results[0].Context != "10" || results[0].Value != 101 || results[1].Context != "20" || results[1].Value != 102 {
+		t.Errorf("WalkVLANs: %+v", results)
+	}
+
+	entries, err := s.VLANMACTable()
+	if err != nil {
+		t.Fatalf("VLANMACTable: %s", err)
+	}
+	if len(entries) != 2 || entries[0].FDBID != 10 || entries[0].IfIndex != 101 || entries[1].FDBID != 20 || entries[1].IfIndex != 102 {
+		t.Errorf("VLANMACTable: %+v", entries)
+	}
+}
@@ -102,7 +102,9 @@ func decodeValue(valueType Asn1BER, data []byte) (retVal *Variable, err error) {
 		retVal.Value = string(data)
 	case ObjectIdentifier:
 		retVal.Type = ObjectIdentifier
-		retVal.Value, _ = parseObjectIdentifier(data)
+		if oid, err := parseObjectIdentifier(data); err == nil {
+			retVal.Value = oid.ints()
+		}
 	// IpAddress
 	case IpAddress:
 		retVal.Type = IpAddress
@@ -158,7 +160,7 @@ func decodeValue(valueType Asn1BER, data []byte) (retVal *Variable, err error) {
 
 // Parses UINT16
 func ParseUint16(content []byte) int {
-	number := uint8(content[1]) | uint8(content[0])<<8
+	number := uint16(content[1]) | uint16(content[0])<<8
 	//fmt.Printf("\t%d\n", number)
 
 	return int(number)
@@ -0,0 +1,296 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var (
+	oidEntPhysicalTable  = MustParseOID(".1.3.6.1.2.1.47.1.1.1")
+	oidEntPhySensorTable = MustParseOID(".1.3.6.1.2.1.99.1.1")
+)
+
+// Columns of entPhysicalTable (RFC 6933)
+const (
+	entPhysicalDescr        = 2
+	entPhysicalVendorType   = 3
+	entPhysicalContainedIn  = 4
+	entPhysicalClass        = 5
+	entPhysicalParentRelPos = 6
+	entPhysicalName         = 7
+	entPhysicalHardwareRev  = 8
+	entPhysicalFirmwareRev  = 9
+	entPhysicalSoftwareRev  = 10
+	entPhysicalSerialNum    = 11
+	entPhysicalMfgName      = 12
+	entPhysicalModelName    = 13
+	entPhysicalAlias        = 14
+	entPhysicalAssetID      = 15
+	entPhysicalIsFRU        = 16
+	entPhysicalMfgDate      = 17
+)
+
+// Columns of entPhySensorTable (RFC 3433)
+const (
+	entPhySensorType         = 1
+	entPhySensorScale        = 2
+	entPhySensorPrecision    = 3
+	entPhySensorValue        = 4
+	entPhySensorOperStatus   = 5
+	entPhySensorUnitsDisplay = 6
+)
+
+// PhysicalClass is the general type of a physical entity
+type PhysicalClass int
+
+const (
+	PhysicalOther        PhysicalClass = 1
+	PhysicalUnknown      PhysicalClass = 2
+	PhysicalChassis      PhysicalClass = 3
+	PhysicalBackplane    PhysicalClass = 4
+	PhysicalContainer    PhysicalClass = 5
+	PhysicalPowerSupply  PhysicalClass = 6
+	PhysicalFan          PhysicalClass = 7
+	PhysicalSensor       PhysicalClass = 8
+	PhysicalModule       PhysicalClass = 9
+	PhysicalPort         PhysicalClass = 10
+	PhysicalStack        PhysicalClass = 11
+	PhysicalCPU          PhysicalClass = 12
+	PhysicalEnergyObject PhysicalClass = 13
+	PhysicalBattery      PhysicalClass = 14
+	PhysicalStorageDrive PhysicalClass = 15
+)
+
+// String returns the label of the class, such as "powerSupply"
+func (c PhysicalClass) String() string {
+	if name, ok := BuiltinMIBs().typeSyntax("PhysicalClass").EnumName(int64(c)); ok {
+		return name
+	}
+	return strconv.Itoa(int(c))
+}
+
+// SensorType is the type of data a sensor reports, such as 8 for celsius
+type SensorType int
+
+// String returns the label of the type, such as "celsius"
+func (t SensorType) String() string {
+	if name, ok := BuiltinMIBs().typeSyntax("EntitySensorDataType").EnumName(int64(t)); ok {
+		return name
+	}
+	return strconv.Itoa(int(t))
+}
+
+// Inventory is the physical inventory of a device: the entities of
+// entPhysicalTable arranged by containment
+type Inventory struct {
+	// Roots are the entities not contained in any other, usually the
+	// chassis or stack
+	Roots []*PhysicalEntity
+	// Entities holds all entities, sorted by index
+	Entities []*PhysicalEntity
+
+	byIndex map[uint32]*PhysicalEntity
+}
+
+// Entity returns the entity with the given entPhysicalIndex, or nil if there
+// is none
+func (inv *Inventory) Entity(index uint32) *PhysicalEntity {
+	return inv.byIndex[index]
+}
+
+// PhysicalEntity is a physical component of a device, from a row of
+// entPhysicalTable
+type PhysicalEntity struct {
+	Index uint32
+	Descr string
+	// VendorType is the vendor specific hardware type, or nil if unknown
+	VendorType OID
+	Class      PhysicalClass
+	// ParentRelPos is the position among the siblings of the same class,
+	// such as the slot number, or -1 if unknown
+	ParentRelPos int
+	Name         string
+	HardwareRev  string
+	FirmwareRev  string
+	SoftwareRev  string
+	SerialNum    string
+	MfgName      string
+	ModelName    string
+	Alias        string
+	AssetID      string
+	// IsFRU is set for field replaceable units
+	IsFRU bool
+	// MfgDate is the zero time if unknown
+	MfgDate time.Time
+
+	// Parent is the entity this one is contained in, or nil for a root
+	Parent *PhysicalEntity
+	// Children are sorted by ParentRelPos and index
+	Children []*PhysicalEntity
+	// Sensor is the reading of the entity from entPhySensorTable, or nil
+	// if it is not a sensor
+	Sensor *Sensor
+}
+
+// Sensor is a reading of a physical sensor, from a row of
+// entPhySensorTable
+type Sensor struct {
+	Type SensorType
+	// Scale is the EntitySensorDataScale, such as 8 for milli and 9 for
+	// units
+	Scale int
+	// Precision is the number of decimal places in RawValue, or if
+	// negative, the number of trailing zeros it drops
+	Precision int
+	RawValue  int
+	// Value is RawValue scaled by Scale and Precision into units, such as
+	// volts or degrees Celsius
+	Value float64
+	// OperStatus is 1 (ok), 2 (unavailable) or 3 (nonoperational). The
+	// value is only meaningful if ok.
+	OperStatus int
+	// Units is the units the agent displays the value in, such as "Volts"
+	Units string
+}
+
+// Inventory retrieves the physical entities of the target from ENTITY-MIB,
+// arranged by entPhysicalContainedIn, and joins the readings of the sensors
+// among them from ENTITY-SENSOR-MIB. Agents without ENTITY-SENSOR-MIB return
+// an inventory without sensors.
+//
+// If a request fails, the entities retrieved so far are returned along with
+// the error.
+func (x *GoSNMP) Inventory() (*Inventory, error) {
+	table, err := x.BulkGetTableOID(0, oidEntPhysicalTable,
+		entPhysicalDescr, entPhysicalVendorType, entPhysicalContainedIn, entPhysicalClass,
+		entPhysicalParentRelPos, entPhysicalName, entPhysicalHardwareRev, entPhysicalFirmwareRev,
+		entPhysicalSoftwareRev, entPhysicalSerialNum, entPhysicalMfgName, entPhysicalModelName,
+		entPhysicalAlias, entPhysicalAssetID, entPhysicalIsFRU, entPhysicalMfgDate)
+	if table == nil {
+		return nil, err
+	}
+
+	inv := &Inventory{byIndex: make(map[uint32]*PhysicalEntity)}
+	containedIn := make(map[*PhysicalEntity]uint32)
+	for _, row := range table.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		e := &PhysicalEntity{Index: row.Index[0]}
+		e.setPhysicalEntry(table, row)
+		inv.Entities = append(inv.Entities, e)
+		inv.byIndex[e.Index] = e
+		if parent, ok := tableUint(table, row, entPhysicalContainedIn); ok {
+			containedIn[e] = uint32(parent)
+		}
+	}
+	inv.arrange(containedIn)
+	if err != nil {
+		return inv, err
+	}
+
+	sensors, err := x.BulkGetTableOID(0, oidEntPhySensorTable,
+		entPhySensorType, entPhySensorScale, entPhySensorPrecision, entPhySensorValue,
+		entPhySensorOperStatus, entPhySensorUnitsDisplay)
+	if sensors == nil {
+		return inv, err
+	}
+	for _, row := range sensors.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		if e := inv.byIndex[row.Index[0]]; e != nil {
+			e.Sensor = newSensor(sensors, row)
+		}
+	}
+	return inv, err
+}
+
+// setPhysicalEntry fills in the entity from its entPhysicalTable row
+func (e *PhysicalEntity) setPhysicalEntry(t *Table, row *TableRow) {
+	e.Descr, _ = tableString(t, row, entPhysicalDescr)
+	// zeroDotZero (0.0) means the vendor type is unknown
+	if oid := tableOID(t, row, entPhysicalVendorType); len(oid) > 0 && !oid.Equal(OID{0, 0}) {
+		e.VendorType = oid
+	}
+	e.Class = PhysicalClass(tableInt(t, row, entPhysicalClass))
+	e.ParentRelPos = -1
+	if pdu, ok := t.Cell(row, entPhysicalParentRelPos); ok {
+		if n, ok := pdu.Value.(int); ok {
+			e.ParentRelPos = n
+		}
+	}
+	e.Name, _ = tableString(t, row, entPhysicalName)
+	e.HardwareRev, _ = tableString(t, row, entPhysicalHardwareRev)
+	e.FirmwareRev, _ = tableString(t, row, entPhysicalFirmwareRev)
+	e.SoftwareRev, _ = tableString(t, row, entPhysicalSoftwareRev)
+	e.SerialNum, _ = tableString(t, row, entPhysicalSerialNum)
+	e.MfgName, _ = tableString(t, row, entPhysicalMfgName)
+	e.ModelName, _ = tableString(t, row, entPhysicalModelName)
+	e.Alias, _ = tableString(t, row, entPhysicalAlias)
+	e.AssetID, _ = tableString(t, row, entPhysicalAssetID)
+	// TruthValue: true(1), false(2)
+	e.IsFRU = tableInt(t, row, entPhysicalIsFRU) == 1
+	if date, ok := tableString(t, row, entPhysicalMfgDate); ok {
+		// An unknown date is all zeros, which does not parse
+		e.MfgDate, _ = ParseDateAndTime([]byte(date))
+	}
+}
+
+// arrange links the entities into a tree by their entPhysicalContainedIn.
+// Entities contained in an entity that does not exist, or that would make a
+// cycle, are made roots.
+func (inv *Inventory) arrange(containedIn map[*PhysicalEntity]uint32) {
+	for _, e := range inv.Entities {
+		parent := inv.byIndex[containedIn[e]]
+		for p := parent; p != nil; p = p.Parent {
+			if p == e {
+				parent = nil
+				break
+			}
+		}
+		if parent == nil {
+			inv.Roots = append(inv.Roots, e)
+			continue
+		}
+		e.Parent = parent
+		parent.Children = append(parent.Children, e)
+	}
+
+	for _, e := range inv.Entities {
+		children := e.Children
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].ParentRelPos < children[j].ParentRelPos
+		})
+	}
+}
+
+// newSensor decodes a row of entPhySensorTable
+func newSensor(t *Table, row *TableRow) *Sensor {
+	s := &Sensor{
+		Type:       SensorType(tableInt(t, row, entPhySensorType)),
+		Scale:      tableInt(t, row, entPhySensorScale),
+		Precision:  tableInt(t, row, entPhySensorPrecision),
+		RawValue:   tableInt(t, row, entPhySensorValue),
+		OperStatus: tableInt(t, row, entPhySensorOperStatus),
+	}
+	s.Units, _ = tableString(t, row, entPhySensorUnitsDisplay)
+	// units(9) is 10^0, and each step is a factor of 1000. Dividing by an
+	// exact power of ten keeps values such as 3.3 exact.
+	exp := -s.Precision
+	if s.Scale != 0 {
+		exp += 3 * (s.Scale - 9)
+	}
+	if exp < 0 {
+		s.Value = float64(s.RawValue) / math.Pow10(-exp)
+	} else {
+		s.Value = float64(s.RawValue) * math.Pow10(exp)
+	}
+	return s
+}
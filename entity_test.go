@@ -0,0 +1,120 @@
+package gosnmp
+
+import (
+	"testing"
+	"time"
+)
+
+// entityMIB is an agent view of a chassis holding a power supply with a
+// voltage sensor and a module in slot 2
+var entityMIB = map[string]Value{
+	".1.3.6.1.2.1.47.1.1.1.1.2.1":  OctetStringValue("Router chassis"),
+	".1.3.6.1.2.1.47.1.1.1.1.3.1":  ObjectIdentifierValue{1, 3, 6, 1, 4, 1, 9, 12, 3, 1, 3, 1},
+	".1.3.6.1.2.1.47.1.1.1.1.4.1":  Integer32Value(0),
+	".1.3.6.1.2.1.47.1.1.1.1.5.1":  Integer32Value(3),
+	".1.3.6.1.2.1.47.1.1.1.1.6.1":  Integer32Value(-1),
+	".1.3.6.1.2.1.47.1.1.1.1.11.1": OctetStringValue("FOX1234"),
+	".1.3.6.1.2.1.47.1.1.1.1.13.1": OctetStringValue("ISR4331"),
+	".1.3.6.1.2.1.47.1.1.1.1.16.1": Integer32Value(2),
+	".1.3.6.1.2.1.47.1.1.1.1.17.1": OctetStringValue{0x07, 0xe4, 3, 15, 0, 0, 0, 0},
+	".1.3.6.1.2.1.47.1.1.1.1.2.2":  OctetStringValue("Module"),
+	".1.3.6.1.2.1.47.1.1.1.1.3.2":  ObjectIdentifierValue{0, 0},
+	".1.3.6.1.2.1.47.1.1.1.1.4.2":  Integer32Value(1),
+	".1.3.6.1.2.1.47.1.1.1.1.5.2":  Integer32Value(9),
+	".1.3.6.1.2.1.47.1.1.1.1.6.2":  Integer32Value(2),
+	".1.3.6.1.2.1.47.1.1.1.1.16.2": Integer32Value(1),
+	".1.3.6.1.2.1.47.1.1.1.1.2.3":  OctetStringValue("Power supply"),
+	".1.3.6.1.2.1.47.1.1.1.1.4.3":  Integer32Value(1),
+	".1.3.6.1.2.1.47.1.1.1.1.5.3":  Integer32Value(6),
+	".1.3.6.1.2.1.47.1.1.1.1.6.3":  Integer32Value(0),
+	".1.3.6.1.2.1.47.1.1.1.1.2.4":  OctetStringValue("PSU voltage"),
+	".1.3.6.1.2.1.47.1.1.1.1.4.4":  Integer32Value(3),
+	".1.3.6.1.2.1.47.1.1.1.1.5.4":  Integer32Value(8),
+	".1.3.6.1.2.1.99.1.1.1.1.4":    Integer32Value(4),
+	".1.3.6.1.2.1.99.1.1.1.2.4":    Integer32Value(8),
+	".1.3.6.1.2.1.99.1.1.1.3.4":    Integer32Value(1),
+	".1.3.6.1.2.1.99.1.1.1.4.4":    Integer32Value(120125),
+	".1.3.6.1.2.1.99.1.1.1.5.4":    Integer32Value(1),
+	".1.3.6.1.2.1.99.1.1.1.6.4":    OctetStringValue("Volts"),
+}
+
+// Test building the physical inventory tree with sensor readings
+func TestInventory(t *testing.T) {
+	agent := newTestAgent(t, entityMIB)
+	inv, err := agent.client().Inventory()
+	if err != nil {
+		t.Fatalf("Inventory: %s", err)
+	}
+	if len(inv.Entities) != 4 || len(inv.Roots) != 1 {
+		t.Fatalf("Expected 4 entities and 1 root, got %d and %d", len(inv.Entities), len(inv.Roots))
+	}
+
+	chassis := inv.Roots[0]
+	if chassis.Index != 1 || chassis.Class != PhysicalChassis || chassis.SerialNum != "FOX1234" || chassis.ModelName != "ISR4331" ||
+		chassis.IsFRU || chassis.VendorType.String() != ".1.3.6.1.4.1.9.12.3.1.3.1" || chassis.Parent != nil {
+		t.Errorf("Chassis: %+v", chassis)
+	}
+	if want := time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC); !chassis.MfgDate.Equal(want) {
+		t.Errorf("MfgDate:\n\twant: %s\n\tgot : %s", want, chassis.MfgDate)
+	}
+	// Children are ordered by their relative position
+	if len(chassis.Children) != 2 || chassis.Children[0].Index != 3 || chassis.Children[1].Index != 2 {
+		t.Fatalf("Chassis children: %v", chassis.Children)
+	}
+	module := inv.Entity(2)
+	if module.Class != PhysicalModule || !module.IsFRU || module.VendorType != nil || module.Parent != chassis || module.ParentRelPos != 2 {
+		t.Errorf("Module: %+v", module)
+	}
+
+	sensor := inv.Entity(4)
+	if sensor.Parent != inv.Entity(3) || sensor.Sensor == nil {
+		t.Fatalf("Sensor: %+v", sensor)
+	}
+	if s := sensor.Sensor; s.Type.String() != "voltsDC" || s.RawValue != 120125 || s.Value != 12.0125 || s.OperStatus != 1 || s.Units != "Volts" {
+		t.Errorf("Sensor reading: %+v", s)
+	}
+	if module.Sensor != nil {
+		t.Errorf("Module has a sensor reading: %+v", module.Sensor)
+	}
+}
+
+// Test that containment cycles and dangling parents are broken into roots
+func TestInventoryArrange(t *testing.T) {
+	a, b, c := &PhysicalEntity{Index: 1}, &PhysicalEntity{Index: 2}, &PhysicalEntity{Index: 3}
+	inv := &Inventory{
+		Entities: []*PhysicalEntity{a, b, c},
+		byIndex:  map[uint32]*PhysicalEntity{1: a, 2: b, 3: c},
+	}
+	inv.arrange(map[*PhysicalEntity]uint32{a: 2, b: 1, c: 9})
+	if len(inv.Roots) != 2 || inv.Roots[0] != b || inv.Roots[1] != c || a.Parent != b {
+		t.Errorf("Roots: %v", inv.Roots)
+	}
+}
+
+// Test scaling sensor values
+func TestSensorScale(t *testing.T) {
+	tests := []struct {
+		scale, precision, raw int
+		want                  float64
+	}{
+		{9, 0, 42, 42},
+		{8, 0, 3300, 3.3},
+		{9, 2, 2550, 25.5},
+		{10, -1, 15, 150000},
+	}
+	for _, test := range tests {
+		mib := map[string]Value{
+			".1.3.6.1.2.1.99.1.1.1.2.1": Integer32Value(test.scale),
+			".1.3.6.1.2.1.99.1.1.1.3.1": Integer32Value(test.precision),
+			".1.3.6.1.2.1.99.1.1.1.4.1": Integer32Value(test.raw),
+		}
+		agent := newTestAgent(t, mib)
+		table, err := agent.client().GetTable(".1.3.6.1.2.1.99.1.1")
+		if err != nil || len(table.Rows) != 1 {
+			t.Fatalf("GetTable: %v", err)
+		}
+		if s := newSensor(table, table.Rows[0]); s.Value != test.want {
+			t.Errorf("Scale %d precision %d value %d:\n\twant: %v\n\tgot : %v", test.scale, test.precision, test.raw, test.want, s.Value)
+		}
+	}
+}
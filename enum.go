@@ -0,0 +1,168 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bits is the set of bits of a BITS value, in bit order. Bits that the
+// syntax does not name have an empty Name.
+type Bits []NamedNumber
+
+// String returns the bits as "{bridge(2), router(4)}"
+func (b Bits) String() string {
+	parts := make([]string, len(b))
+	for i, bit := range b {
+		parts[i] = formatNamedNumber(bit.Name, bit.Value)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Has returns true if the named bit is set
+func (b Bits) Has(name string) bool {
+	for _, bit := range b {
+		if bit.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNamedNumber renders an enumeration value as "up(1)", or as the bare
+// number if it has no label
+func formatNamedNumber(name string, n int64) string {
+	if name == "" {
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprintf("%s(%d)", name, n)
+}
+
+// EnumName returns the label of an enumeration value or named bit
+func (s *MIBSyntax) EnumName(n int64) (string, bool) {
+	for _, e := range s.Enums {
+		if e.Value == n {
+			return e.Name, true
+		}
+	}
+	return "", false
+}
+
+// EnumValue returns the value of an enumeration label or named bit. The
+// label may be given as "down" or as rendered, "down(2)".
+func (s *MIBSyntax) EnumValue(label string) (int64, bool) {
+	if i := strings.IndexByte(label, '('); i > 0 && strings.HasSuffix(label, ")") {
+		n, err := strconv.ParseInt(label[i+1:len(label)-1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		if name, ok := s.EnumName(n); !ok || name != label[:i] {
+			return 0, false
+		}
+		return n, true
+	}
+	for _, e := range s.Enums {
+		if e.Name == label {
+			return e.Value, true
+		}
+	}
+	return 0, false
+}
+
+// DecodeBits decodes a BITS value, in which bit 0 is the most significant
+// bit of the first octet, into the set of bits it holds
+func (s *MIBSyntax) DecodeBits(data []byte) Bits {
+	bits := Bits{}
+	for i, octet := range data {
+		for j := 0; j < 8; j++ {
+			if octet&(0x80>>uint(j)) == 0 {
+				continue
+			}
+			n := int64(i*8 + j)
+			name, _ := s.EnumName(n)
+			bits = append(bits, NamedNumber{name, n})
+		}
+	}
+	return bits
+}
+
+// EncodeBits encodes the named bits as a BITS value, using as many octets
+// as the highest bit the syntax names requires
+func (s *MIBSyntax) EncodeBits(labels ...string) ([]byte, error) {
+	var highest int64
+	for _, e := range s.Enums {
+		if e.Value > highest {
+			highest = e.Value
+		}
+	}
+
+	data := make([]byte, highest/8+1)
+	for _, label := range labels {
+		n, ok := s.EnumValue(label)
+		if !ok {
+			return nil, fmt.Errorf("Unknown bit %q", label)
+		}
+		data[n/8] |= 0x80 >> uint(n%8)
+	}
+	return data, nil
+}
+
+// ParseLabel converts a label into a value of the syntax: an enumeration
+// label such as "down" into an Integer32Value, or a list of named bits such
+// as "{bridge, router}" into the OctetStringValue of a BITS syntax.
+// Numbers are accepted in place of enumeration labels.
+func (s *MIBSyntax) ParseLabel(label string) (Value, error) {
+	if s.Base == "BITS" {
+		labels := strings.FieldsFunc(strings.Trim(label, "{}"), func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+		data, err := s.EncodeBits(labels...)
+		if err != nil {
+			return nil, err
+		}
+		return OctetStringValue(data), nil
+	}
+
+	if len(s.Enums) == 0 {
+		return nil, fmt.Errorf("Syntax %s has no enumeration", s.Type)
+	}
+	n, ok := s.EnumValue(label)
+	if !ok {
+		var err error
+		if n, err = strconv.ParseInt(label, 10, 32); err != nil {
+			return nil, fmt.Errorf("Unknown label %q of %s", label, s.Type)
+		}
+	}
+	return Integer32Value(n), nil
+}
+
+// ParseLabel converts a label into a value for the object an instance OID
+// belongs to, as MIBSyntax.ParseLabel does
+func (t *MIBTree) ParseLabel(oid OID, label string) (Value, error) {
+	s := t.syntaxOf(oid)
+	if s == nil {
+		return nil, fmt.Errorf("No syntax known for %s", oid)
+	}
+	return s.ParseLabel(label)
+}
+
+// SetLabel sends an SNMP SET request to the target, setting oid to the value
+// of an enumeration label, such as "down" for IF-MIB::ifAdminStatus, or of a
+// list of named bits. The label is converted through MIBTree.ParseLabel
+// before the request is made, so Set and SetValues only ever see typed
+// values; use ParseLabel directly to set labels alongside other variables.
+func (x *GoSNMP) SetLabel(oid string, label string) (*SnmpPacket, error) {
+	name, err := x.ResolveOID(oid)
+	if err != nil {
+		return nil, err
+	}
+	value, err := x.mibs().ParseLabel(name, label)
+	if err != nil {
+		return nil, err
+	}
+	return x.SetValues(VarBind{Name: name, Value: value})
+}
@@ -0,0 +1,104 @@
+package gosnmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test rendering enumerations and named bits
+func TestEnumFormatting(t *testing.T) {
+	tree := BuiltinMIBs()
+	tests := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{"ifOperStatus.1", Integer32Value(1), "up(1)"},
+		{"ifAdminStatus.1", Integer32Value(2), "down(2)"},
+		{"ifOperStatus.1", Integer32Value(42), "42"},
+		{"ifType.1", Integer32Value(6), "ethernetCsmacd(6)"},
+		{"lldpRemSysCapEnabled.0.1.1", OctetStringValue{0x28}, "{bridge(2), router(4)}"},
+		{"lldpRemSysCapEnabled.0.1.1", OctetStringValue{0x00}, "{}"},
+		// Bits beyond the named ones are shown by number
+		{"lldpLocSysCapSupported.0", OctetStringValue{0x80, 0x40}, "{other(0), 9}"},
+	}
+	for _, test := range tests {
+		oid, err := tree.Resolve(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tree.FormatValue(VarBind{oid, test.value}); got != test.want {
+			t.Errorf("FormatValue %s %v:\n\twant: %s\n\tgot : %s", test.name, test.value, test.want, got)
+		}
+	}
+
+	got, err := DecodeTC("LldpSystemCapabilitiesMap", OctetStringValue{0x28})
+	want := Bits{{"bridge", 2}, {"router", 4}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeTC bits:\n\twant: %v\n\tgot : %v (%v)", want, got, err)
+	}
+	if bits := got.(Bits); !bits.Has("router") || bits.Has("repeater") {
+		t.Errorf("Bits.Has: %v", bits)
+	}
+}
+
+// Test converting labels into values
+func TestParseLabel(t *testing.T) {
+	tree := BuiltinMIBs()
+	tests := []struct {
+		name  string
+		label string
+		want  Value
+	}{
+		{"ifAdminStatus.3", "down", Integer32Value(2)},
+		{"ifAdminStatus.3", "testing(3)", Integer32Value(3)},
+		{"ifAdminStatus.3", "1", Integer32Value(1)},
+		{"lldpLocSysCapEnabled.0", "{bridge, router}", OctetStringValue{0x28}},
+		{"lldpLocSysCapEnabled.0", "bridge(2) stationOnly", OctetStringValue{0x21}},
+		{"lldpLocSysCapEnabled.0", "", OctetStringValue{0x00}},
+	}
+	for _, test := range tests {
+		oid, _ := tree.Resolve(test.name)
+		got, err := tree.ParseLabel(oid, test.label)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseLabel %s %q:\n\twant: %v\n\tgot : %v (%v)", test.name, test.label, test.want, got, err)
+		}
+	}
+
+	for _, bad := range []struct{ name, label string }{
+		{"ifAdminStatus.3", "sideways"},
+		{"ifAdminStatus.3", "up(2)"},
+		{"lldpLocSysCapEnabled.0", "bridge teleporter"},
+		{"ifDescr.3", "up"},
+	} {
+		oid, _ := tree.Resolve(bad.name)
+		if v, err := tree.ParseLabel(oid, bad.label); err == nil {
+			t.Errorf("Expected error for %s %q, got %v", bad.name, bad.label, v)
+		}
+	}
+}
+
+// Test setting a variable by label
+func TestSetLabel(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.2.2.1.7.3": Integer32Value(1),
+	})
+	s := agent.client()
+
+	res, err := s.SetLabel("IF-MIB::ifAdminStatus.3", "down")
+	if err != nil || SnmpError(res.Error) != NoError {
+		t.Fatalf("SetLabel: %v %v", res, err)
+	}
+	if got := s.mibs().FormatValue(res.VarBinds[0]); got != "down(2)" {
+		t.Errorf("SetLabel response: %s", got)
+	}
+	if _, err := s.SetLabel("ifAdminStatus.3", "sideways"); err == nil {
+		t.Errorf("Expected error for unknown label")
+	}
+	if _, err := s.SetLabel("ifDescr.3", "down"); err == nil {
+		t.Errorf("Expected error for a syntax without enumeration")
+	}
+	if n := agent.requestCount(); n != 1 {
+		t.Errorf("Expected labels to be rejected before sending, got %d requests", n)
+	}
+}
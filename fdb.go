@@ -0,0 +1,323 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"sort"
+	"sync"
+)
+
+var (
+	oidDot1dBasePortTable   = MustParseOID(".1.3.6.1.2.1.17.1.4")
+	oidDot1dTpFdbTable      = MustParseOID(".1.3.6.1.2.1.17.4.3")
+	oidDot1qTpFdbTable      = MustParseOID(".1.3.6.1.2.1.17.7.1.2.2")
+	oidIpNetToPhysicalTable = MustParseOID(".1.3.6.1.2.1.4.35")
+	oidIpNetToMediaTable    = MustParseOID(".1.3.6.1.2.1.4.22")
+)
+
+// Columns of the BRIDGE-MIB (RFC 4188), Q-BRIDGE-MIB (RFC 4363) and IP-MIB
+// (RFC 4293) tables
+const (
+	dot1dBasePortIfIndex = 2
+
+	dot1dTpFdbPort   = 2
+	dot1dTpFdbStatus = 3
+
+	dot1qTpFdbPort   = 2
+	dot1qTpFdbStatus = 3
+
+	ipNetToPhysicalPhysAddress = 4
+	ipNetToPhysicalType        = 6
+
+	ipNetToMediaPhysAddress = 2
+	ipNetToMediaType        = 4
+)
+
+// FDB entry statuses of dot1dTpFdbStatus and dot1qTpFdbStatus
+const (
+	FDBOther   = 1
+	FDBInvalid = 2
+	FDBLearned = 3
+	FDBSelf    = 4
+	FDBMgmt    = 5
+)
+
+// FDBEntry is a MAC address in the forwarding database of a bridge
+type FDBEntry struct {
+	MAC net.HardwareAddr
+	// FDBID is the filtering database, usually the VLAN, for entries of
+	// dot1qTpFdbTable, or zero for entries of dot1dTpFdbTable
+	FDBID uint32
+	// Port is the bridge port the address was seen on, or zero if unknown
+	Port int
+	// IfIndex is the interface of the bridge port, or zero if unknown
+	IfIndex int
+	// Status is one of FDBOther, FDBInvalid, FDBLearned, FDBSelf or FDBMgmt
+	Status int
+}
+
+// ARPEntry is an IP address resolved to a MAC address, from the ARP cache
+// or IPv6 neighbour cache of a device
+type ARPEntry struct {
+	IfIndex int
+	Address InetAddress
+	MAC     net.HardwareAddr
+	// Type is other(1), invalid(2), dynamic(3), static(4) or local(5)
+	Type int
+}
+
+// MACTable retrieves the forwarding database of a bridge from
+// dot1qTpFdbTable, or from dot1dTpFdbTable if the agent does not have
+// Q-BRIDGE-MIB, with the bridge ports mapped to interfaces through
+// dot1dBasePortTable
+//
+// If a request fails, the entries retrieved so far are returned along with
+// the error. The entries are still retrieved if dot1dBasePortTable fails,
+// with IfIndex zero for the ports that could not be mapped, and that error
+// is returned unless a later request fails too.
+func (x *GoSNMP) MACTable() ([]*FDBEntry, error) {
+	ports, portsErr := x.BulkGetTableOID(0, oidDot1dBasePortTable, dot1dBasePortIfIndex)
+	ifIndex := make(map[int]int)
+	if ports != nil {
+		for _, row := range ports.Rows {
+			if len(row.Index) == 1 {
+				ifIndex[int(row.Index[0])] = tableInt(ports, row, dot1dBasePortIfIndex)
+			}
+		}
+	}
+
+	var entries []*FDBEntry
+	// Indexed by dot1qFdbId and dot1qTpFdbAddress
+	qTable, err := x.BulkGetTableOID(0, oidDot1qTpFdbTable, dot1qTpFdbPort, dot1qTpFdbStatus)
+	if qTable == nil {
+		return nil, err
+	}
+	for _, row := range qTable.Rows {
+		if len(row.Index) != 7 {
+			continue
+		}
+		e := &FDBEntry{FDBID: row.Index[0], MAC: oidMAC(row.Index[1:])}
+		e.Port = tableInt(qTable, row, dot1qTpFdbPort)
+		e.Status = tableInt(qTable, row, dot1qTpFdbStatus)
+		e.IfIndex = ifIndex[e.Port]
+		entries = append(entries, e)
+	}
+	if err != nil {
+		return entries, err
+	}
+	if len(entries) > 0 {
+		return entries, portsErr
+	}
+
+	// Indexed by dot1dTpFdbAddress
+	table, err := x.BulkGetTableOID(0, oidDot1dTpFdbTable, dot1dTpFdbPort, dot1dTpFdbStatus)
+	if table == nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		if len(row.Index) != 6 {
+			continue
+		}
+		e := &FDBEntry{MAC: oidMAC(row.Index)}
+		e.Port = tableInt(table, row, dot1dTpFdbPort)
+		e.Status = tableInt(table, row, dot1dTpFdbStatus)
+		e.IfIndex = ifIndex[e.Port]
+		entries = append(entries, e)
+	}
+	if err == nil {
+		err = portsErr
+	}
+	return entries, err
+}
+
+// ARPTable retrieves the IP to MAC address mappings of a device from
+// ipNetToPhysicalTable, or from the IPv4 only ipNetToMediaTable if the agent
+// does not have the former
+//
+// If a request fails, the entries retrieved so far are returned along with
+// the error.
+func (x *GoSNMP) ARPTable() ([]*ARPEntry, error) {
+	var entries []*ARPEntry
+	// Indexed by ipNetToPhysicalIfIndex, ipNetToPhysicalNetAddressType and
+	// the length-prefixed ipNetToPhysicalNetAddress
+	table, err := x.BulkGetTableOID(0, oidIpNetToPhysicalTable, ipNetToPhysicalPhysAddress, ipNetToPhysicalType)
+	if table == nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		values, decodeErr := row.DecodeIndex(IndexSpec{{Kind: IndexInteger}, {Kind: IndexInetAddress}})
+		if decodeErr != nil {
+			continue
+		}
+		e := &ARPEntry{IfIndex: int(values[0].(uint32)), Address: values[1].(InetAddress)}
+		mac, _ := tableString(table, row, ipNetToPhysicalPhysAddress)
+		e.MAC = net.HardwareAddr(mac)
+		e.Type = tableInt(table, row, ipNetToPhysicalType)
+		entries = append(entries, e)
+	}
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
+
+	// Indexed by ipNetToMediaIfIndex and ipNetToMediaNetAddress
+	table, err = x.BulkGetTableOID(0, oidIpNetToMediaTable, ipNetToMediaPhysAddress, ipNetToMediaType)
+	if table == nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		values, decodeErr := row.DecodeIndex(IndexSpec{{Kind: IndexInteger}, {Kind: IndexIPAddress}})
+		if decodeErr != nil {
+			continue
+		}
+		e := &ARPEntry{IfIndex: int(values[0].(uint32)), Address: InetAddress{InetIPv4, values[1].(net.IP)}}
+		mac, _ := tableString(table, row, ipNetToMediaPhysAddress)
+		e.MAC = net.HardwareAddr(mac)
+		// ipNetToMediaType has no local(5), but is otherwise the same
+		e.Type = tableInt(table, row, ipNetToMediaType)
+		entries = append(entries, e)
+	}
+	return entries, err
+}
+
+// oidMAC converts the six arcs of a MacAddress index to the address
+func oidMAC(arcs OID) net.HardwareAddr {
+	mac := make(net.HardwareAddr, len(arcs))
+	for i, arc := range arcs {
+		mac[i] = byte(arc)
+	}
+	return mac
+}
+
+// PortLocation is where a MAC address was seen: a port of a switch
+type PortLocation struct {
+	// Switch is the name the forwarding database was added under
+	Switch string
+	FDBEntry
+	// MACs is the number of addresses seen on the same port, which is low
+	// for the access port a host is connected to and high for uplinks
+	MACs int
+}
+
+// PortLocator finds the switch ports MAC and IP addresses are on, from the
+// forwarding databases of many switches and the ARP tables of the routers
+// between them. It is safe for concurrent use.
+type PortLocator struct {
+	mu sync.Mutex
+	// locations by MAC address
+	locations map[string][]*PortLocation
+	// MAC addresses by IP address
+	arp map[string]net.HardwareAddr
+}
+
+// NewPortLocator returns an empty PortLocator
+func NewPortLocator() *PortLocator {
+	return &PortLocator{
+		locations: make(map[string][]*PortLocation),
+		arp:       make(map[string]net.HardwareAddr),
+	}
+}
+
+// AddMACTable adds the forwarding database of a switch, as retrieved by
+// GoSNMP.MACTable, replacing what was added earlier under the same name.
+// Invalid entries and entries without a port are ignored.
+func (l *PortLocator) AddMACTable(name string, entries []*FDBEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for mac, locations := range l.locations {
+		kept := locations[:0]
+		for _, loc := range locations {
+			if loc.Switch != name {
+				kept = append(kept, loc)
+			}
+		}
+		if len(kept) == 0 {
+			delete(l.locations, mac)
+		} else {
+			l.locations[mac] = kept
+		}
+	}
+
+	perPort := make(map[int]int)
+	var added []*PortLocation
+	for _, e := range entries {
+		if e.Status == FDBInvalid || e.Port == 0 {
+			continue
+		}
+		perPort[e.Port]++
+		added = append(added, &PortLocation{Switch: name, FDBEntry: *e})
+	}
+	for _, loc := range added {
+		loc.MACs = perPort[loc.Port]
+		key := loc.MAC.String()
+		l.locations[key] = append(l.locations[key], loc)
+	}
+}
+
+// AddARPTable adds the ARP table of a device, as retrieved by
+// GoSNMP.ARPTable. Invalid and incomplete entries are ignored.
+func (l *PortLocator) AddARPTable(entries []*ARPEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, e := range entries {
+		ip := e.Address.IP()
+		// Type invalid(2)
+		if ip == nil || len(e.MAC) == 0 || e.Type == 2 {
+			continue
+		}
+		l.arp[ip.String()] = e.MAC
+	}
+}
+
+// Collect retrieves the forwarding database and ARP table of each device
+// and adds them under the device's Target. Devices that are not switches or
+// routers contribute what they have. The first error is returned after all
+// devices were tried.
+func (l *PortLocator) Collect(devices ...*GoSNMP) error {
+	var first error
+	for _, x := range devices {
+		entries, err := x.MACTable()
+		l.AddMACTable(x.Target, entries)
+		if err != nil && first == nil {
+			first = err
+		}
+		arp, err := x.ARPTable()
+		l.AddARPTable(arp)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// LocateMAC returns the ports a MAC address was seen on, most likely the
+// port it is connected to first: the one with the fewest addresses
+func (l *PortLocator) LocateMAC(mac net.HardwareAddr) []PortLocation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var locations []PortLocation
+	for _, loc := range l.locations[mac.String()] {
+		locations = append(locations, *loc)
+	}
+	sort.SliceStable(locations, func(i, j int) bool { return locations[i].MACs < locations[j].MACs })
+	return locations
+}
+
+// LocateIP resolves an IP address to its MAC address through the ARP tables
+// and returns it along with the ports it was seen on, or nil if the address
+// is not known
+func (l *PortLocator) LocateIP(ip net.IP) (net.HardwareAddr, []PortLocation) {
+	l.mu.Lock()
+	mac := l.arp[ip.String()]
+	l.mu.Unlock()
+
+	if mac == nil {
+		return nil, nil
+	}
+	return mac, l.LocateMAC(mac)
+}
@@ -0,0 +1,143 @@
+package gosnmp
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+// Test reading a Q-BRIDGE-MIB forwarding database with bridge port mapping
+func TestMACTable(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.17.1.4.1.2.1":                    Integer32Value(10101),
+		".1.3.6.1.2.1.17.1.4.1.2.2":                    Integer32Value(10102),
+		".1.3.6.1.2.1.17.7.1.2.2.1.2.10.0.27.33.1.2.3": Integer32Value(1),
+		".1.3.6.1.2.1.17.7.1.2.2.1.3.10.0.27.33.1.2.3": Integer32Value(3),
+		".1.3.6.1.2.1.17.7.1.2.2.1.2.20.0.27.33.1.2.4": Integer32Value(2),
+		".1.3.6.1.2.1.17.7.1.2.2.1.3.20.0.27.33.1.2.4": Integer32Value(3),
+		// dot1dTpFdbTable is ignored when Q-BRIDGE-MIB has entries
+		".1.3.6.1.2.1.17.4.3.1.2.0.27.33.1.2.5": Integer32Value(1),
+	})
+	entries, err := agent.client().MACTable()
+	if err != nil {
+		t.Fatalf("MACTable: %s", err)
+	}
+	want := []*FDBEntry{
+		{MAC: net.HardwareAddr{0, 27, 33, 1, 2, 3}, FDBID: 10, Port: 1, IfIndex: 10101, Status: FDBLearned},
+		{MAC: net.HardwareAddr{0, 27, 33, 1, 2, 4}, FDBID: 20, Port: 2, IfIndex: 10102, Status: FDBLearned},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("MACTable:\n\twant: %+v\n\tgot : %+v", want, entries)
+	}
+}
+
+// Test falling back to dot1dTpFdbTable
+func TestMACTableBridge(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.17.1.4.1.2.3":             Integer32Value(3),
+		".1.3.6.1.2.1.17.4.3.1.2.0.27.33.1.2.5": Integer32Value(3),
+		".1.3.6.1.2.1.17.4.3.1.3.0.27.33.1.2.5": Integer32Value(3),
+	})
+	entries, err := agent.client().MACTable()
+	if err != nil {
+		t.Fatalf("MACTable: %s", err)
+	}
+	if len(entries) != 1 || entries[0].MAC.String() != "00:1b:21:01:02:05" || entries[0].FDBID != 0 || entries[0].IfIndex != 3 {
+		t.Errorf("MACTable: %+v", entries)
+	}
+}
+
+// Test that entries are still returned when dot1dBasePortTable fails
+func TestMACTablePortsError(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.17.1.4.1.2.1":                    Integer32Value(10101),
+		".1.3.6.1.2.1.17.7.1.2.2.1.2.10.0.27.33.1.2.3": Integer32Value(1),
+		".1.3.6.1.2.1.17.7.1.2.2.1.3.10.0.27.33.1.2.3": Integer32Value(3),
+	})
+	dot1dBasePortTable := MustParseOID(".1.3.6.1.2.1.17.1.4")
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if req.VarBinds[0].Name.HasPrefix(dot1dBasePortTable) {
+			return &SnmpPacket{Error: uint8(GenErr), ErrorIndex: 1, VarBinds: req.VarBinds}
+		}
+		return agent.handle(req)
+	})
+	entries, err := agent.client().MACTable()
+	if err == nil {
+		t.Errorf("MACTable: expected the dot1dBasePortTable error")
+	}
+	want := []*FDBEntry{
+		{MAC: net.HardwareAddr{0, 27, 33, 1, 2, 3}, FDBID: 10, Port: 1, Status: FDBLearned},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("MACTable:\n\twant: %+v\n\tgot : %+v", want, entries)
+	}
+}
+
+// Test reading ARP entries from ipNetToPhysicalTable and ipNetToMediaTable
+func TestARPTable(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.35.1.4.2.1.4.192.0.2.10": OctetStringValue{0, 27, 33, 1, 2, 3},
+		".1.3.6.1.2.1.4.35.1.6.2.1.4.192.0.2.10": Integer32Value(3),
+	})
+	entries, err := agent.client().ARPTable()
+	if err != nil {
+		t.Fatalf("ARPTable: %s", err)
+	}
+	if len(entries) != 1 || entries[0].IfIndex != 2 || entries[0].Address.String() != "192.0.2.10" ||
+		entries[0].MAC.String() != "00:1b:21:01:02:03" || entries[0].Type != 3 {
+		t.Errorf("ARPTable: %+v", entries)
+	}
+
+	agent = newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.22.1.2.2.192.0.2.11": OctetStringValue{0, 27, 33, 1, 2, 4},
+		".1.3.6.1.2.1.4.22.1.4.2.192.0.2.11": Integer32Value(4),
+	})
+	entries, err = agent.client().ARPTable()
+	if err != nil {
+		t.Fatalf("ARPTable: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Address.String() != "192.0.2.11" || entries[0].MAC.String() != "00:1b:21:01:02:04" || entries[0].Type != 4 {
+		t.Errorf("ipNetToMediaTable: %+v", entries)
+	}
+}
+
+// Test locating MAC and IP addresses across switches
+func TestPortLocator(t *testing.T) {
+	host := net.HardwareAddr{0, 27, 33, 1, 2, 3}
+	other := net.HardwareAddr{0, 27, 33, 1, 2, 4}
+
+	l := NewPortLocator()
+	// The host is on access port 5 of switch2, which core sees on its uplink
+	l.AddMACTable("core", []*FDBEntry{
+		{MAC: host, Port: 48, IfIndex: 48, Status: FDBLearned},
+		{MAC: other, Port: 48, IfIndex: 48, Status: FDBLearned},
+	})
+	l.AddMACTable("switch2", []*FDBEntry{
+		{MAC: host, Port: 5, IfIndex: 10105, Status: FDBLearned},
+		{MAC: other, Port: 6, Status: FDBInvalid},
+	})
+	l.AddARPTable([]*ARPEntry{{Address: InetAddress{InetIPv4, []byte{192, 0, 2, 10}}, MAC: host, Type: 3}})
+
+	locations := l.LocateMAC(host)
+	if len(locations) != 2 || locations[0].Switch != "switch2" || locations[0].IfIndex != 10105 || locations[0].MACs != 1 ||
+		locations[1].Switch != "core" || locations[1].MACs != 2 {
+		t.Errorf("LocateMAC: %+v", locations)
+	}
+	if locations := l.LocateMAC(other); len(locations) != 1 {
+		t.Errorf("Invalid entry located: %+v", locations)
+	}
+
+	mac, locations := l.LocateIP(net.ParseIP("192.0.2.10"))
+	if mac.String() != host.String() || len(locations) != 2 {
+		t.Errorf("LocateIP: %s %+v", mac, locations)
+	}
+	if mac, _ := l.LocateIP(net.ParseIP("192.0.2.99")); mac != nil {
+		t.Errorf("Unknown IP located: %s", mac)
+	}
+
+	// Adding a switch again replaces its entries
+	l.AddMACTable("switch2", nil)
+	if locations := l.LocateMAC(host); len(locations) != 1 || locations[0].Switch != "core" {
+		t.Errorf("Replaced switch: %+v", locations)
+	}
+}
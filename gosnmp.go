@@ -22,11 +22,40 @@ type GoSNMP struct {
 	Timeout   time.Duration
 	conn      net.Conn
 	Log       *l.Logger
+
+	// NonIncreasing selects what walks do when the agent returns an OID that
+	// does not sort after the previous one. Defaults to WalkStop.
+	NonIncreasing NonIncreasingPolicy
+	// MaxWalkRequests limits the number of requests a single walk may send.
+	// Zero means no limit.
+	MaxWalkRequests int
+	// MaxWalkVarbinds limits the number of variables a single walk may
+	// return. Zero means no limit.
+	MaxWalkVarbinds int
+	// MaxResponseSize is the response size in bytes that bulk walks aim
+	// for, lowering max-repetitions when responses grow larger. Zero means
+	// max-repetitions is only lowered when the agent answers tooBig.
+	MaxResponseSize int
+	// MIBs resolves symbolic names, such as "IF-MIB::ifDescr.3", given to
+	// requests and walks. When nil, the standard modules of BuiltinMIBs are
+	// used.
+	MIBs *MIBTree
+	// Context selects a context of the agent, such as the BRIDGE-MIB
+	// instance of a VLAN. SNMPv1 and v2c have no contextName, so it is sent
+	// with community string indexing as "community@context". Use
+	// WithContext to query several contexts over the same connection.
+	Context string
 }
 
 // DefaultPort is the default SNMP port
 var DefaultPort = 161
 
+// DefaultMaxRepetitions is used by bulk walks when max-repetitions is zero
+var DefaultMaxRepetitions uint8 = 10
+
+// maxPacketSize is the largest UDP payload that can be received
+const maxPacketSize = 65535
+
 // NewGoSNMP creates a new SNMP Client. Target is the IP address, Community
 // the SNMP Community String and Version the SNMP version. Currently only v2c
 // is supported. Timeout parameter is measured in seconds.
@@ -41,7 +70,14 @@ func NewGoSNMP(target, community string, version SnmpVersion, timeout int64) (*G
 	if err != nil {
 		return nil, fmt.Errorf("Error establishing connection to host: %s\n", err.Error())
 	}
-	s := &GoSNMP{target, community, version, time.Duration(timeout) * time.Second, conn, l.CreateLogger(false, false)}
+	s := &GoSNMP{
+		Target:    target,
+		Community: community,
+		Version:   version,
+		Timeout:   time.Duration(timeout) * time.Second,
+		conn:      conn,
+		Log:       l.CreateLogger(false, false),
+	}
 
 	return s, nil
 }
@@ -68,117 +104,61 @@ func (x *GoSNMP) SetTimeout(seconds int64) {
 // as it receives them, without waiting for the whole process to finish to return the
 // results. Once it has completed the walk, the channel is closed.
 func (x *GoSNMP) StreamWalk(oid string, c chan SnmpPDU) error {
-	if oid == "" {
-		close(c)
-		return fmt.Errorf("No OID given\n")
-	}
+	defer close(c)
 
-	requestOid := oid
+	return x.WalkFunc(oid, func(pdu SnmpPDU) error {
+		c <- pdu
+		return nil
+	})
+}
 
-	for {
-		res, err := x.GetNext(oid)
-		if err != nil {
-			close(c)
-			return err
-		}
-		if res != nil {
-			if len(res.Variables) > 0 {
-				if strings.Index(res.Variables[0].Name, requestOid) > -1 {
-					if res.Variables[0].Value == "endOfMib" {
-						break
-					}
-					c <- res.Variables[0]
-					// Set to the next
-					oid = res.Variables[0].Name
-					x.Log.Debug("Moving to %s\n", oid)
-				} else {
-					x.Log.Debug("Root OID mismatch, stopping walk\n")
-					break
-				}
-			} else {
-				break
-			}
-		} else {
-			break
-		}
+// StreamBulkWalk is like StreamWalk, but walks the target using SNMP BULK-GET
+// requests
+func (x *GoSNMP) StreamBulkWalk(maxRepetitions uint8, oid string, c chan SnmpPDU) error {
+	defer close(c)
 
-	}
-	close(c)
-	return nil
+	return x.BulkWalkFunc(maxRepetitions, oid, func(pdu SnmpPDU) error {
+		c <- pdu
+		return nil
+	})
 }
 
 // BulkWalk sends an walks the target using SNMP BULK-GET requests. This returns
-// a Variable with the response and the error condition
+// a Variable with the response and the error condition. If a request fails,
+// the variables received up to that point are returned along with the error.
+//
+// Max-repetitions is lowered when the agent answers tooBig or responses
+// exceed MaxResponseSize. GetNext is used instead when Version is Version1,
+// or when the agent fails the first GetBulk request, as SNMPv1 agents do.
+// BulkWalkValues walks from an OID and returns typed VarBinds.
 func (x *GoSNMP) BulkWalk(maxRepetitions uint8, oid string) (results []SnmpPDU, err error) {
-	if oid == "" {
-		return nil, fmt.Errorf("No OID given\n")
-	}
-	return x._bulkWalk(maxRepetitions, oid, oid)
-}
-func (x *GoSNMP) _bulkWalk(maxRepetitions uint8, searchingOid string, rootOid string) (results []SnmpPDU, err error) {
-	response, err := x.GetBulk(0, maxRepetitions, searchingOid)
-	if err != nil {
-		return
-	}
-	for i, v := range response.Variables {
-		if v.Value == "endOfMib" {
-			return
-		}
-		// is this variable still in the requested oid range
-		if strings.HasPrefix(v.Name, rootOid) {
-			results = append(results, v)
-			// is the last oid received still in the requested range
-			if i == len(response.Variables)-1 {
-				var subResults []SnmpPDU
-				subResults, err = x._bulkWalk(maxRepetitions, v.Name, rootOid)
-				if err != nil {
-					return
-				}
-				results = append(results, subResults...)
-			}
-		}
-	}
+	err = x.BulkWalkFunc(maxRepetitions, oid, func(pdu SnmpPDU) error {
+		results = append(results, pdu)
+		return nil
+	})
 	return
 }
 
-// Walk will SNMP walk the target, blocking until the process is complete
+// Walk will SNMP walk the target, blocking until the process is complete.
+// See WalkValues for a walk from an OID.
 func (x *GoSNMP) Walk(oid string) (results []SnmpPDU, err error) {
-	if oid == "" {
-		return nil, fmt.Errorf("No OID given\n")
-	}
 	results = make([]SnmpPDU, 0)
-	requestOid := oid
-
-	for {
-		res, err := x.GetNext(oid)
-		if err != nil {
-			return results, err
-		}
-		if res != nil {
-			if len(res.Variables) > 0 {
-				if strings.Index(res.Variables[0].Name, requestOid) > -1 {
-					results = append(results, res.Variables[0])
-					// Set to the next
-					oid = res.Variables[0].Name
-					x.Log.Debug("Moving to %s\n", oid)
-				} else {
-					x.Log.Debug("Root OID mismatch, stopping walk\n")
-					break
-				}
-			} else {
-				break
-			}
-		} else {
-			break
-		}
-
-	}
+	err = x.WalkFunc(oid, func(pdu SnmpPDU) error {
+		results = append(results, pdu)
+		return nil
+	})
 	return
 }
 
 // sendPacket marshals & send an SNMP request. Unmarshals the response and
 // returns back the parsed SNMP packet
 func (x *GoSNMP) sendPacket(packet *SnmpPacket) (*SnmpPacket, error) {
+	return x.exchange(packet, false)
+}
+
+// exchange sends the packet and unmarshals the response, either into legacy
+// Variables or into typed VarBinds
+func (x *GoSNMP) exchange(packet *SnmpPacket, typed bool) (*SnmpPacket, error) {
 	// Set timeouts on the connection
 	deadline := time.Now()
 	x.conn.SetDeadline(deadline.Add(x.Timeout))
@@ -199,7 +179,7 @@ func (x *GoSNMP) sendPacket(packet *SnmpPacket) (*SnmpPacket, error) {
 		return nil, fmt.Errorf("Error writing to socket: %s\n", err.Error())
 	}
 	// Try to read the response
-	resp := make([]byte, 8192, 8192)
+	resp := make([]byte, maxPacketSize, maxPacketSize)
 	n, err := x.conn.Read(resp)
 
 	if err != nil {
@@ -207,13 +187,13 @@ func (x *GoSNMP) sendPacket(packet *SnmpPacket) (*SnmpPacket, error) {
 	}
 
 	// Unmarshal the read bytes
-	pdu, err := Unmarshal(resp[:n])
+	pdu, err := unmarshal(resp[:n], typed)
 
 	if err != nil {
 		return nil, fmt.Errorf("Unable to decode packet: %s\n", err.Error())
 	}
 
-	if len(pdu.Variables) < 1 {
+	if len(pdu.Variables) < 1 && len(pdu.VarBinds) < 1 && pdu.Error == 0 {
 		return nil, fmt.Errorf("No responses received.")
 	}
 
@@ -226,7 +206,8 @@ func (x *GoSNMP) sendPacket(packet *SnmpPacket) (*SnmpPacket, error) {
 }
 
 // GetNext sends an SNMP Get Next Request to the target. Returns the next
-// variable response from the OID given or an error
+// variable response from the OID given or an error. GetNextValues accepts
+// several OIDs.
 func (x *GoSNMP) GetNext(oid string) (*SnmpPacket, error) {
 	return x.request(GetNextRequest, oid)
 }
@@ -242,45 +223,137 @@ func (x *GoSNMP) Debug(data []byte) (*SnmpPacket, error) {
 }
 
 // GetBulk sends an SNMP BULK-GET request to the target. Returns a Variable with
-// the response or an error
+// the response or an error. GetBulkValues decodes the response into typed
+// VarBinds.
 func (x *GoSNMP) GetBulk(nonRepeaters, maxRepetitions uint8, oids ...string) (*SnmpPacket, error) {
+	pdus, err := x.oidsToPdus(oids...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create and send the packet
 	return x.sendPacket(&SnmpPacket{
 		Version:        x.Version,
-		Community:      x.Community,
+		Community:      x.community(),
 		RequestType:    GetBulkRequest,
 		NonRepeaters:   nonRepeaters,
 		MaxRepetitions: maxRepetitions,
-		Variables:      oidsToPbus(oids...),
+		Variables:      pdus,
 	})
 }
 
 // Get sends an SNMP GET request to the target. Returns a Variable with the
-// response or an error
+// response or an error. GetValues is its typed counterpart.
 func (x *GoSNMP) Get(oid string) (*SnmpPacket, error) {
 	return x.request(GetRequest, oid)
 }
 
 // GetMulti sends an SNMP GET request to the target. Returns a Variable with the
-// response or an error
+// response or an error. GetValues also gets several variables at once.
 func (x *GoSNMP) GetMulti(oids []string) (*SnmpPacket, error) {
 	return x.request(GetRequest, oids...)
 }
 
 func (x *GoSNMP) request(requestType Asn1BER, oids ...string) (*SnmpPacket, error) {
+	pdus, err := x.oidsToPdus(oids...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create and send the packet
 	return x.sendPacket(&SnmpPacket{
 		Version:     x.Version,
-		Community:   x.Community,
+		Community:   x.community(),
 		RequestType: requestType,
-		Variables:   oidsToPbus(oids...),
+		Variables:   pdus,
 	})
 }
 
-func oidsToPbus(oids ...string) []SnmpPDU {
+// Set sends an SNMP SET request to the target, setting oid to value. The
+// response variables are returned as typed VarBinds.
+func (x *GoSNMP) Set(oid string, value Value) (*SnmpPacket, error) {
+	name, err := x.ResolveOID(oid)
+	if err != nil {
+		return nil, err
+	}
+	return x.SetValues(VarBind{Name: name, Value: value})
+}
+
+// SetValues sends an SNMP SET request to the target, setting all the given
+// variables at once. The response variables are returned as typed VarBinds.
+func (x *GoSNMP) SetValues(varbinds ...VarBind) (*SnmpPacket, error) {
+	return x.exchange(&SnmpPacket{
+		Version:     x.Version,
+		Community:   x.community(),
+		RequestType: SetRequest,
+		VarBinds:    varbinds,
+	}, true)
+}
+
+// community returns the community string requests are sent with
+func (x *GoSNMP) community() string {
+	if x.Context != "" {
+		return x.Community + "@" + x.Context
+	}
+	return x.Community
+}
+
+// ResolveOID parses a numeric OID, or resolves a symbolic name
+func (x *GoSNMP) ResolveOID(name string) (OID, error) {
+	return x.mibs().Resolve(name)
+}
+
+// mibs returns the tree symbolic names are resolved with
+func (x *GoSNMP) mibs() *MIBTree {
+	if x.MIBs != nil {
+		return x.MIBs
+	}
+	return BuiltinMIBs()
+}
+
+// GetValues sends an SNMP GET request to the target. The response variables
+// are returned as typed VarBinds.
+func (x *GoSNMP) GetValues(oids ...OID) (*SnmpPacket, error) {
+	return x.requestValues(GetRequest, 0, 0, oids...)
+}
+
+// GetNextValues sends an SNMP Get Next Request to the target. The response
+// variables are returned as typed VarBinds.
+func (x *GoSNMP) GetNextValues(oids ...OID) (*SnmpPacket, error) {
+	return x.requestValues(GetNextRequest, 0, 0, oids...)
+}
+
+// GetBulkValues sends an SNMP BULK-GET request to the target. The response
+// variables are returned as typed VarBinds.
+func (x *GoSNMP) GetBulkValues(nonRepeaters, maxRepetitions uint8, oids ...OID) (*SnmpPacket, error) {
+	return x.requestValues(GetBulkRequest, nonRepeaters, maxRepetitions, oids...)
+}
+
+func (x *GoSNMP) requestValues(requestType Asn1BER, nonRepeaters, maxRepetitions uint8, oids ...OID) (*SnmpPacket, error) {
+	varbinds := make([]VarBind, len(oids))
+	for i, oid := range oids {
+		varbinds[i] = VarBind{Name: oid, Value: NullValue{}}
+	}
+
+	return x.exchange(&SnmpPacket{
+		Version:        x.Version,
+		Community:      x.community(),
+		RequestType:    requestType,
+		NonRepeaters:   nonRepeaters,
+		MaxRepetitions: maxRepetitions,
+		VarBinds:       varbinds,
+	}, true)
+}
+
+// oidsToPdus builds the request variables, resolving symbolic names
+func (x *GoSNMP) oidsToPdus(oids ...string) ([]SnmpPDU, error) {
 	pdus := make([]SnmpPDU, len(oids))
 	for i, oid := range oids {
-		pdus[i] = SnmpPDU{Name: oid, Type: Null}
+		resolved, err := x.mibs().Resolve(oid)
+		if err != nil {
+			return nil, err
+		}
+		pdus[i] = SnmpPDU{Name: resolved.String(), Type: Null}
 	}
-	return pdus
+	return pdus, nil
 }
@@ -100,3 +100,13 @@ func TestDataTypeStrings(t *testing.T) {
 		t.Errorf("Data Type strings:\n\twant: %q\n\tgot : %q", "Integer", dataType)
 	}
 }
+
+// Test decoding big-endian 16-bit integers
+func TestParseUint16(t *testing.T) {
+	if n := ParseUint16([]byte{0x01, 0x02}); n != 258 {
+		t.Errorf("ParseUint16:\n\twant: %d\n\tgot : %d", 258, n)
+	}
+	if n := ParseUint16([]byte{0xff, 0xff}); n != 65535 {
+		t.Errorf("ParseUint16:\n\twant: %d\n\tgot : %d", 65535, n)
+	}
+}
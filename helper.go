@@ -10,13 +10,16 @@ import (
 	"fmt"
 )
 
-func marshalObjectIdentifier(oid []int) (ret []byte, err error) {
+// marshalObjectIdentifier encodes an OBJECT IDENTIFIER. The first arc must
+// be 0, 1 or 2; the second arc must be below 40 unless the first one is 2,
+// since the two are packed into a single sub-identifier (X.690 8.19.4).
+func marshalObjectIdentifier(oid OID) (ret []byte, err error) {
 	out := bytes.NewBuffer(make([]byte, 0, 128))
-	if len(oid) < 2 || oid[0] > 6 || oid[1] >= 40 {
+	if len(oid) < 2 || oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
 		return nil, errors.New("invalid object identifier")
 	}
 
-	err = out.WriteByte(byte(oid[0]*40 + oid[1]))
+	err = marshalBase128Int(out, int64(oid[0])*40+int64(oid[1]))
 	if err != nil {
 		return
 	}
@@ -35,35 +38,47 @@ func marshalObjectIdentifier(oid []int) (ret []byte, err error) {
 // parseObjectIdentifier parses an OBJECT IDENTIFIER from the given bytes and
 // returns it. An object identifier is a sequence of variable length integers
 // that are assigned in a hierarchy.
-func parseObjectIdentifier(bytes []byte) (s []int, err error) {
+func parseObjectIdentifier(bytes []byte) (s OID, err error) {
 	if len(bytes) == 0 {
 		err = fmt.Errorf("zero length OBJECT IDENTIFIER")
 		return
 	}
 
-	// In the worst case, we get two elements from the first byte (which is
-	// encoded differently) and then every varint is a single byte long.
-	s = make([]int, len(bytes)+1)
+	// In the worst case, we get two elements from the first sub-identifier
+	// and then every varint is a single byte long.
+	s = make(OID, len(bytes)+1)
+
+	// The first sub-identifier is 40*value1 + value2, where value2 is only
+	// bounded when value1 is 0 or 1. Under arc 2 it may span several bytes.
+	v, offset, err := parseBase128Int(bytes, 0, 1<<32+79)
+	if err != nil {
+		return
+	}
+	switch {
+	case v < 40:
+		s[0], s[1] = 0, uint32(v)
+	case v < 80:
+		s[0], s[1] = 1, uint32(v-40)
+	default:
+		s[0], s[1] = 2, uint32(v-80)
+	}
 
-	// The first byte is 40*value1 + value2:
-	s[0] = int(bytes[0]) / 40
-	s[1] = int(bytes[0]) % 40
 	i := 2
-	for offset := 1; offset < len(bytes); i++ {
-		var v int
-		v, offset, err = parseBase128Int(bytes, offset)
+	for ; offset < len(bytes); i++ {
+		v, offset, err = parseBase128Int(bytes, offset, 1<<32-1)
 		if err != nil {
 			return
 		}
-		s[i] = v
+		s[i] = uint32(v)
 	}
 	s = s[0:i]
 	return
 }
 
 // parseBase128Int parses a base-128 encoded int from the given offset in the
-// given byte slice. It returns the value and the new offset.
-func parseBase128Int(bytes []byte, initOffset int) (ret, offset int, err error) {
+// given byte slice. It returns the value and the new offset. Values larger
+// than max are rejected.
+func parseBase128Int(bytes []byte, initOffset int, max uint64) (ret uint64, offset int, err error) {
 	offset = initOffset
 	for shifted := 0; offset < len(bytes); shifted++ {
 		if shifted > 4 {
@@ -72,8 +87,12 @@ func parseBase128Int(bytes []byte, initOffset int) (ret, offset int, err error)
 		}
 		ret <<= 7
 		b := bytes[offset]
-		ret |= int(b & 0x7f)
+		ret |= uint64(b & 0x7f)
 		offset++
+		if ret > max {
+			err = fmt.Errorf("Structural Error: base 128 integer too large")
+			return
+		}
 		if b&0x80 == 0 {
 			return
 		}
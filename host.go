@@ -0,0 +1,298 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import "time"
+
+var (
+	oidHrStorageTable   = MustParseOID(".1.3.6.1.2.1.25.2.3")
+	oidHrDeviceTable    = MustParseOID(".1.3.6.1.2.1.25.3.2")
+	oidHrProcessorTable = MustParseOID(".1.3.6.1.2.1.25.3.3")
+	oidHrSWRunTable     = MustParseOID(".1.3.6.1.2.1.25.4.2")
+	oidHrSWRunPerfTable = MustParseOID(".1.3.6.1.2.1.25.5.1")
+)
+
+// Columns of the HOST-RESOURCES-MIB tables (RFC 2790)
+const (
+	hrStorageType               = 2
+	hrStorageDescr              = 3
+	hrStorageAllocationUnits    = 4
+	hrStorageSize               = 5
+	hrStorageUsed               = 6
+	hrStorageAllocationFailures = 7
+
+	hrDeviceType   = 2
+	hrDeviceDescr  = 3
+	hrDeviceID     = 4
+	hrDeviceStatus = 5
+	hrDeviceErrors = 6
+
+	hrProcessorFrwID = 1
+	hrProcessorLoad  = 2
+
+	hrSWRunName       = 2
+	hrSWRunID         = 3
+	hrSWRunPath       = 4
+	hrSWRunParameters = 5
+	hrSWRunType       = 6
+	hrSWRunStatus     = 7
+
+	hrSWRunPerfCPU = 1
+	hrSWRunPerfMem = 2
+)
+
+// HostResources is what HOST-RESOURCES-MIB describes of a host
+type HostResources struct {
+	Storage    []*HostStorage
+	Processors []*HostProcessor
+	Processes  []*HostProcess
+	Devices    []*HostDevice
+}
+
+// HostStorage is a storage area, such as a file system or the physical
+// memory, from a row of hrStorageTable
+type HostStorage struct {
+	Index int
+	// Type is the storage type, such as hrStorageFixedDisk
+	// (.1.3.6.1.2.1.25.2.1.4), and TypeName its name if it is known
+	Type     OID
+	TypeName string
+	Descr    string
+	// AllocationUnits is the size in bytes of the units Size and Used were
+	// reported in
+	AllocationUnits int
+	// Size and Used are in bytes
+	Size               uint64
+	Used               uint64
+	AllocationFailures uint64
+}
+
+// Free returns the unused size in bytes
+func (s *HostStorage) Free() uint64 {
+	if s.Used > s.Size {
+		return 0
+	}
+	return s.Size - s.Used
+}
+
+// Utilization returns the used fraction of the size, or zero if the size
+// is zero
+func (s *HostStorage) Utilization() float64 {
+	if s.Size == 0 {
+		return 0
+	}
+	return float64(s.Used) / float64(s.Size)
+}
+
+// HostProcessor is a processor, from a row of hrProcessorTable
+type HostProcessor struct {
+	// Index is the hrDeviceIndex of the processor
+	Index      int
+	FirmwareID OID
+	// Load is the average percentage of time the processor was busy over
+	// the last minute
+	Load int
+	// Device is the processor's row of hrDeviceTable, if it was retrieved
+	Device *HostDevice
+}
+
+// HostProcess is a program loaded on the host, from a row of hrSWRunTable
+// and its hrSWRunPerfTable counterpart
+type HostProcess struct {
+	// Index is usually the process ID
+	Index      int
+	Name       string
+	ID         OID
+	Path       string
+	Parameters string
+	// Type is unknown(1), operatingSystem(2), deviceDriver(3) or
+	// application(4)
+	Type int
+	// Status is running(1), runnable(2), notRunnable(3) or invalid(4)
+	Status int
+	// CPU is the processor time the process has consumed
+	CPU time.Duration
+	// Memory is the real memory allocated to the process in bytes
+	Memory uint64
+}
+
+// HostDevice is a device of the host, from a row of hrDeviceTable
+type HostDevice struct {
+	Index int
+	// Type is the device type, such as hrDeviceProcessor
+	// (.1.3.6.1.2.1.25.3.1.3), and TypeName its name if it is known
+	Type     OID
+	TypeName string
+	Descr    string
+	ID       OID
+	// Status is unknown(1), running(2), warning(3), testing(4) or down(5)
+	Status int
+	Errors uint64
+}
+
+// HostResources retrieves the storage, processors, processes and devices of
+// the target from HOST-RESOURCES-MIB, walking each table with GetBulk
+// requests (GetNext for SNMPv1). Processors are joined with their devices.
+//
+// If a request fails, what was retrieved so far is returned along with the
+// error.
+func (x *GoSNMP) HostResources() (*HostResources, error) {
+	h := &HostResources{}
+	var err error
+	if h.Storage, err = x.HostStorage(); err != nil {
+		return h, err
+	}
+	if h.Devices, err = x.HostDevices(); err != nil {
+		return h, err
+	}
+	if h.Processors, err = x.HostProcessors(); err != nil {
+		return h, err
+	}
+	devices := make(map[int]*HostDevice)
+	for _, d := range h.Devices {
+		devices[d.Index] = d
+	}
+	for _, p := range h.Processors {
+		p.Device = devices[p.Index]
+	}
+	h.Processes, err = x.HostProcesses()
+	return h, err
+}
+
+// HostStorage retrieves hrStorageTable, converting sizes to bytes
+func (x *GoSNMP) HostStorage() ([]*HostStorage, error) {
+	t, err := x.BulkGetTableOID(0, oidHrStorageTable,
+		hrStorageType, hrStorageDescr, hrStorageAllocationUnits, hrStorageSize, hrStorageUsed, hrStorageAllocationFailures)
+	if t == nil {
+		return nil, err
+	}
+	var storage []*HostStorage
+	for _, row := range t.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		s := &HostStorage{Index: int(row.Index[0])}
+		s.Type, s.TypeName = x.tableType(t, row, hrStorageType)
+		s.Descr, _ = tableString(t, row, hrStorageDescr)
+		s.AllocationUnits = tableInt(t, row, hrStorageAllocationUnits)
+		if s.AllocationUnits > 0 {
+			s.Size = hrStorageUnits(t, row, hrStorageSize) * uint64(s.AllocationUnits)
+			s.Used = hrStorageUnits(t, row, hrStorageUsed) * uint64(s.AllocationUnits)
+		}
+		s.AllocationFailures, _ = tableUint(t, row, hrStorageAllocationFailures)
+		storage = append(storage, s)
+	}
+	return storage, err
+}
+
+// HostProcessors retrieves hrProcessorTable
+func (x *GoSNMP) HostProcessors() ([]*HostProcessor, error) {
+	t, err := x.BulkGetTableOID(0, oidHrProcessorTable, hrProcessorFrwID, hrProcessorLoad)
+	if t == nil {
+		return nil, err
+	}
+	var processors []*HostProcessor
+	for _, row := range t.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		p := &HostProcessor{Index: int(row.Index[0])}
+		p.FirmwareID = tableOID(t, row, hrProcessorFrwID)
+		p.Load = tableInt(t, row, hrProcessorLoad)
+		processors = append(processors, p)
+	}
+	return processors, err
+}
+
+// HostProcesses retrieves hrSWRunTable, joined with the CPU time and memory
+// of hrSWRunPerfTable if the agent has it
+func (x *GoSNMP) HostProcesses() ([]*HostProcess, error) {
+	t, err := x.BulkGetTableOID(0, oidHrSWRunTable,
+		hrSWRunName, hrSWRunID, hrSWRunPath, hrSWRunParameters, hrSWRunType, hrSWRunStatus)
+	if t == nil {
+		return nil, err
+	}
+	var processes []*HostProcess
+	byIndex := make(map[uint32]*HostProcess)
+	for _, row := range t.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		p := &HostProcess{Index: int(row.Index[0])}
+		p.Name, _ = tableString(t, row, hrSWRunName)
+		p.ID = tableOID(t, row, hrSWRunID)
+		p.Path, _ = tableString(t, row, hrSWRunPath)
+		p.Parameters, _ = tableString(t, row, hrSWRunParameters)
+		p.Type = tableInt(t, row, hrSWRunType)
+		p.Status = tableInt(t, row, hrSWRunStatus)
+		processes = append(processes, p)
+		byIndex[row.Index[0]] = p
+	}
+	if err != nil {
+		return processes, err
+	}
+
+	perf, err := x.BulkGetTableOID(0, oidHrSWRunPerfTable, hrSWRunPerfCPU, hrSWRunPerfMem)
+	if perf == nil {
+		return processes, err
+	}
+	for _, row := range perf.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		if p := byIndex[row.Index[0]]; p != nil {
+			// hrSWRunPerfCPU is in centi-seconds, hrSWRunPerfMem in KBytes
+			p.CPU = time.Duration(tableInt(perf, row, hrSWRunPerfCPU)) * 10 * time.Millisecond
+			p.Memory = uint64(tableInt(perf, row, hrSWRunPerfMem)) * 1024
+		}
+	}
+	return processes, err
+}
+
+// HostDevices retrieves hrDeviceTable
+func (x *GoSNMP) HostDevices() ([]*HostDevice, error) {
+	t, err := x.BulkGetTableOID(0, oidHrDeviceTable,
+		hrDeviceType, hrDeviceDescr, hrDeviceID, hrDeviceStatus, hrDeviceErrors)
+	if t == nil {
+		return nil, err
+	}
+	var devices []*HostDevice
+	for _, row := range t.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		d := &HostDevice{Index: int(row.Index[0])}
+		d.Type, d.TypeName = x.tableType(t, row, hrDeviceType)
+		d.Descr, _ = tableString(t, row, hrDeviceDescr)
+		d.ID = tableOID(t, row, hrDeviceID)
+		d.Status = tableInt(t, row, hrDeviceStatus)
+		d.Errors, _ = tableUint(t, row, hrDeviceErrors)
+		devices = append(devices, d)
+	}
+	return devices, err
+}
+
+// tableType returns an AutonomousType cell and the name of the type
+func (x *GoSNMP) tableType(t *Table, row *TableRow, column uint32) (OID, string) {
+	oid := tableOID(t, row, column)
+	if oid == nil {
+		return nil, ""
+	}
+	if n := x.mibs().Node(oid); n != nil {
+		return oid, n.Name
+	}
+	return oid, ""
+}
+
+// hrStorageUnits returns a size of hrStorageTable in allocation units.
+// Agents report sizes beyond the Integer32 range, such as those of large
+// file systems, as negative numbers, which are taken as unsigned.
+func hrStorageUnits(t *Table, row *TableRow, column uint32) uint64 {
+	n := tableInt(t, row, column)
+	if n < 0 {
+		return uint64(uint32(int32(n)))
+	}
+	return uint64(n)
+}
@@ -0,0 +1,76 @@
+package gosnmp
+
+import (
+	"testing"
+	"time"
+)
+
+// hostMIB is an agent view of a host with memory, a large file system, a
+// processor and two processes
+var hostMIB = map[string]Value{
+	".1.3.6.1.2.1.25.2.3.1.2.1":      ObjectIdentifierValue{1, 3, 6, 1, 2, 1, 25, 2, 1, 2},
+	".1.3.6.1.2.1.25.2.3.1.3.1":      OctetStringValue("Physical memory"),
+	".1.3.6.1.2.1.25.2.3.1.4.1":      Integer32Value(1024),
+	".1.3.6.1.2.1.25.2.3.1.5.1":      Integer32Value(16384),
+	".1.3.6.1.2.1.25.2.3.1.6.1":      Integer32Value(4096),
+	".1.3.6.1.2.1.25.2.3.1.2.31":     ObjectIdentifierValue{1, 3, 6, 1, 2, 1, 25, 2, 1, 4},
+	".1.3.6.1.2.1.25.2.3.1.3.31":     OctetStringValue("/data"),
+	".1.3.6.1.2.1.25.2.3.1.4.31":     Integer32Value(4096),
+	".1.3.6.1.2.1.25.2.3.1.5.31":     Integer32Value(-1),
+	".1.3.6.1.2.1.25.2.3.1.6.31":     Integer32Value(1 << 30),
+	".1.3.6.1.2.1.25.3.2.1.2.196608": ObjectIdentifierValue{1, 3, 6, 1, 2, 1, 25, 3, 1, 3},
+	".1.3.6.1.2.1.25.3.2.1.3.196608": OctetStringValue("Intel Xeon"),
+	".1.3.6.1.2.1.25.3.2.1.5.196608": Integer32Value(2),
+	".1.3.6.1.2.1.25.3.3.1.1.196608": ObjectIdentifierValue{0, 0},
+	".1.3.6.1.2.1.25.3.3.1.2.196608": Integer32Value(37),
+	".1.3.6.1.2.1.25.4.2.1.2.1":      OctetStringValue("systemd"),
+	".1.3.6.1.2.1.25.4.2.1.4.1":      OctetStringValue("/sbin/init"),
+	".1.3.6.1.2.1.25.4.2.1.6.1":      Integer32Value(4),
+	".1.3.6.1.2.1.25.4.2.1.7.1":      Integer32Value(2),
+	".1.3.6.1.2.1.25.4.2.1.2.812":    OctetStringValue("sshd"),
+	".1.3.6.1.2.1.25.4.2.1.5.812":    OctetStringValue("-D"),
+	".1.3.6.1.2.1.25.4.2.1.7.812":    Integer32Value(1),
+	".1.3.6.1.2.1.25.5.1.1.1.812":    Integer32Value(250),
+	".1.3.6.1.2.1.25.5.1.1.2.812":    Integer32Value(5120),
+}
+
+// Test collecting storage, processors, processes and devices
+func TestHostResources(t *testing.T) {
+	agent := newTestAgent(t, hostMIB)
+	h, err := agent.client().HostResources()
+	if err != nil {
+		t.Fatalf("HostResources: %s", err)
+	}
+
+	if len(h.Storage) != 2 {
+		t.Fatalf("Expected 2 storage areas, got %d", len(h.Storage))
+	}
+	mem, data := h.Storage[0], h.Storage[1]
+	if mem.TypeName != "hrStorageRam" || mem.Descr != "Physical memory" || mem.Size != 16<<20 || mem.Used != 4<<20 ||
+		mem.Free() != 12<<20 || mem.Utilization() != 0.25 {
+		t.Errorf("Memory: %+v", mem)
+	}
+	// A size beyond the Integer32 range, reported as a negative number
+	if want := uint64(0xffffffff) * 4096; data.Size != want || data.Used != 4<<40 || data.TypeName != "hrStorageFixedDisk" {
+		t.Errorf("File system:\n\twant: %d\n\tgot : %+v", want, data)
+	}
+
+	if len(h.Devices) != 1 || len(h.Processors) != 1 {
+		t.Fatalf("Expected 1 device and 1 processor, got %d and %d", len(h.Devices), len(h.Processors))
+	}
+	cpu := h.Processors[0]
+	if cpu.Index != 196608 || cpu.Load != 37 || cpu.Device != h.Devices[0] || cpu.Device.TypeName != "hrDeviceProcessor" ||
+		cpu.Device.Descr != "Intel Xeon" || cpu.Device.Status != 2 {
+		t.Errorf("Processor: %+v %+v", cpu, cpu.Device)
+	}
+
+	if len(h.Processes) != 2 {
+		t.Fatalf("Expected 2 processes, got %d", len(h.Processes))
+	}
+	if p := h.Processes[0]; p.Index != 1 || p.Name != "systemd" || p.Path != "/sbin/init" || p.Type != 4 || p.Status != 2 || p.CPU != 0 {
+		t.Errorf("Process 1: %+v", p)
+	}
+	if p := h.Processes[1]; p.Index != 812 || p.Parameters != "-D" || p.CPU != 2500*time.Millisecond || p.Memory != 5<<20 {
+		t.Errorf("Process 812: %+v", p)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"net"
+)
+
+// IndexKind is the syntax of one component of a table INDEX clause, which
+// determines how it is encoded in the instance suffix (RFC 2578 7.7)
+type IndexKind uint8
+
+const (
+	// IndexInteger is an INTEGER, Unsigned32 or similar, encoded as a
+	// single arc. Decoded as uint32.
+	IndexInteger IndexKind = iota
+	// IndexOctetString is an OCTET STRING, encoded as a length arc followed
+	// by one arc per octet, or without the length if IMPLIED or of fixed
+	// size. Decoded as []byte.
+	IndexOctetString
+	// IndexIPAddress is an IpAddress, encoded as four arcs. Decoded as net.IP.
+	IndexIPAddress
+	// IndexMACAddress is a MacAddress or other 6 octet PhysAddress, encoded
+	// as six arcs. Decoded as net.HardwareAddr.
+	IndexMACAddress
+	// IndexOID is an OBJECT IDENTIFIER, encoded as a length arc followed by
+	// its arcs, or without the length if IMPLIED. Decoded as OID.
+	IndexOID
+	// IndexInetAddress is an InetAddressType followed by an InetAddress
+	// (RFC 4001), encoded as the type arc and a length-prefixed address.
+	// Decoded as InetAddress.
+	IndexInetAddress
+)
+
+// IndexComponent describes one component of a table INDEX clause
+type IndexComponent struct {
+	Kind IndexKind
+	// Size is the length of a fixed size OCTET STRING, which is encoded
+	// without a length arc
+	Size int
+	// Implied is set for the last component when declared IMPLIED, which
+	// is then encoded without a length arc
+	Implied bool
+}
+
+// IndexSpec describes the INDEX clause of a table. Its components are
+// decoded from and encoded into the instance suffix in order.
+type IndexSpec []IndexComponent
+
+// InetAddressType is the type of an InetAddress (RFC 4001)
+type InetAddressType uint32
+
+const (
+	InetUnknown InetAddressType = 0
+	InetIPv4    InetAddressType = 1
+	InetIPv6    InetAddressType = 2
+	InetIPv4z   InetAddressType = 3
+	InetIPv6z   InetAddressType = 4
+	InetDNS     InetAddressType = 16
+)
+
+// InetAddress is an address qualified by its InetAddressType
+type InetAddress struct {
+	Type    InetAddressType
+	Address []byte
+}
+
+// IP returns the address as a net.IP, or nil if it is not an IPv4 or IPv6
+// address. The zone of scoped addresses is dropped.
+func (a InetAddress) IP() net.IP {
+	switch {
+	case (a.Type == InetIPv4 || a.Type == InetIPv4z) && len(a.Address) >= 4:
+		return net.IP(a.Address[:4])
+	case (a.Type == InetIPv6 || a.Type == InetIPv6z) && len(a.Address) >= 16:
+		return net.IP(a.Address[:16])
+	}
+	return nil
+}
+
+func (a InetAddress) String() string {
+	if ip := a.IP(); ip != nil {
+		return ip.String()
+	}
+	if a.Type == InetDNS {
+		return string(a.Address)
+	}
+	return hexString(a.Address)
+}
+
+// Decode decodes the instance suffix of a table row into one value per
+// component of the index specification
+func (spec IndexSpec) Decode(suffix OID) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(spec))
+	rest := suffix
+
+	for i, c := range spec {
+		value, remaining, err := c.decode(rest)
+		if err != nil {
+			return nil, indexError(suffix, i, err.Error())
+		}
+		values = append(values, value)
+		rest = remaining
+	}
+
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("Unable to decode index %s: %d trailing arcs", suffix, len(rest))
+	}
+	return values, nil
+}
+
+// decode decodes the component from the start of arcs, returning the value
+// and the remaining arcs
+func (c IndexComponent) decode(arcs OID) (value interface{}, rest OID, err error) {
+	switch c.Kind {
+	case IndexInteger:
+		if len(arcs) < 1 {
+			return nil, nil, fmt.Errorf("missing integer")
+		}
+		value, rest = arcs[0], arcs[1:]
+	case IndexIPAddress:
+		var octets []byte
+		if octets, rest, err = takeOctets(arcs, 4); err == nil {
+			value = net.IP(octets)
+		}
+	case IndexMACAddress:
+		var octets []byte
+		if octets, rest, err = takeOctets(arcs, 6); err == nil {
+			value = net.HardwareAddr(octets)
+		}
+	case IndexOctetString:
+		var length int
+		if length, rest, err = takeLength(arcs, c); err == nil {
+			value, rest, err = takeOctets(rest, length)
+		}
+	case IndexOID:
+		var length int
+		if length, rest, err = takeLength(arcs, c); err == nil {
+			if length > len(rest) {
+				err = fmt.Errorf("OID length %d exceeds %d remaining arcs", length, len(rest))
+			} else {
+				value, rest = rest[:length].Copy(), rest[length:]
+			}
+		}
+	case IndexInetAddress:
+		if len(arcs) < 1 {
+			return nil, nil, fmt.Errorf("missing address type")
+		}
+		addr := InetAddress{Type: InetAddressType(arcs[0])}
+		var length int
+		if length, rest, err = takeLength(arcs[1:], c); err == nil {
+			if addr.Address, rest, err = takeOctets(rest, length); err == nil {
+				value = addr
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown index kind %d", c.Kind)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, rest, nil
+}
+
+// Encode encodes index values into an instance suffix, which can be appended
+// to a column OID to address a row, for instance when creating one. The
+// values may be given as any of the types Decode returns, or as int for
+// integers, string for octet strings and net.IP for InetAddresses.
+func (spec IndexSpec) Encode(values ...interface{}) (OID, error) {
+	if len(values) != len(spec) {
+		return nil, fmt.Errorf("Unable to encode index: %d values for %d components", len(values), len(spec))
+	}
+
+	var suffix OID
+	for i, c := range spec {
+		var err error
+
+		switch c.Kind {
+		case IndexInteger:
+			var n uint32
+			if n, err = indexInteger(values[i]); err == nil {
+				suffix = append(suffix, n)
+			}
+		case IndexIPAddress:
+			ip, ok := values[i].(net.IP)
+			if ip = ip.To4(); !ok || ip == nil {
+				err = fmt.Errorf("%v is not an IPv4 address", values[i])
+				break
+			}
+			suffix = appendOctets(suffix, ip, c, false)
+		case IndexMACAddress:
+			mac, ok := values[i].(net.HardwareAddr)
+			if !ok || len(mac) != 6 {
+				err = fmt.Errorf("%v is not a MAC address", values[i])
+				break
+			}
+			suffix = appendOctets(suffix, mac, c, false)
+		case IndexOctetString:
+			var octets []byte
+			switch v := values[i].(type) {
+			case []byte:
+				octets = v
+			case string:
+				octets = []byte(v)
+			default:
+				err = fmt.Errorf("%v is not an octet string", values[i])
+			}
+			if err == nil && c.Size > 0 && len(octets) != c.Size {
+				err = fmt.Errorf("octet string length %d, want %d", len(octets), c.Size)
+			}
+			if err == nil {
+				suffix = appendOctets(suffix, octets, c, true)
+			}
+		case IndexOID:
+			oid, ok := values[i].(OID)
+			if !ok {
+				err = fmt.Errorf("%v is not an OID", values[i])
+				break
+			}
+			if !c.Implied {
+				suffix = append(suffix, uint32(len(oid)))
+			}
+			suffix = append(suffix, oid...)
+		case IndexInetAddress:
+			var addr InetAddress
+			switch v := values[i].(type) {
+			case InetAddress:
+				addr = v
+			case net.IP:
+				if ip4 := v.To4(); ip4 != nil {
+					addr = InetAddress{InetIPv4, ip4}
+				} else {
+					addr = InetAddress{InetIPv6, v.To16()}
+				}
+			default:
+				err = fmt.Errorf("%v is not an InetAddress", values[i])
+			}
+			if err == nil {
+				suffix = append(suffix, uint32(addr.Type))
+				suffix = appendOctets(suffix, addr.Address, c, true)
+			}
+		default:
+			err = fmt.Errorf("unknown index kind %d", c.Kind)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("Unable to encode index component %d: %s", i+1, err.Error())
+		}
+	}
+	return suffix, nil
+}
+
+// DecodeIndex decodes the index of the row according to spec
+func (r *TableRow) DecodeIndex(spec IndexSpec) ([]interface{}, error) {
+	return spec.Decode(r.Index)
+}
+
+// takeLength returns the length of a variable length component, which is
+// the fixed size, the number of remaining arcs if implied, or the next arc
+func takeLength(arcs OID, c IndexComponent) (int, OID, error) {
+	switch {
+	case c.Size > 0:
+		return c.Size, arcs, nil
+	case c.Implied:
+		return len(arcs), arcs, nil
+	case len(arcs) < 1:
+		return 0, nil, fmt.Errorf("missing length")
+	case arcs[0] > uint32(len(arcs)-1):
+		// Compared before converting, as int may not hold every arc
+		return 0, nil, fmt.Errorf("length %d exceeds %d remaining arcs", arcs[0], len(arcs)-1)
+	}
+	return int(arcs[0]), arcs[1:], nil
+}
+
+// takeOctets decodes length arcs as octets
+func takeOctets(arcs OID, length int) ([]byte, OID, error) {
+	if length > len(arcs) {
+		return nil, nil, fmt.Errorf("length %d exceeds %d remaining arcs", length, len(arcs))
+	}
+	octets := make([]byte, length)
+	for i, arc := range arcs[:length] {
+		if arc > 255 {
+			return nil, nil, fmt.Errorf("arc %d is not an octet", arc)
+		}
+		octets[i] = byte(arc)
+	}
+	return octets, arcs[length:], nil
+}
+
+// appendOctets encodes octets as arcs, prefixed by their length if the
+// component has a variable length
+func appendOctets(suffix OID, octets []byte, c IndexComponent, variable bool) OID {
+	if variable && c.Size == 0 && !c.Implied {
+		suffix = append(suffix, uint32(len(octets)))
+	}
+	for _, b := range octets {
+		suffix = append(suffix, uint32(b))
+	}
+	return suffix
+}
+
+func indexInteger(v interface{}) (uint32, error) {
+	var n int64
+	switch v := v.(type) {
+	case uint32:
+		return v, nil
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case uint:
+		n = int64(v)
+	case uint64:
+		if v > 1<<32-1 {
+			return 0, fmt.Errorf("%d out of range", v)
+		}
+		return uint32(v), nil
+	default:
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+	if n < 0 || n > 1<<32-1 {
+		return 0, fmt.Errorf("%d out of range", n)
+	}
+	return uint32(n), nil
+}
+
+func indexError(suffix OID, i int, msg string) error {
+	return fmt.Errorf("Unable to decode index %s component %d: %s", suffix, i+1, msg)
+}
@@ -0,0 +1,108 @@
+package gosnmp
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+var indexTests = []struct {
+	name   string
+	spec   IndexSpec
+	suffix string
+	values []interface{}
+}{
+	{
+		"ipAddrTable",
+		IndexSpec{{Kind: IndexIPAddress}},
+		"10.0.0.1",
+		[]interface{}{net.IP{10, 0, 0, 1}},
+	},
+	{
+		"ipNetToPhysicalTable",
+		IndexSpec{{Kind: IndexInteger}, {Kind: IndexInetAddress}},
+		"3.1.4.192.168.1.254",
+		[]interface{}{uint32(3), InetAddress{InetIPv4, []byte{192, 168, 1, 254}}},
+	},
+	{
+		"ipNetToPhysicalTable IPv6",
+		IndexSpec{{Kind: IndexInteger}, {Kind: IndexInetAddress}},
+		"3.2.16.254.128.0.0.0.0.0.0.2.27.33.255.254.128.0.1",
+		[]interface{}{uint32(3), InetAddress{InetIPv6, net.ParseIP("fe80::21b:21ff:fe80:1")}},
+	},
+	{
+		"dot1qTpFdbTable",
+		IndexSpec{{Kind: IndexInteger}, {Kind: IndexMACAddress}},
+		"10.0.27.33.255.128.1",
+		[]interface{}{uint32(10), net.HardwareAddr{0x00, 0x1b, 0x21, 0xff, 0x80, 0x01}},
+	},
+	{
+		"snmpCommunityTable",
+		IndexSpec{{Kind: IndexOctetString, Implied: true}},
+		"112.117.98.108.105.99",
+		[]interface{}{[]byte("public")},
+	},
+	{
+		"vacmAccessTable",
+		IndexSpec{{Kind: IndexOctetString}, {Kind: IndexOctetString}, {Kind: IndexInteger}, {Kind: IndexInteger}},
+		"2.118.49.0.2.1",
+		[]interface{}{[]byte("v1"), []byte{}, uint32(2), uint32(1)},
+	},
+	{
+		"OID",
+		IndexSpec{{Kind: IndexOID}, {Kind: IndexOID, Implied: true}},
+		"3.1.3.6.2.1",
+		[]interface{}{OID{1, 3, 6}, OID{2, 1}},
+	},
+}
+
+// Test index decoding and encoding of common INDEX clauses
+func TestIndexCodec(t *testing.T) {
+	for _, test := range indexTests {
+		suffix := MustParseOID(test.suffix)
+
+		values, err := test.spec.Decode(suffix)
+		if err != nil {
+			t.Errorf("%s: unable to decode: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(values, test.values) {
+			t.Errorf("%s decode:\n\twant: %#v\n\tgot : %#v", test.name, test.values, values)
+		}
+
+		encoded, err := test.spec.Encode(test.values...)
+		if err != nil {
+			t.Errorf("%s: unable to encode: %s", test.name, err)
+			continue
+		}
+		if !encoded.Equal(suffix) {
+			t.Errorf("%s encode:\n\twant: %s\n\tgot : %s", test.name, suffix, encoded)
+		}
+	}
+
+	// Encode accepts convenience types
+	spec := IndexSpec{{Kind: IndexInteger}, {Kind: IndexInetAddress}, {Kind: IndexOctetString}}
+	if suffix, err := spec.Encode(3, net.ParseIP("10.0.0.1"), "ab"); err != nil || suffix.String() != ".3.1.4.10.0.0.1.2.97.98" {
+		t.Errorf("Encode with convenience types: %s %v", suffix, err)
+	}
+
+	// Malformed suffixes are rejected
+	for _, s := range []string{"3", "3.1.4.10.0.0", "3.1.4.10.0.0.1.9", "3.1.4.10.0.300.1"} {
+		if _, err := indexTests[1].spec.Decode(MustParseOID(s)); err == nil {
+			t.Errorf("Expected error decoding %s", s)
+		}
+	}
+
+	// Lengths beyond the int range of 32-bit platforms are rejected
+	for _, spec := range []IndexSpec{{{Kind: IndexOctetString}}, {{Kind: IndexOID}}, {{Kind: IndexInetAddress}}} {
+		for _, length := range []uint32{1 << 31, 1<<32 - 1} {
+			suffix := OID{length, 1, 2}
+			if spec[0].Kind == IndexInetAddress {
+				suffix = OID{uint32(InetIPv4), length, 1, 2}
+			}
+			if _, err := spec.Decode(suffix); err == nil {
+				t.Errorf("Expected error decoding %s", suffix)
+			}
+		}
+	}
+}
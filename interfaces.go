@@ -0,0 +1,312 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"time"
+)
+
+var (
+	oidSysUpTime = MustParseOID(".1.3.6.1.2.1.1.3.0")
+	oidIfTable   = MustParseOID(".1.3.6.1.2.1.2.2")
+	oidIfXTable  = MustParseOID(".1.3.6.1.2.1.31.1.1")
+)
+
+// Columns of ifTable (RFC 2863)
+const (
+	ifDescr        = 2
+	ifType         = 3
+	ifMtu          = 4
+	ifSpeed        = 5
+	ifPhysAddress  = 6
+	ifAdminStatus  = 7
+	ifOperStatus   = 8
+	ifLastChange   = 9
+	ifInOctets     = 10
+	ifInUcastPkts  = 11
+	ifInDiscards   = 13
+	ifInErrors     = 14
+	ifOutOctets    = 16
+	ifOutUcastPkts = 17
+	ifOutDiscards  = 19
+	ifOutErrors    = 20
+)
+
+// Columns of ifXTable (RFC 2863)
+const (
+	ifName                     = 1
+	ifInMulticastPkts          = 2
+	ifInBroadcastPkts          = 3
+	ifOutMulticastPkts         = 4
+	ifOutBroadcastPkts         = 5
+	ifHCInOctets               = 6
+	ifHCInUcastPkts            = 7
+	ifHCInMulticastPkts        = 8
+	ifHCInBroadcastPkts        = 9
+	ifHCOutOctets              = 10
+	ifHCOutUcastPkts           = 11
+	ifHCOutMulticastPkts       = 12
+	ifHCOutBroadcastPkts       = 13
+	ifHighSpeed                = 15
+	ifAlias                    = 18
+	ifCounterDiscontinuityTime = 19
+)
+
+// Interface is a network interface, merged from its rows in ifTable and
+// ifXTable
+type Interface struct {
+	Index uint32
+	Descr string
+	// Name is ifName, or ifDescr if the agent has no ifXTable
+	Name  string
+	Alias string
+	// Type is the IANAifType, such as 6 for ethernetCsmacd
+	Type        int
+	MTU         int
+	PhysAddress net.HardwareAddr
+	// Speed is the bandwidth in bits per second, from ifHighSpeed when
+	// ifSpeed is saturated at 4294967295 or missing
+	Speed       uint64
+	AdminStatus int
+	OperStatus  int
+	// LastChange is the sysUpTime of the last change of OperStatus
+	LastChange time.Duration
+
+	InOctets         uint64
+	InUcastPkts      uint64
+	InMulticastPkts  uint64
+	InBroadcastPkts  uint64
+	InDiscards       uint64
+	InErrors         uint64
+	OutOctets        uint64
+	OutUcastPkts     uint64
+	OutMulticastPkts uint64
+	OutBroadcastPkts uint64
+	OutDiscards      uint64
+	OutErrors        uint64
+	// HighCapacity is set if the octet and packet counters are the 64-bit
+	// ifHC* counters rather than their 32-bit counterparts. Agents missing
+	// any of the ifHC* counters of an interface are only read through the
+	// 32-bit ones.
+	HighCapacity bool
+
+	// Time is when the interface was polled
+	Time time.Time
+
+	sysUpTime     SnmpPDU
+	discontinuity SnmpPDU
+}
+
+// InterfaceRates are the traffic rates of an Interface between two polls
+type InterfaceRates struct {
+	// InBits and OutBits are in bits per second
+	InBits  float64
+	OutBits float64
+	// InUtilization and OutUtilization are fractions of the interface
+	// Speed, or zero if the speed is unknown
+	InUtilization  float64
+	OutUtilization float64
+}
+
+// Interfaces retrieves all interfaces of the target, walking the columns of
+// ifTable and ifXTable with GetBulk requests (GetNext for SNMPv1) and
+// merging their rows by ifIndex. The 64-bit ifHC* counters are used when the
+// agent has them. sysUpTime.0 is requested along with the counters, so that
+// Rates can tell agent restarts apart.
+//
+// If a request fails, the interfaces retrieved so far are returned along
+// with the error.
+func (x *GoSNMP) Interfaces() ([]*Interface, error) {
+	now := time.Now()
+	ifTable, err := x.bulkGetTableUpTime(oidIfTable,
+		ifDescr, ifType, ifMtu, ifSpeed, ifPhysAddress, ifAdminStatus, ifOperStatus, ifLastChange,
+		ifInOctets, ifInUcastPkts, ifInDiscards, ifInErrors, ifOutOctets, ifOutUcastPkts, ifOutDiscards, ifOutErrors)
+	if ifTable == nil {
+		return nil, err
+	}
+	var interfaces []*Interface
+	byIndex := make(map[uint32]*Interface)
+	for _, row := range ifTable.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		i := &Interface{Index: row.Index[0], Time: now, sysUpTime: row.sysUpTime}
+		i.setIfEntry(ifTable, row)
+		interfaces = append(interfaces, i)
+		byIndex[i.Index] = i
+	}
+	if err != nil {
+		return interfaces, err
+	}
+
+	ifXTable, err := x.bulkGetTableUpTime(oidIfXTable,
+		ifName, ifInMulticastPkts, ifInBroadcastPkts, ifOutMulticastPkts, ifOutBroadcastPkts,
+		ifHCInOctets, ifHCInUcastPkts, ifHCInMulticastPkts, ifHCInBroadcastPkts,
+		ifHCOutOctets, ifHCOutUcastPkts, ifHCOutMulticastPkts, ifHCOutBroadcastPkts,
+		ifHighSpeed, ifAlias, ifCounterDiscontinuityTime)
+	if ifXTable == nil {
+		return interfaces, err
+	}
+	for _, row := range ifXTable.Rows {
+		if len(row.Index) != 1 {
+			continue
+		}
+		if i := byIndex[row.Index[0]]; i != nil {
+			i.setIfXEntry(ifXTable, row)
+		}
+	}
+	return interfaces, err
+}
+
+// setIfEntry fills in the interface from its ifTable row
+func (i *Interface) setIfEntry(t *Table, row *TableRow) {
+	i.Descr, _ = tableString(t, row, ifDescr)
+	i.Name = i.Descr
+	i.Type = tableInt(t, row, ifType)
+	i.MTU = tableInt(t, row, ifMtu)
+	i.Speed, _ = tableUint(t, row, ifSpeed)
+	if mac, ok := tableString(t, row, ifPhysAddress); ok && mac != "" {
+		i.PhysAddress = net.HardwareAddr(mac)
+	}
+	i.AdminStatus = tableInt(t, row, ifAdminStatus)
+	i.OperStatus = tableInt(t, row, ifOperStatus)
+	i.LastChange = TimeTicksValue(tableInt(t, row, ifLastChange)).Duration()
+
+	i.InOctets, _ = tableUint(t, row, ifInOctets)
+	i.InUcastPkts, _ = tableUint(t, row, ifInUcastPkts)
+	i.InDiscards, _ = tableUint(t, row, ifInDiscards)
+	i.InErrors, _ = tableUint(t, row, ifInErrors)
+	i.OutOctets, _ = tableUint(t, row, ifOutOctets)
+	i.OutUcastPkts, _ = tableUint(t, row, ifOutUcastPkts)
+	i.OutDiscards, _ = tableUint(t, row, ifOutDiscards)
+	i.OutErrors, _ = tableUint(t, row, ifOutErrors)
+}
+
+// setIfXEntry fills in the interface from its ifXTable row, preferring the
+// 64-bit counters
+func (i *Interface) setIfXEntry(t *Table, row *TableRow) {
+	if name, ok := tableString(t, row, ifName); ok && name != "" {
+		i.Name = name
+	}
+	i.Alias, _ = tableString(t, row, ifAlias)
+	if high, ok := tableUint(t, row, ifHighSpeed); ok && (i.Speed == 0xffffffff || i.Speed == 0) {
+		i.Speed = high * 1000000
+	}
+	i.discontinuity, _ = t.Cell(row, ifCounterDiscontinuityTime)
+
+	i.InMulticastPkts, _ = tableUint(t, row, ifInMulticastPkts)
+	i.InBroadcastPkts, _ = tableUint(t, row, ifInBroadcastPkts)
+	i.OutMulticastPkts, _ = tableUint(t, row, ifOutMulticastPkts)
+	i.OutBroadcastPkts, _ = tableUint(t, row, ifOutBroadcastPkts)
+
+	// The 64-bit counters are only used if the agent has all of them, so
+	// that HighCapacity holds for every counter
+	hc := map[uint32]*uint64{
+		ifHCInOctets:         &i.InOctets,
+		ifHCInUcastPkts:      &i.InUcastPkts,
+		ifHCInMulticastPkts:  &i.InMulticastPkts,
+		ifHCInBroadcastPkts:  &i.InBroadcastPkts,
+		ifHCOutOctets:        &i.OutOctets,
+		ifHCOutUcastPkts:     &i.OutUcastPkts,
+		ifHCOutMulticastPkts: &i.OutMulticastPkts,
+		ifHCOutBroadcastPkts: &i.OutBroadcastPkts,
+	}
+	values := make(map[uint32]uint64)
+	for column := range hc {
+		n, ok := tableUint(t, row, column)
+		if !ok {
+			return
+		}
+		values[column] = n
+	}
+	i.HighCapacity = true
+	for column, counter := range hc {
+		*counter = values[column]
+	}
+	i.sysUpTime = row.sysUpTime
+}
+
+// Rates computes the traffic rates of the interface since an earlier poll
+// of it. The flag tells why the rates cannot be trusted, if so, such as an
+// agent restart or a counter discontinuity between the polls.
+func (i *Interface) Rates(prev *Interface) (InterfaceRates, CounterFlag) {
+	var rates InterfaceRates
+	if prev.HighCapacity != i.HighCapacity {
+		return rates, CounterNotCounter
+	}
+
+	in := CounterDelta(prev.octetSample(prev.InOctets), i.octetSample(i.InOctets))
+	if !in.Valid() {
+		return rates, in.Flag
+	}
+	out := CounterDelta(prev.octetSample(prev.OutOctets), i.octetSample(i.OutOctets))
+	if !out.Valid() {
+		return rates, out.Flag
+	}
+
+	rates.InBits, rates.OutBits = in.Rate()*8, out.Rate()*8
+	if i.Speed > 0 {
+		rates.InUtilization = rates.InBits / float64(i.Speed)
+		rates.OutUtilization = rates.OutBits / float64(i.Speed)
+	}
+	return rates, CounterValid
+}
+
+// octetSample describes an octet counter of the interface as a sample
+func (i *Interface) octetSample(octets uint64) CounterSample {
+	typ := Asn1BER(Counter32)
+	if i.HighCapacity {
+		typ = Counter64
+	}
+	return CounterSample{
+		Time:              i.Time,
+		Counter:           SnmpPDU{Type: typ, Value: octets},
+		SysUpTime:         i.sysUpTime,
+		DiscontinuityTime: i.discontinuity,
+	}
+}
+
+// pduUint returns the value of an integer, counter, gauge or TimeTicks
+// variable
+func pduUint(pdu SnmpPDU) (uint64, bool) {
+	switch v := pdu.Value.(type) {
+	case int:
+		if v >= 0 {
+			return uint64(v), true
+		}
+	case uint64:
+		return v, true
+	}
+	return 0, false
+}
+
+func tableUint(t *Table, row *TableRow, column uint32) (uint64, bool) {
+	pdu, ok := t.Cell(row, column)
+	if !ok {
+		return 0, false
+	}
+	return pduUint(pdu)
+}
+
+func tableInt(t *Table, row *TableRow, column uint32) int {
+	pdu, _ := t.Cell(row, column)
+	n, _ := pdu.Value.(int)
+	return n
+}
+
+func tableString(t *Table, row *TableRow, column uint32) (string, bool) {
+	pdu, _ := t.Cell(row, column)
+	s, ok := pdu.Value.(string)
+	return s, ok
+}
+
+func tableOID(t *Table, row *TableRow, column uint32) OID {
+	pdu, _ := t.Cell(row, column)
+	if arcs, ok := pdu.Value.([]int); ok {
+		return intsToOID(arcs)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package gosnmp
+
+import (
+	"testing"
+	"time"
+)
+
+// ifMIB is an agent view with two interfaces: eth0 has the ifXTable 64-bit
+// counters and a 10 Gbit/s speed beyond ifSpeed, lo only has ifTable
+var ifMIB = map[string]Value{
+	".1.3.6.1.2.1.1.3.0":         TimeTicksValue(360000),
+	".1.3.6.1.2.1.2.2.1.2.1":     OctetStringValue("eth0"),
+	".1.3.6.1.2.1.2.2.1.3.1":     Integer32Value(6),
+	".1.3.6.1.2.1.2.2.1.4.1":     Integer32Value(1500),
+	".1.3.6.1.2.1.2.2.1.5.1":     Gauge32Value(4294967295),
+	".1.3.6.1.2.1.2.2.1.6.1":     OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 1},
+	".1.3.6.1.2.1.2.2.1.7.1":     Integer32Value(1),
+	".1.3.6.1.2.1.2.2.1.8.1":     Integer32Value(1),
+	".1.3.6.1.2.1.2.2.1.9.1":     TimeTicksValue(1000),
+	".1.3.6.1.2.1.2.2.1.10.1":    Counter32Value(1000),
+	".1.3.6.1.2.1.2.2.1.11.1":    Counter32Value(10),
+	".1.3.6.1.2.1.2.2.1.14.1":    Counter32Value(3),
+	".1.3.6.1.2.1.2.2.1.16.1":    Counter32Value(2000),
+	".1.3.6.1.2.1.2.2.1.2.2":     OctetStringValue("lo"),
+	".1.3.6.1.2.1.2.2.1.3.2":     Integer32Value(24),
+	".1.3.6.1.2.1.2.2.1.5.2":     Gauge32Value(10000000),
+	".1.3.6.1.2.1.2.2.1.7.2":     Integer32Value(1),
+	".1.3.6.1.2.1.2.2.1.8.2":     Integer32Value(2),
+	".1.3.6.1.2.1.2.2.1.10.2":    Counter32Value(500),
+	".1.3.6.1.2.1.2.2.1.16.2":    Counter32Value(600),
+	".1.3.6.1.2.1.31.1.1.1.1.1":  OctetStringValue("Ethernet0"),
+	".1.3.6.1.2.1.31.1.1.1.6.1":  Counter64Value(1 << 40),
+	".1.3.6.1.2.1.31.1.1.1.7.1":  Counter64Value(1 << 33),
+	".1.3.6.1.2.1.31.1.1.1.8.1":  Counter64Value(0),
+	".1.3.6.1.2.1.31.1.1.1.9.1":  Counter64Value(0),
+	".1.3.6.1.2.1.31.1.1.1.10.1": Counter64Value(1<<40 + 1),
+	".1.3.6.1.2.1.31.1.1.1.11.1": Counter64Value(0),
+	".1.3.6.1.2.1.31.1.1.1.12.1": Counter64Value(0),
+	".1.3.6.1.2.1.31.1.1.1.13.1": Counter64Value(0),
+	".1.3.6.1.2.1.31.1.1.1.15.1": Gauge32Value(10000),
+	".1.3.6.1.2.1.31.1.1.1.18.1": OctetStringValue("uplink"),
+	".1.3.6.1.2.1.31.1.1.1.19.1": TimeTicksValue(0),
+}
+
+// Test collecting interfaces from ifTable and ifXTable
+func TestInterfaces(t *testing.T) {
+	agent := newTestAgent(t, ifMIB)
+	interfaces, err := agent.client().Interfaces()
+	if err != nil {
+		t.Fatalf("Interfaces: %s", err)
+	}
+	if len(interfaces) != 2 {
+		t.Fatalf("Expected 2 interfaces, got %d", len(interfaces))
+	}
+
+	eth0, lo := interfaces[0], interfaces[1]
+	if eth0.Index != 1 || eth0.Name != "Ethernet0" || eth0.Descr != "eth0" || eth0.Alias != "uplink" ||
+		eth0.Type != 6 || eth0.MTU != 1500 || eth0.PhysAddress.String() != "00:1b:21:ff:80:01" ||
+		eth0.AdminStatus != 1 || eth0.OperStatus != 1 || eth0.LastChange != 10*time.Second {
+		t.Errorf("eth0: %+v", eth0)
+	}
+	if eth0.Speed != 10000000000 {
+		t.Errorf("eth0 speed:\n\twant: %d\n\tgot : %d", uint64(10000000000), eth0.Speed)
+	}
+	if !eth0.HighCapacity || eth0.InOctets != 1<<40 || eth0.OutOctets != 1<<40+1 || eth0.InUcastPkts != 1<<33 || eth0.InErrors != 3 {
+		t.Errorf("eth0 counters: %+v", eth0)
+	}
+
+	if lo.Index != 2 || lo.Name != "lo" || lo.Speed != 10000000 || lo.OperStatus != 2 || lo.PhysAddress != nil {
+		t.Errorf("lo: %+v", lo)
+	}
+	if lo.HighCapacity || lo.InOctets != 500 || lo.OutOctets != 600 {
+		t.Errorf("lo counters: %+v", lo)
+	}
+
+	// sysUpTime is polled in the same requests as the counters
+	for _, i := range interfaces {
+		if ticks, ok := timeTicks(i.sysUpTime); !ok || ticks != 360000 {
+			t.Errorf("%s sysUpTime: %+v", i.Name, i.sysUpTime)
+		}
+	}
+	for _, req := range agent.received() {
+		if req.RequestType != GetBulkRequest || req.Error != 1 || !req.VarBinds[0].Name.Equal(oidSysUpTime.Parent()) {
+			t.Errorf("Expected sysUpTime as the non-repeater of %v", req.VarBinds)
+		}
+	}
+}
+
+// Test the 32-bit counters are kept when an ifHC* counter is missing
+func TestInterfacesPartialHC(t *testing.T) {
+	mib := make(map[string]Value)
+	for name, value := range ifMIB {
+		mib[name] = value
+	}
+	delete(mib, ".1.3.6.1.2.1.31.1.1.1.13.1")
+	agent := newTestAgent(t, mib)
+	interfaces, err := agent.client().Interfaces()
+	if err != nil || len(interfaces) != 2 {
+		t.Fatalf("Interfaces: %v %v", interfaces, err)
+	}
+	if eth0 := interfaces[0]; eth0.HighCapacity || eth0.InOctets != 1000 || eth0.OutOctets != 2000 || eth0.InUcastPkts != 10 {
+		t.Errorf("eth0 counters: %+v", eth0)
+	}
+}
+
+// Test computing interface rates and utilisation between polls
+func TestInterfaceRates(t *testing.T) {
+	uptime := func(ticks int) SnmpPDU { return SnmpPDU{Type: TimeTicks, Value: ticks} }
+	start := time.Unix(1700000000, 0)
+
+	prev := &Interface{Speed: 100000000, InOctets: 0xffffff00, OutOctets: 1000, Time: start, sysUpTime: uptime(1000)}
+	cur := &Interface{Speed: 100000000, InOctets: 1250000 - 0x100, OutOctets: 1000 + 2500000, Time: start.Add(10 * time.Second), sysUpTime: uptime(2000)}
+
+	rates, flag := cur.Rates(prev)
+	if flag != CounterValid {
+		t.Fatalf("Rates flag: %s", flag)
+	}
+	want := InterfaceRates{InBits: 1000000, OutBits: 2000000, InUtilization: 0.01, OutUtilization: 0.02}
+	if rates != want {
+		t.Errorf("Rates:\n\twant: %+v\n\tgot : %+v", want, rates)
+	}
+
+	restarted := *cur
+	restarted.sysUpTime = uptime(10)
+	if _, flag := restarted.Rates(prev); flag != CounterRestarted {
+		t.Errorf("Expected restart, got %s", flag)
+	}
+
+	upgraded := *cur
+	upgraded.HighCapacity = true
+	if _, flag := upgraded.Rates(prev); flag != CounterNotCounter {
+		t.Errorf("Expected counter mismatch, got %s", flag)
+	}
+
+	unknown := *cur
+	unknown.Speed = 0
+	if rates, flag := unknown.Rates(prev); flag != CounterValid || rates.InUtilization != 0 || rates.InBits != 1000000 {
+		t.Errorf("Unknown speed: %+v %s", rates, flag)
+	}
+}
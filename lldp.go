@@ -0,0 +1,289 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"strconv"
+)
+
+var (
+	oidLldpLocChassisIdSubtype = MustParseOID(".1.0.8802.1.1.2.1.3.1.0")
+	oidLldpLocChassisId        = MustParseOID(".1.0.8802.1.1.2.1.3.2.0")
+	oidLldpLocSysName          = MustParseOID(".1.0.8802.1.1.2.1.3.3.0")
+	oidLldpLocSysDesc          = MustParseOID(".1.0.8802.1.1.2.1.3.4.0")
+	oidLldpLocPortTable        = MustParseOID(".1.0.8802.1.1.2.1.3.7")
+	oidLldpRemTable            = MustParseOID(".1.0.8802.1.1.2.1.4.1")
+	oidLldpRemManAddrTable     = MustParseOID(".1.0.8802.1.1.2.1.4.2")
+)
+
+// Columns of lldpLocPortTable, lldpRemTable and lldpRemManAddrTable
+// (IEEE 802.1AB)
+const (
+	lldpLocPortIdSubtype = 2
+	lldpLocPortId        = 3
+	lldpLocPortDesc      = 4
+
+	lldpRemChassisIdSubtype = 4
+	lldpRemChassisId        = 5
+	lldpRemPortIdSubtype    = 6
+	lldpRemPortId           = 7
+	lldpRemPortDesc         = 8
+	lldpRemSysName          = 9
+	lldpRemSysDesc          = 10
+	lldpRemSysCapSupported  = 11
+	lldpRemSysCapEnabled    = 12
+
+	lldpRemManAddrIfSubtype = 3
+)
+
+// LLDPChassisIDSubtype is how an LLDP chassis ID is to be interpreted
+type LLDPChassisIDSubtype int
+
+const (
+	LLDPChassisComponent LLDPChassisIDSubtype = 1
+	LLDPChassisIfAlias   LLDPChassisIDSubtype = 2
+	LLDPChassisPort      LLDPChassisIDSubtype = 3
+	LLDPChassisMAC       LLDPChassisIDSubtype = 4
+	LLDPChassisNetwork   LLDPChassisIDSubtype = 5
+	LLDPChassisIfName    LLDPChassisIDSubtype = 6
+	LLDPChassisLocal     LLDPChassisIDSubtype = 7
+)
+
+var lldpChassisIDSubtypeStrings = map[LLDPChassisIDSubtype]string{
+	LLDPChassisComponent: "chassisComponent",
+	LLDPChassisIfAlias:   "interfaceAlias",
+	LLDPChassisPort:      "portComponent",
+	LLDPChassisMAC:       "macAddress",
+	LLDPChassisNetwork:   "networkAddress",
+	LLDPChassisIfName:    "interfaceName",
+	LLDPChassisLocal:     "local",
+}
+
+func (s LLDPChassisIDSubtype) String() string {
+	if name, ok := lldpChassisIDSubtypeStrings[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// LLDPPortIDSubtype is how an LLDP port ID is to be interpreted
+type LLDPPortIDSubtype int
+
+const (
+	LLDPPortIfAlias   LLDPPortIDSubtype = 1
+	LLDPPortComponent LLDPPortIDSubtype = 2
+	LLDPPortMAC       LLDPPortIDSubtype = 3
+	LLDPPortNetwork   LLDPPortIDSubtype = 4
+	LLDPPortIfName    LLDPPortIDSubtype = 5
+	LLDPPortCircuitID LLDPPortIDSubtype = 6
+	LLDPPortLocal     LLDPPortIDSubtype = 7
+)
+
+var lldpPortIDSubtypeStrings = map[LLDPPortIDSubtype]string{
+	LLDPPortIfAlias:   "interfaceAlias",
+	LLDPPortComponent: "portComponent",
+	LLDPPortMAC:       "macAddress",
+	LLDPPortNetwork:   "networkAddress",
+	LLDPPortIfName:    "interfaceName",
+	LLDPPortCircuitID: "agentCircuitId",
+	LLDPPortLocal:     "local",
+}
+
+func (s LLDPPortIDSubtype) String() string {
+	if name, ok := lldpPortIDSubtypeStrings[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// LLDPSystem is the LLDP view of a device: its own identity and the
+// neighbours it has discovered
+type LLDPSystem struct {
+	ChassisIDSubtype LLDPChassisIDSubtype
+	// ChassisID is decoded according to its subtype: MAC addresses as
+	// "00:1b:21:ff:80:01", network addresses as IP addresses, and other IDs
+	// as text, or as hex bytes if they are not printable
+	ChassisID string
+	SysName   string
+	SysDesc   string
+	Neighbors []*LLDPNeighbor
+}
+
+// LLDPNeighbor is a remote system discovered on a local port, from a row of
+// lldpRemTable
+type LLDPNeighbor struct {
+	// LocalPortNum is lldpRemLocalPortNum, which is usually, but not
+	// necessarily, the ifIndex of the local port
+	LocalPortNum uint32
+	// LocalPortID and LocalPortDesc are from lldpLocPortTable, or empty if
+	// the agent does not have the port there
+	LocalPortID   string
+	LocalPortDesc string
+	RemoteIndex   uint32
+
+	ChassisIDSubtype LLDPChassisIDSubtype
+	ChassisID        string
+	PortIDSubtype    LLDPPortIDSubtype
+	PortID           string
+	PortDesc         string
+	SysName          string
+	SysDesc          string
+	// CapSupported and CapEnabled are the system capabilities, such as
+	// bridge(2) and router(4)
+	CapSupported Bits
+	CapEnabled   Bits
+	// ManagementAddresses are from lldpRemManAddrTable. Address families
+	// other than IPv4, IPv6 and DNS have the InetUnknown type.
+	ManagementAddresses []InetAddress
+}
+
+// LLDP retrieves the local identity of the target and the neighbours it
+// has discovered, from LLDP-MIB (IEEE 802.1AB-2005). Neighbours are in the
+// order of lldpRemTable.
+//
+// If a request fails, what was retrieved so far is returned along with the
+// error. The neighbours are still retrieved if lldpLocPortTable fails, with
+// no LocalPortID or LocalPortDesc, and that error is returned unless a later
+// request fails too.
+func (x *GoSNMP) LLDP() (*LLDPSystem, error) {
+	varbinds, err := x.getScalars([]OID{oidLldpLocChassisIdSubtype, oidLldpLocChassisId, oidLldpLocSysName, oidLldpLocSysDesc})
+	if err != nil {
+		return nil, err
+	}
+	sys := &LLDPSystem{}
+	var chassisID []byte
+	for _, vb := range varbinds {
+		switch v := vb.Value.(type) {
+		case Integer32Value:
+			if vb.Name.Equal(oidLldpLocChassisIdSubtype) {
+				sys.ChassisIDSubtype = LLDPChassisIDSubtype(v)
+			}
+		case OctetStringValue:
+			switch {
+			case vb.Name.Equal(oidLldpLocChassisId):
+				chassisID = v
+			case vb.Name.Equal(oidLldpLocSysName):
+				sys.SysName = string(v)
+			case vb.Name.Equal(oidLldpLocSysDesc):
+				sys.SysDesc = string(v)
+			}
+		}
+	}
+	sys.ChassisID = lldpChassisID(sys.ChassisIDSubtype, chassisID)
+
+	locPorts, locErr := x.BulkGetTableOID(0, oidLldpLocPortTable, lldpLocPortIdSubtype, lldpLocPortId, lldpLocPortDesc)
+	if locPorts == nil {
+		locPorts = &Table{}
+	}
+
+	remTable, err := x.BulkGetTableOID(0, oidLldpRemTable,
+		lldpRemChassisIdSubtype, lldpRemChassisId, lldpRemPortIdSubtype, lldpRemPortId, lldpRemPortDesc,
+		lldpRemSysName, lldpRemSysDesc, lldpRemSysCapSupported, lldpRemSysCapEnabled)
+	if remTable == nil {
+		return sys, err
+	}
+	byIndex := make(map[string]*LLDPNeighbor)
+	for _, row := range remTable.Rows {
+		// Indexed by lldpRemTimeMark, lldpRemLocalPortNum and lldpRemIndex
+		if len(row.Index) != 3 {
+			continue
+		}
+		n := &LLDPNeighbor{LocalPortNum: row.Index[1], RemoteIndex: row.Index[2]}
+		n.setRemEntry(remTable, row)
+		if port := locPorts.Row(OID{n.LocalPortNum}); port != nil {
+			subtype := LLDPPortIDSubtype(tableInt(locPorts, port, lldpLocPortIdSubtype))
+			id, _ := tableString(locPorts, port, lldpLocPortId)
+			n.LocalPortID = lldpPortID(subtype, []byte(id))
+			n.LocalPortDesc, _ = tableString(locPorts, port, lldpLocPortDesc)
+		}
+		sys.Neighbors = append(sys.Neighbors, n)
+		byIndex[row.Index.String()] = n
+	}
+	if err != nil {
+		return sys, err
+	}
+
+	// The management addresses are only in the index of
+	// lldpRemManAddrTable, after the index of the neighbour: the address
+	// family and the length-prefixed address
+	manAddrs, err := x.BulkGetTableOID(0, oidLldpRemManAddrTable, lldpRemManAddrIfSubtype)
+	if manAddrs == nil {
+		return sys, err
+	}
+	if err == nil {
+		err = locErr
+	}
+	for _, row := range manAddrs.Rows {
+		if len(row.Index) < 5 || len(row.Index) != 5+int(row.Index[4]) {
+			continue
+		}
+		n := byIndex[row.Index[:3].String()]
+		if n == nil {
+			continue
+		}
+		address := make([]byte, row.Index[4])
+		for i := range address {
+			address[i] = byte(row.Index[5+i])
+		}
+		n.ManagementAddresses = append(n.ManagementAddresses, lldpAddress(row.Index[3], address))
+	}
+	return sys, err
+}
+
+// setRemEntry fills in the neighbour from its lldpRemTable row
+func (n *LLDPNeighbor) setRemEntry(t *Table, row *TableRow) {
+	n.ChassisIDSubtype = LLDPChassisIDSubtype(tableInt(t, row, lldpRemChassisIdSubtype))
+	chassisID, _ := tableString(t, row, lldpRemChassisId)
+	n.ChassisID = lldpChassisID(n.ChassisIDSubtype, []byte(chassisID))
+	n.PortIDSubtype = LLDPPortIDSubtype(tableInt(t, row, lldpRemPortIdSubtype))
+	portID, _ := tableString(t, row, lldpRemPortId)
+	n.PortID = lldpPortID(n.PortIDSubtype, []byte(portID))
+	n.PortDesc, _ = tableString(t, row, lldpRemPortDesc)
+	n.SysName, _ = tableString(t, row, lldpRemSysName)
+	n.SysDesc, _ = tableString(t, row, lldpRemSysDesc)
+
+	capabilities := BuiltinMIBs().typeSyntax("LldpSystemCapabilitiesMap")
+	if supported, ok := tableString(t, row, lldpRemSysCapSupported); ok {
+		n.CapSupported = capabilities.DecodeBits([]byte(supported))
+	}
+	if enabled, ok := tableString(t, row, lldpRemSysCapEnabled); ok {
+		n.CapEnabled = capabilities.DecodeBits([]byte(enabled))
+	}
+}
+
+func lldpChassisID(subtype LLDPChassisIDSubtype, id []byte) string {
+	return lldpID(id, subtype == LLDPChassisMAC, subtype == LLDPChassisNetwork)
+}
+
+func lldpPortID(subtype LLDPPortIDSubtype, id []byte) string {
+	return lldpID(id, subtype == LLDPPortMAC, subtype == LLDPPortNetwork)
+}
+
+// lldpID renders a chassis or port ID. A network address is prefixed with
+// its IANA address family.
+func lldpID(id []byte, mac, network bool) string {
+	switch {
+	case mac && len(id) == 6:
+		return net.HardwareAddr(id).String()
+	case network && len(id) > 1:
+		return lldpAddress(uint32(id[0]), id[1:]).String()
+	case isPrintable(id):
+		return string(id)
+	}
+	return hexString(id)
+}
+
+// lldpAddress converts an address qualified by its IANA address family
+// (IANA-ADDRESS-FAMILY-NUMBERS-MIB) to an InetAddress. The ipV4, ipV6 and
+// dns families share their numbers with the InetAddressType.
+func lldpAddress(family uint32, address []byte) InetAddress {
+	a := InetAddress{Type: InetUnknown, Address: address}
+	switch InetAddressType(family) {
+	case InetIPv4, InetIPv6, InetDNS:
+		a.Type = InetAddressType(family)
+	}
+	return a
+}
@@ -0,0 +1,96 @@
+package gosnmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+// lldpMIB is an agent view of a switch with a router neighbour on port 5
+var lldpMIB = map[string]Value{
+	".1.0.8802.1.1.2.1.3.1.0":                               Integer32Value(4),
+	".1.0.8802.1.1.2.1.3.2.0":                               OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 1},
+	".1.0.8802.1.1.2.1.3.3.0":                               OctetStringValue("switch1"),
+	".1.0.8802.1.1.2.1.3.7.1.2.5":                           Integer32Value(5),
+	".1.0.8802.1.1.2.1.3.7.1.3.5":                           OctetStringValue("Gi0/5"),
+	".1.0.8802.1.1.2.1.3.7.1.4.5":                           OctetStringValue("uplink"),
+	".1.0.8802.1.1.2.1.4.1.1.4.0.5.1":                       Integer32Value(5),
+	".1.0.8802.1.1.2.1.4.1.1.5.0.5.1":                       OctetStringValue{1, 192, 0, 2, 1},
+	".1.0.8802.1.1.2.1.4.1.1.6.0.5.1":                       Integer32Value(3),
+	".1.0.8802.1.1.2.1.4.1.1.7.0.5.1":                       OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 2},
+	".1.0.8802.1.1.2.1.4.1.1.8.0.5.1":                       OctetStringValue("ge-0/0/1"),
+	".1.0.8802.1.1.2.1.4.1.1.9.0.5.1":                       OctetStringValue("router1"),
+	".1.0.8802.1.1.2.1.4.1.1.11.0.5.1":                      OctetStringValue{0x28},
+	".1.0.8802.1.1.2.1.4.1.1.12.0.5.1":                      OctetStringValue{0x08},
+	".1.0.8802.1.1.2.1.4.2.1.3.0.5.1.1.4.192.0.2.1":         Integer32Value(2),
+	".1.0.8802.1.1.2.1.4.2.1.3.0.5.1.6.6.0.27.33.255.128.2": Integer32Value(2),
+}
+
+// Test retrieving the local identity and neighbours from LLDP-MIB
+func TestLLDP(t *testing.T) {
+	agent := newTestAgent(t, lldpMIB)
+	sys, err := agent.client().LLDP()
+	if err != nil {
+		t.Fatalf("LLDP: %s", err)
+	}
+	if sys.ChassisIDSubtype != LLDPChassisMAC || sys.ChassisID != "00:1b:21:ff:80:01" || sys.SysName != "switch1" {
+		t.Errorf("LLDP system: %+v", sys)
+	}
+	if len(sys.Neighbors) != 1 {
+		t.Fatalf("Expected 1 neighbour, got %d", len(sys.Neighbors))
+	}
+
+	n := sys.Neighbors[0]
+	if n.LocalPortNum != 5 || n.LocalPortID != "Gi0/5" || n.LocalPortDesc != "uplink" || n.RemoteIndex != 1 {
+		t.Errorf("Local port: %+v", n)
+	}
+	if n.ChassisIDSubtype != LLDPChassisNetwork || n.ChassisID != "192.0.2.1" {
+		t.Errorf("Chassis ID:\n\twant: %s %s\n\tgot : %s %s", LLDPChassisNetwork, "192.0.2.1", n.ChassisIDSubtype, n.ChassisID)
+	}
+	if n.PortIDSubtype != LLDPPortMAC || n.PortID != "00:1b:21:ff:80:02" || n.PortDesc != "ge-0/0/1" || n.SysName != "router1" {
+		t.Errorf("Neighbour: %+v", n)
+	}
+	if n.CapSupported.String() != "{bridge(2), router(4)}" || !n.CapEnabled.Has("router") || n.CapEnabled.Has("bridge") {
+		t.Errorf("Capabilities: %s %s", n.CapSupported, n.CapEnabled)
+	}
+	want := []InetAddress{
+		{InetIPv4, []byte{192, 0, 2, 1}},
+		{InetUnknown, []byte{0, 27, 33, 255, 128, 2}},
+	}
+	if !reflect.DeepEqual(n.ManagementAddresses, want) {
+		t.Errorf("ManagementAddresses:\n\twant: %v\n\tgot : %v", want, n.ManagementAddresses)
+	}
+}
+
+// Test the neighbours are still retrieved when lldpLocPortTable fails
+func TestLLDPLocPortError(t *testing.T) {
+	agent := newTestAgent(t, lldpMIB)
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if req.VarBinds[0].Name.HasPrefix(oidLldpLocPortTable) {
+			return &SnmpPacket{Error: uint8(GenErr), ErrorIndex: 1, VarBinds: req.VarBinds}
+		}
+		return agent.handle(req)
+	})
+	sys, err := agent.client().LLDP()
+	if err == nil {
+		t.Errorf("LLDP: expected the lldpLocPortTable error")
+	}
+	if len(sys.Neighbors) != 1 {
+		t.Fatalf("Expected 1 neighbour, got %d", len(sys.Neighbors))
+	}
+	if n := sys.Neighbors[0]; n.LocalPortNum != 5 || n.LocalPortID != "" || n.SysName != "router1" || len(n.ManagementAddresses) != 2 {
+		t.Errorf("Neighbour: %+v", n)
+	}
+}
+
+// Test rendering chassis and port ID subtypes
+func TestLLDPSubtypeStrings(t *testing.T) {
+	if s := LLDPChassisIfName.String(); s != "interfaceName" {
+		t.Errorf("LLDPChassisIfName: %s", s)
+	}
+	if s := LLDPPortCircuitID.String(); s != "agentCircuitId" {
+		t.Errorf("LLDPPortCircuitID: %s", s)
+	}
+	if s := LLDPPortIDSubtype(9).String(); s != "9" {
+		t.Errorf("Unknown subtype: %s", s)
+	}
+}
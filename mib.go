@@ -0,0 +1,334 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MIBNodeKind is the macro used to define a MIB node
+type MIBNodeKind uint8
+
+const (
+	// MIBUnknown is an intermediate node without a definition of its own
+	MIBUnknown MIBNodeKind = iota
+	MIBObjectIdentifier
+	MIBObjectType
+	MIBModuleIdentity
+	MIBObjectIdentity
+	MIBNotificationType
+	MIBTrapType
+	MIBObjectGroup
+	MIBNotificationGroup
+	MIBModuleCompliance
+	MIBAgentCapabilities
+)
+
+var mibNodeKindStrings = map[MIBNodeKind]string{
+	MIBUnknown:           "Unknown",
+	MIBObjectIdentifier:  "OBJECT IDENTIFIER",
+	MIBObjectType:        "OBJECT-TYPE",
+	MIBModuleIdentity:    "MODULE-IDENTITY",
+	MIBObjectIdentity:    "OBJECT-IDENTITY",
+	MIBNotificationType:  "NOTIFICATION-TYPE",
+	MIBTrapType:          "TRAP-TYPE",
+	MIBObjectGroup:       "OBJECT-GROUP",
+	MIBNotificationGroup: "NOTIFICATION-GROUP",
+	MIBModuleCompliance:  "MODULE-COMPLIANCE",
+	MIBAgentCapabilities: "AGENT-CAPABILITIES",
+}
+
+func (k MIBNodeKind) String() string {
+	return mibNodeKindStrings[k]
+}
+
+// NamedNumber is an enumeration label or a named bit of a BITS syntax
+type NamedNumber struct {
+	Name  string
+	Value int64
+}
+
+// MIBSyntax is the SYNTAX of an OBJECT-TYPE or TEXTUAL-CONVENTION
+type MIBSyntax struct {
+	// Type is the type as written in the definition, such as "INTEGER",
+	// "DisplayString" or "SEQUENCE OF IfEntry"
+	Type string
+	// Base is the underlying SMI type the syntax resolves to, such as
+	// "INTEGER", "OCTET STRING", "OBJECT IDENTIFIER", "BITS", "Counter32",
+	// "SEQUENCE" or "SEQUENCE OF". It is empty if the type is unknown.
+	Base string
+	// TC is the textual convention the syntax refers to, if any
+	TC string
+	// DisplayHint is the DISPLAY-HINT of the textual convention, if any
+	DisplayHint string
+	// Enums holds the enumeration labels of an INTEGER or the named bits of
+	// a BITS syntax
+	Enums []NamedNumber
+	// Size is the fixed length of an OCTET STRING, or zero if it varies
+	Size int
+}
+
+// MIBNode is a node of the OID tree defined by the loaded MIB modules
+type MIBNode struct {
+	Name   string
+	Module string
+	OID    OID
+	Kind   MIBNodeKind
+
+	Syntax      *MIBSyntax
+	Units       string
+	Access      string
+	Status      string
+	Description string
+	// Index holds the objects of the INDEX clause of a table entry. Implied
+	// is set if the last of them is IMPLIED.
+	Index   []string
+	Implied bool
+	// Augments is the entry this table entry augments, sharing its index
+	Augments string
+	// Objects holds the OBJECTS of a notification or group
+	Objects []string
+
+	Parent   *MIBNode
+	Children []*MIBNode
+
+	tree *MIBTree
+}
+
+// String returns the node as MODULE::name
+func (n *MIBNode) String() string {
+	if n.Name == "" {
+		return n.OID.String()
+	}
+	return n.Module + "::" + n.Name
+}
+
+// child returns the child node with the given arc, or nil
+func (n *MIBNode) child(arc uint32) *MIBNode {
+	i := sort.Search(len(n.Children), func(i int) bool { return n.Children[i].arc() >= arc })
+	if i < len(n.Children) && n.Children[i].arc() == arc {
+		return n.Children[i]
+	}
+	return nil
+}
+
+func (n *MIBNode) arc() uint32 {
+	return n.OID[len(n.OID)-1]
+}
+
+// MIBTree is a queryable tree of MIB definitions, loaded from MIB modules
+type MIBTree struct {
+	root    *MIBNode
+	byName  map[string][]*MIBNode
+	modules map[string]*mibModule
+	// order holds the module names in the order they were loaded
+	order []string
+	// pending holds definitions whose OID could not be resolved yet
+	pending []*mibDef
+	// base is consulted for modules and names not found in the tree
+	base *MIBTree
+}
+
+// NewMIBTree returns an empty MIB tree
+func NewMIBTree() *MIBTree {
+	return &MIBTree{
+		root:    &MIBNode{OID: OID{}},
+		byName:  make(map[string][]*MIBNode),
+		modules: make(map[string]*mibModule),
+	}
+}
+
+// SetBase layers the tree on top of base, usually BuiltinMIBs(). Names,
+// OIDs and types not found in the tree are then looked up in base, and
+// modules loaded into the tree may import from the modules of base.
+func (t *MIBTree) SetBase(base *MIBTree) {
+	t.base = base
+}
+
+// Modules returns the names of the loaded modules, in load order
+func (t *MIBTree) Modules() []string {
+	return append([]string(nil), t.order...)
+}
+
+// Lookup finds a node by name, given either as "MODULE::name" or as a bare
+// name. Bare names defined by several modules resolve to the first module
+// loaded.
+func (t *MIBTree) Lookup(name string) (*MIBNode, error) {
+	if module, object, ok := strings.Cut(name, "::"); ok {
+		if m, ok := t.modules[module]; ok {
+			if def, ok := m.defs[object]; ok && def.node.tree != nil {
+				return def.node, nil
+			}
+		}
+	} else if nodes := t.byName[name]; len(nodes) > 0 {
+		return nodes[0], nil
+	}
+
+	if t.base != nil {
+		return t.base.Lookup(name)
+	}
+	return nil, fmt.Errorf("Unknown MIB object %q", name)
+}
+
+// Node returns the node with exactly the given OID, or nil
+func (t *MIBTree) Node(oid OID) *MIBNode {
+	n, suffix := t.Longest(oid)
+	if n == nil || len(suffix) > 0 {
+		return nil
+	}
+	return n
+}
+
+// Longest returns the named node with the longest OID that is a prefix of
+// oid, along with the remaining arcs (the instance suffix for an object).
+// It returns nil if no named node matches.
+func (t *MIBTree) Longest(oid OID) (*MIBNode, OID) {
+	var found *MIBNode
+	n := t.root
+	for _, arc := range oid {
+		if n = n.child(arc); n == nil {
+			break
+		}
+		if n.Name != "" {
+			found = n
+		}
+	}
+
+	if t.base != nil {
+		if other, _ := t.base.Longest(oid); other != nil && (found == nil || len(other.OID) > len(found.OID)) {
+			found = other
+		}
+	}
+	if found == nil {
+		return nil, oid
+	}
+	return found, oid[len(found.OID):]
+}
+
+// Walk calls fn for every named node in OID order, stopping if fn returns
+// false
+func (t *MIBTree) Walk(fn func(n *MIBNode) bool) {
+	var walk func(n *MIBNode) bool
+	walk = func(n *MIBNode) bool {
+		if n.Name != "" && !fn(n) {
+			return false
+		}
+		for _, c := range n.Children {
+			if !walk(c) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(t.root)
+}
+
+// insert places a defined node in the tree at its OID
+func (t *MIBTree) insert(def *mibDef, oid OID) *MIBNode {
+	n := t.root
+	for i, arc := range oid {
+		c := n.child(arc)
+		if c == nil {
+			c = &MIBNode{OID: oid[:i+1].Copy(), Parent: n, tree: t}
+			at := sort.Search(len(n.Children), func(j int) bool { return n.Children[j].arc() > arc })
+			n.Children = append(n.Children, nil)
+			copy(n.Children[at+1:], n.Children[at:])
+			n.Children[at] = c
+		}
+		n = c
+	}
+
+	// The first definition of an OID names the node; later definitions by
+	// other modules (such as mib-2 in RFC1213-MIB) share it
+	if n.Name == "" {
+		d := def.node
+		d.OID, d.Parent, d.Children, d.tree = n.OID, n.Parent, n.Children, t
+		for _, c := range d.Children {
+			c.Parent = d
+		}
+		for i, c := range d.Parent.Children {
+			if c == n {
+				d.Parent.Children[i] = d
+			}
+		}
+		n = d
+	}
+	def.node = n
+	t.byName[def.name] = append(t.byName[def.name], n)
+	return n
+}
+
+// IndexSpec returns the index specification of a table entry, or of the
+// entry of a column, derived from the syntaxes of its INDEX objects
+func (n *MIBNode) IndexSpec() (IndexSpec, error) {
+	entry := n
+	if len(entry.Index) == 0 && entry.Augments == "" && entry.Parent != nil {
+		entry = entry.Parent
+	}
+	for seen := 0; entry.Augments != "" && seen < 8; seen++ {
+		augmented, err := entry.tree.lookupIn(entry.Module, entry.Augments)
+		if err != nil {
+			return nil, err
+		}
+		entry = augmented
+	}
+	if len(entry.Index) == 0 {
+		return nil, fmt.Errorf("%s is not a table entry or column", n)
+	}
+
+	var spec IndexSpec
+	for i := 0; i < len(entry.Index); i++ {
+		object, err := entry.tree.lookupIn(entry.Module, entry.Index[i])
+		if err != nil {
+			return nil, err
+		}
+		implied := entry.Implied && i == len(entry.Index)-1
+
+		var c IndexComponent
+		syntax := object.Syntax
+		if syntax == nil {
+			return nil, fmt.Errorf("Index object %s has no syntax", object)
+		}
+		switch {
+		case syntax.TC == "InetAddressType" && i+1 < len(entry.Index):
+			// An InetAddressType is followed by the InetAddress it qualifies
+			i++
+			implied = entry.Implied && i == len(entry.Index)-1
+			c = IndexComponent{Kind: IndexInetAddress, Implied: implied}
+		case syntax.TC == "MacAddress":
+			c = IndexComponent{Kind: IndexMACAddress}
+		case syntax.Base == "IpAddress" || syntax.Base == "NetworkAddress":
+			c = IndexComponent{Kind: IndexIPAddress}
+		case syntax.Base == "OCTET STRING" || syntax.Base == "BITS" || syntax.Base == "Opaque":
+			c = IndexComponent{Kind: IndexOctetString, Size: syntax.Size, Implied: implied}
+		case syntax.Base == "OBJECT IDENTIFIER":
+			c = IndexComponent{Kind: IndexOID, Implied: implied}
+		case syntax.Base != "":
+			c = IndexComponent{Kind: IndexInteger}
+		default:
+			return nil, fmt.Errorf("Unable to determine index syntax of %s (%s)", object, syntax.Type)
+		}
+		spec = append(spec, c)
+	}
+	return spec, nil
+}
+
+// lookupIn finds a name as seen from a module: defined in it, imported by it
+// or, failing that, anywhere in the tree
+func (t *MIBTree) lookupIn(module, name string) (*MIBNode, error) {
+	if m, ok := t.modules[module]; ok {
+		if def, ok := m.defs[name]; ok && def.node.tree != nil {
+			return def.node, nil
+		}
+		if from, ok := m.imports[name]; ok {
+			if n, err := t.Lookup(from + "::" + name); err == nil {
+				return n, nil
+			}
+		}
+	}
+	return t.Lookup(name)
+}
@@ -0,0 +1,361 @@
+package gosnmp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testMIBFiles is a small set of modules exercising the constructs of SMIv1
+// and SMIv2, modelled on SNMPv2-TC and IF-MIB
+var testMIBFiles = map[string]string{
+	"TEST-TC": `
+TEST-TC DEFINITIONS ::= BEGIN
+
+IMPORTS
+    TEXTUAL-CONVENTION FROM SNMPv2-TC;
+
+DisplayString ::= TEXTUAL-CONVENTION
+    DISPLAY-HINT "255a"
+    STATUS       current
+    DESCRIPTION  "Textual information -- not a comment."
+    SYNTAX       OCTET STRING (SIZE (0..255))
+
+MacAddress ::= TEXTUAL-CONVENTION
+    DISPLAY-HINT "1x:"
+    STATUS       current
+    DESCRIPTION  "An IEEE 802 MAC address."
+    SYNTAX       OCTET STRING (SIZE (6))
+
+TestStatus ::= TEXTUAL-CONVENTION
+    STATUS       current
+    DESCRIPTION  "Operational status."
+    SYNTAX       INTEGER { up(1), down(2), testing(3) }
+
+-- SMIv1 style type assignment
+Label ::= DisplayString
+
+END
+`,
+	"TEST-MIB.my": `
+TEST-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    MODULE-IDENTITY, OBJECT-TYPE, NOTIFICATION-TYPE, OBJECT-IDENTITY,
+    Counter32, Integer32, mib-2            FROM SNMPv2-SMI
+    DisplayString, MacAddress, TestStatus,
+    Label                                  FROM TEST-TC
+    OBJECT-GROUP                           FROM SNMPv2-CONF;
+
+testMIB MODULE-IDENTITY
+    LAST-UPDATED "201201010000Z"
+    ORGANIZATION "gosnmp"
+    CONTACT-INFO "none"
+    DESCRIPTION  "A test module."
+    REVISION     "201201010000Z"
+    DESCRIPTION  "Initial revision."
+    ::= { mib-2 9999 }
+
+testObjects OBJECT IDENTIFIER ::= { testMIB 1 }
+
+testName OBJECT-TYPE
+    SYNTAX      Label
+    MAX-ACCESS  read-write
+    STATUS      current
+    DESCRIPTION "The name."
+    DEFVAL      { "none" }
+    ::= { testObjects 1 }
+
+testTable OBJECT-TYPE
+    SYNTAX      SEQUENCE OF TestEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "A table."
+    ::= { testObjects 2 }
+
+testEntry OBJECT-TYPE
+    SYNTAX      TestEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "A row."
+    INDEX       { testIndex, testMac, IMPLIED testDescr }
+    ::= { testTable 1 }
+
+TestEntry ::= SEQUENCE {
+    testIndex   Integer32,
+    testMac     MacAddress,
+    testDescr   DisplayString,
+    testStatus  TestStatus,
+    testOctets  Counter32,
+    testFlags   BITS
+}
+
+testIndex OBJECT-TYPE
+    SYNTAX      Integer32 (1..2147483647)
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "The index."
+    ::= { testEntry 1 }
+
+testMac OBJECT-TYPE
+    SYNTAX      MacAddress
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "The address."
+    ::= { testEntry 2 }
+
+testDescr OBJECT-TYPE
+    SYNTAX      DisplayString
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "The description."
+    ::= { testEntry 3 }
+
+testStatus OBJECT-TYPE
+    SYNTAX      TestStatus
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "The status."
+    ::= { testEntry 4 }
+
+testOctets OBJECT-TYPE
+    SYNTAX      Counter32
+    UNITS       "octets"
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "The octets."
+    ::= { testEntry 5 }
+
+testFlags OBJECT-TYPE
+    SYNTAX      BITS { red(0), green(1), blue(2) }
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "The flags."
+    ::= { testEntry 6 }
+
+testXEntry OBJECT-TYPE
+    SYNTAX      TestEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "An augmenting row."
+    AUGMENTS    { testEntry }
+    ::= { testObjects 3 }
+
+testAlarm NOTIFICATION-TYPE
+    OBJECTS     { testStatus, testDescr }
+    STATUS      current
+    DESCRIPTION "Status changed."
+    ::= { testMIB 0 1 }
+
+testProduct OBJECT-IDENTITY
+    STATUS      current
+    DESCRIPTION "A product."
+    ::= { iso org(3) dod(6) internet(1) private(4) enterprises(1) 99999 }
+
+testGroup OBJECT-GROUP
+    OBJECTS     { testName, testStatus }
+    STATUS      current
+    DESCRIPTION "A group."
+    ::= { testMIB 2 }
+
+END
+`,
+	"TEST-V1-MIB.txt": `
+TEST-V1-MIB DEFINITIONS ::= BEGIN
+
+IMPORTS
+    enterprises FROM RFC1155-SMI
+    OBJECT-TYPE FROM RFC-1212
+    TRAP-TYPE   FROM RFC-1215;
+
+acme OBJECT IDENTIFIER ::= { enterprises 99998 }
+
+acmeTemp OBJECT-TYPE
+    SYNTAX  INTEGER
+    ACCESS  read-only
+    STATUS  mandatory
+    DESCRIPTION "Temperature."
+    ::= { acme 1 }
+
+acmeHot TRAP-TYPE
+    ENTERPRISE acme
+    VARIABLES  { acmeTemp }
+    DESCRIPTION "Too hot."
+    ::= 3
+
+END
+`,
+	"README": "Not a MIB module.\n",
+}
+
+func loadTestMIBs(t *testing.T) *MIBTree {
+	dir := t.TempDir()
+	for name, content := range testMIBFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree := NewMIBTree()
+	if err := tree.LoadDir(dir); err != nil {
+		t.Fatalf("Unable to load test MIBs: %s", err)
+	}
+	return tree
+}
+
+// Test loading modules and querying their definitions
+func TestMIBTree(t *testing.T) {
+	tree := loadTestMIBs(t)
+
+	if n := len(tree.Modules()); n != 3 {
+		t.Errorf("Modules: %v", tree.Modules())
+	}
+
+	tests := []struct {
+		name string
+		oid  string
+		kind MIBNodeKind
+	}{
+		{"testMIB", ".1.3.6.1.2.1.9999", MIBModuleIdentity},
+		{"TEST-MIB::testObjects", ".1.3.6.1.2.1.9999.1", MIBObjectIdentifier},
+		{"testEntry", ".1.3.6.1.2.1.9999.1.2.1", MIBObjectType},
+		{"testFlags", ".1.3.6.1.2.1.9999.1.2.1.6", MIBObjectType},
+		{"testAlarm", ".1.3.6.1.2.1.9999.0.1", MIBNotificationType},
+		{"testProduct", ".1.3.6.1.4.1.99999", MIBObjectIdentity},
+		{"testGroup", ".1.3.6.1.2.1.9999.2", MIBObjectGroup},
+		{"acmeHot", ".1.3.6.1.4.1.99998.0.3", MIBTrapType},
+	}
+	for _, test := range tests {
+		n, err := tree.Lookup(test.name)
+		if err != nil {
+			t.Errorf("Lookup %s: %s", test.name, err)
+			continue
+		}
+		if n.OID.String() != test.oid || n.Kind != test.kind {
+			t.Errorf("Lookup %s:\n\twant: %s %s\n\tgot : %s %s", test.name, test.oid, test.kind, n.OID, n.Kind)
+		}
+	}
+
+	if _, err := tree.Lookup("TEST-TC::testName"); err == nil {
+		t.Errorf("Expected error looking up a name in the wrong module")
+	}
+
+	n, _ := tree.Lookup("testMIB")
+	if n.Description != "A test module." {
+		t.Errorf("Module description: %q", n.Description)
+	}
+	if n, _ := tree.Lookup("testAlarm"); !reflect.DeepEqual(n.Objects, []string{"testStatus", "testDescr"}) {
+		t.Errorf("Notification objects: %v", n.Objects)
+	}
+
+	// Nodes are found by OID, with the instance suffix split off
+	n, suffix := tree.Longest(MustParseOID(".1.3.6.1.2.1.9999.1.2.1.4.7"))
+	if n == nil || n.Name != "testStatus" || suffix.String() != ".7" {
+		t.Errorf("Longest: %v %s", n, suffix)
+	}
+	if n := tree.Node(MustParseOID(".1.3.6.1.2.1.9999.1.2.1.4")); n == nil || n.String() != "TEST-MIB::testStatus" {
+		t.Errorf("Node: %v", n)
+	}
+	if n := tree.Node(MustParseOID(".1.3.6.1.2.1")); n != nil {
+		t.Errorf("Node of an unnamed OID: %v", n)
+	}
+
+	var names []string
+	entry, _ := tree.Lookup("testEntry")
+	for _, c := range entry.Children {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, " ") != "testIndex testMac testDescr testStatus testOctets testFlags" {
+		t.Errorf("Children: %v", names)
+	}
+}
+
+// Test resolution of syntaxes through textual conventions
+func TestMIBSyntax(t *testing.T) {
+	tree := loadTestMIBs(t)
+
+	tests := []struct {
+		name   string
+		syntax MIBSyntax
+		access string
+	}{
+		{"testName", MIBSyntax{Type: "Label", Base: "OCTET STRING", TC: "DisplayString", DisplayHint: "255a"}, "read-write"},
+		{"testMac", MIBSyntax{Type: "MacAddress", Base: "OCTET STRING", TC: "MacAddress", DisplayHint: "1x:", Size: 6}, "not-accessible"},
+		{"testStatus", MIBSyntax{Type: "TestStatus", Base: "INTEGER", TC: "TestStatus",
+			Enums: []NamedNumber{{"up", 1}, {"down", 2}, {"testing", 3}}}, "read-only"},
+		{"testFlags", MIBSyntax{Type: "BITS", Base: "BITS",
+			Enums: []NamedNumber{{"red", 0}, {"green", 1}, {"blue", 2}}}, "read-only"},
+		{"testOctets", MIBSyntax{Type: "Counter32", Base: "Counter32"}, "read-only"},
+		{"testTable", MIBSyntax{Type: "SEQUENCE OF TestEntry", Base: "SEQUENCE OF"}, "not-accessible"},
+		{"testEntry", MIBSyntax{Type: "TestEntry", Base: "SEQUENCE"}, "not-accessible"},
+		{"acmeTemp", MIBSyntax{Type: "INTEGER", Base: "INTEGER"}, "read-only"},
+	}
+	for _, test := range tests {
+		n, err := tree.Lookup(test.name)
+		if err != nil {
+			t.Errorf("Lookup %s: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(*n.Syntax, test.syntax) || n.Access != test.access {
+			t.Errorf("%s syntax:\n\twant: %+v %s\n\tgot : %+v %s", test.name, test.syntax, test.access, *n.Syntax, n.Access)
+		}
+	}
+
+	if n, _ := tree.Lookup("testOctets"); n.Units != "octets" || n.Status != "current" {
+		t.Errorf("testOctets clauses: %q %q", n.Units, n.Status)
+	}
+}
+
+// Test deriving index specifications from INDEX and AUGMENTS clauses
+func TestMIBIndexSpec(t *testing.T) {
+	tree := loadTestMIBs(t)
+	want := IndexSpec{
+		{Kind: IndexInteger},
+		{Kind: IndexMACAddress},
+		{Kind: IndexOctetString, Implied: true},
+	}
+
+	for _, name := range []string{"testEntry", "testStatus", "testXEntry"} {
+		n, _ := tree.Lookup(name)
+		spec, err := n.IndexSpec()
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(spec, want) {
+			t.Errorf("%s index:\n\twant: %v\n\tgot : %v", name, want, spec)
+		}
+	}
+
+	if n, _ := tree.Lookup("testName"); n != nil {
+		if _, err := n.IndexSpec(); err == nil {
+			t.Errorf("Expected error for a scalar")
+		}
+	}
+}
+
+// Test that parse errors and unresolvable imports are reported
+func TestMIBLoadErrors(t *testing.T) {
+	tree := NewMIBTree()
+	err := tree.Load("broken", strings.NewReader("BROKEN DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { 1 3 \nEND\n"))
+	if err == nil {
+		t.Errorf("Expected error for malformed module")
+	}
+
+	missing := `
+MISSING-MIB DEFINITIONS ::= BEGIN
+IMPORTS ifMIB FROM IF-MIB;
+missingObject OBJECT IDENTIFIER ::= { ifMIB 1 }
+presentObject OBJECT IDENTIFIER ::= { 1 3 6 1 4 1 99997 }
+END
+`
+	err = tree.Load("missing", strings.NewReader(missing))
+	if err == nil || !strings.Contains(err.Error(), "MISSING-MIB::missingObject") {
+		t.Errorf("Expected unresolved OID error, got %v", err)
+	}
+	if _, err := tree.Lookup("presentObject"); err != nil {
+		t.Errorf("Resolvable definitions should still load: %s", err)
+	}
+}
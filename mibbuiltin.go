@@ -0,0 +1,160 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"strings"
+	"sync"
+)
+
+// builtinModule is a MIB module compiled into the package. Descriptions are
+// left out, and imports are derived from the names the module refers to.
+type builtinModule struct {
+	name    string
+	types   []builtinType
+	objects []builtinObject
+}
+
+// builtinType is a textual convention
+type builtinType struct {
+	name string
+	// syntax is written as in a MIB, such as "INTEGER { true(1), false(2) }"
+	syntax string
+	hint   string
+}
+
+// builtinObject is a node, defined relative to its parent
+type builtinObject struct {
+	name   string
+	parent string
+	arc    uint32
+	// syntax is written as in a MIB, or is the macro name of a node that is
+	// not an OBJECT-TYPE, such as "NOTIFICATION-TYPE". It is empty for an
+	// OBJECT IDENTIFIER.
+	syntax string
+	access string
+	// index holds the INDEX objects of a table entry, optionally starting
+	// with IMPLIED or as "AUGMENTS entry", or the OBJECTS of a notification
+	index string
+}
+
+const (
+	mibRO = "read-only"
+	mibRW = "read-write"
+	mibRC = "read-create"
+	mibNA = "not-accessible"
+	mibAN = "accessible-for-notify"
+)
+
+var (
+	builtinOnce sync.Once
+	builtinTree *MIBTree
+)
+
+// BuiltinMIBs returns the registry of standard modules compiled into the
+// package, such as SNMPv2-MIB, IF-MIB, IP-MIB, HOST-RESOURCES-MIB,
+// ENTITY-MIB, BRIDGE-MIB and LLDP-MIB. It is used to resolve names when
+// GoSNMP.MIBs is nil.
+//
+// The registry is shared and must not be loaded into. To add modules, create
+// a tree with NewMIBTree and layer it on top with SetBase.
+func BuiltinMIBs() *MIBTree {
+	builtinOnce.Do(func() {
+		builtinTree = compileMIBs(builtinModules)
+	})
+	return builtinTree
+}
+
+// compileMIBs builds a tree from compiled modules
+func compileMIBs(modules []builtinModule) *MIBTree {
+	defined := make(map[string]string)
+	for _, bm := range modules {
+		for _, typ := range bm.types {
+			defined[typ.name] = bm.name
+		}
+		for _, o := range bm.objects {
+			if _, ok := defined[o.name]; !ok {
+				defined[o.name] = bm.name
+			}
+		}
+	}
+
+	t := NewMIBTree()
+	for _, bm := range modules {
+		m := &mibModule{
+			name:    bm.name,
+			imports: make(map[string]string),
+			defs:    make(map[string]*mibDef),
+			types:   make(map[string]*mibType),
+		}
+		refer := func(name string) {
+			if from := defined[name]; from != "" && from != bm.name {
+				m.imports[name] = from
+			}
+		}
+
+		for _, typ := range bm.types {
+			syntax := mustParseSyntax(typ.syntax)
+			m.types[typ.name] = &mibType{syntax: syntax, hint: typ.hint, tc: true}
+			refer(syntax.Type)
+		}
+
+		for _, o := range bm.objects {
+			var value []oidComponent
+			if o.parent != "" {
+				value = append(value, oidComponent{name: o.parent})
+				refer(o.parent)
+			}
+			value = append(value, oidComponent{number: o.arc, numbered: true})
+
+			node := &MIBNode{Kind: MIBObjectIdentifier}
+			names := strings.Fields(o.index)
+			if kind := mibMacroKinds[o.syntax]; kind != MIBUnknown {
+				node.Kind, node.Status, node.Objects = kind, "current", names
+			} else if o.syntax != "" {
+				node.Kind, node.Status, node.Access = MIBObjectType, "current", o.access
+				node.Syntax = mustParseSyntax(o.syntax)
+				refer(node.Syntax.Type)
+
+				switch {
+				case len(names) == 2 && names[0] == "AUGMENTS":
+					node.Augments = names[1]
+				case len(names) > 0 && names[0] == "IMPLIED":
+					node.Index, node.Implied = names[1:], true
+				default:
+					node.Index = names
+				}
+				if len(names) > 0 {
+					// The SEQUENCE type of the entry
+					m.types[node.Syntax.Type] = &mibType{syntax: &MIBSyntax{Type: "SEQUENCE"}}
+				}
+			}
+			for _, name := range names {
+				refer(name)
+			}
+			m.define(o.name, node, value)
+		}
+		t.add(m)
+	}
+
+	if problems := t.resolve(); len(problems) > 0 {
+		panic("gosnmp: invalid built-in MIBs: " + strings.Join(problems, "; "))
+	}
+	return t
+}
+
+// mustParseSyntax parses the syntax of a compiled module
+func mustParseSyntax(s string) *MIBSyntax {
+	toks, err := lexMIB([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+	p := &mibParser{toks: toks}
+	syntax, err := p.parseSyntax()
+	if err != nil || p.peek().kind != mibEOF {
+		panic("gosnmp: invalid built-in syntax " + s)
+	}
+	return syntax
+}
@@ -0,0 +1,202 @@
+package gosnmp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test that every built-in object has a resolved syntax and every table
+// entry a usable index
+func TestBuiltinMIBs(t *testing.T) {
+	tree := BuiltinMIBs()
+	if tree != BuiltinMIBs() {
+		t.Errorf("Expected the registry to be shared")
+	}
+
+	count := 0
+	tree.Walk(func(n *MIBNode) bool {
+		count++
+		if n.Kind != MIBObjectType {
+			return true
+		}
+		if n.Syntax == nil || n.Syntax.Base == "" {
+			t.Errorf("%s has no base syntax", n)
+		}
+		if len(n.Index) > 0 || n.Augments != "" {
+			if _, err := n.IndexSpec(); err != nil {
+				t.Errorf("%s: %s", n, err)
+			}
+		}
+		return true
+	})
+	if count < 400 {
+		t.Errorf("Expected the registry to hold the standard modules, got %d nodes", count)
+	}
+
+	for _, module := range []string{"SNMPv2-MIB", "IF-MIB", "IP-MIB", "HOST-RESOURCES-MIB", "ENTITY-MIB", "BRIDGE-MIB", "LLDP-MIB", "SNMP-FRAMEWORK-MIB"} {
+		found := false
+		for _, m := range tree.Modules() {
+			found = found || m == module
+		}
+		if !found {
+			t.Errorf("Missing module %s", module)
+		}
+	}
+}
+
+var builtinNameTests = []struct {
+	name      string
+	oid       string
+	formatted string
+}{
+	{"sysUpTime.0", ".1.3.6.1.2.1.1.3.0", "SNMPv2-MIB::sysUpTime.0"},
+	{"IF-MIB::ifDescr.3", ".1.3.6.1.2.1.2.2.1.2.3", "IF-MIB::ifDescr.3"},
+	{"ifHCInOctets.3", ".1.3.6.1.2.1.31.1.1.1.6.3", "IF-MIB::ifHCInOctets.3"},
+	{"ipAdEntIfIndex.10.0.0.1", ".1.3.6.1.2.1.4.20.1.2.10.0.0.1", "IP-MIB::ipAdEntIfIndex.10.0.0.1"},
+	{
+		// The address type is implied by the quoted address
+		`ipAddressIfIndex."10.0.0.1"`,
+		".1.3.6.1.2.1.4.34.1.3.1.4.10.0.0.1",
+		`IP-MIB::ipAddressIfIndex."10.0.0.1"`,
+	},
+	{
+		"dot1qTpFdbPort.1.00:1b:21:ff:80:01",
+		".1.3.6.1.2.1.17.7.1.2.2.1.2.1.0.27.33.255.128.1",
+		"Q-BRIDGE-MIB::dot1qTpFdbPort.1.00:1b:21:ff:80:01",
+	},
+	{"lldpRemSysName.0.3.1", ".1.0.8802.1.1.2.1.4.1.1.9.0.3.1", "LLDP-MIB::lldpRemSysName.0.3.1"},
+	{"hrStorageFixedDisk", ".1.3.6.1.2.1.25.2.1.4", "HOST-RESOURCES-TYPES::hrStorageFixedDisk"},
+	{"entPhySensorValue.12", ".1.3.6.1.2.1.99.1.1.1.4.12", "ENTITY-SENSOR-MIB::entPhySensorValue.12"},
+	{"ipRouteNextHop.0.0.0.0", ".1.3.6.1.2.1.4.21.1.7.0.0.0.0", "RFC1213-MIB::ipRouteNextHop.0.0.0.0"},
+	{
+		"ipCidrRouteIfIndex.10.0.0.0.255.0.0.0.0.192.0.2.1",
+		".1.3.6.1.2.1.4.24.4.1.5.10.0.0.0.255.0.0.0.0.192.0.2.1",
+		"IP-FORWARD-MIB::ipCidrRouteIfIndex.10.0.0.0.255.0.0.0.0.192.0.2.1",
+	},
+	{
+		`inetCidrRouteIfIndex."10.0.0.0".8.2.0.0."192.0.2.1"`,
+		".1.3.6.1.2.1.4.24.7.1.7.1.4.10.0.0.0.8.2.0.0.1.4.192.0.2.1",
+		`IP-FORWARD-MIB::inetCidrRouteIfIndex."10.0.0.0".8.2.0.0."192.0.2.1"`,
+	},
+	{"bgpPeerState.192.0.2.1", ".1.3.6.1.2.1.15.3.1.2.192.0.2.1", "BGP4-MIB::bgpPeerState.192.0.2.1"},
+	{"ospfNbrState.10.0.0.2.0", ".1.3.6.1.2.1.14.10.1.6.10.0.0.2.0", "OSPF-MIB::ospfNbrState.10.0.0.2.0"},
+}
+
+// Test resolving and formatting names with the built-in registry
+func TestBuiltinMIBNames(t *testing.T) {
+	tree := BuiltinMIBs()
+	for _, test := range builtinNameTests {
+		oid, err := tree.Resolve(test.name)
+		if err != nil {
+			t.Errorf("Resolve %s: %s", test.name, err)
+			continue
+		}
+		if oid.String() != test.oid {
+			t.Errorf("Resolve %s:\n\twant: %s\n\tgot : %s", test.name, test.oid, oid)
+		}
+		if formatted := tree.Format(oid); formatted != test.formatted {
+			t.Errorf("Format %s:\n\twant: %s\n\tgot : %s", test.oid, test.formatted, formatted)
+		}
+	}
+
+	n, err := tree.Lookup("ifOperStatus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Syntax.Base != "INTEGER" || len(n.Syntax.Enums) != 7 || n.Syntax.Enums[6] != (NamedNumber{"lowerLayerDown", 7}) {
+		t.Errorf("ifOperStatus syntax: %+v", n.Syntax)
+	}
+
+	n, _ = tree.Lookup("hrSystemDate")
+	want := MIBSyntax{Type: "DateAndTime", Base: "OCTET STRING", TC: "DateAndTime", DisplayHint: "2d-1d-1d,1d:1d:1d.1d,1a1d:1d"}
+	if got := *n.Syntax; !reflect.DeepEqual(got, want) {
+		t.Errorf("hrSystemDate syntax:\n\twant: %+v\n\tgot : %+v", want, got)
+	}
+
+	n, _ = tree.Lookup("ifXEntry")
+	if spec, err := n.IndexSpec(); err != nil || !reflect.DeepEqual(spec, IndexSpec{{Kind: IndexInteger}}) {
+		t.Errorf("ifXEntry index: %v %v", spec, err)
+	}
+}
+
+// Test layering a loaded module over the built-in registry
+func TestBuiltinMIBLayering(t *testing.T) {
+	tree := NewMIBTree()
+	tree.SetBase(BuiltinMIBs())
+
+	module := `
+ACME-IF-MIB DEFINITIONS ::= BEGIN
+IMPORTS
+    OBJECT-TYPE, enterprises FROM SNMPv2-SMI
+    DisplayString FROM SNMPv2-TC
+    ifIndex FROM IF-MIB;
+
+acmeIfTable OBJECT-TYPE
+    SYNTAX      SEQUENCE OF AcmeIfEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "Vendor interface extensions."
+    ::= { enterprises 99996 1 }
+
+acmeIfEntry OBJECT-TYPE
+    SYNTAX      AcmeIfEntry
+    MAX-ACCESS  not-accessible
+    STATUS      current
+    DESCRIPTION "An entry."
+    INDEX       { ifIndex }
+    ::= { acmeIfTable 1 }
+
+AcmeIfEntry ::= SEQUENCE { acmeIfLabel DisplayString }
+
+acmeIfLabel OBJECT-TYPE
+    SYNTAX      DisplayString
+    MAX-ACCESS  read-only
+    STATUS      current
+    DESCRIPTION "A label."
+    ::= { acmeIfEntry 1 }
+END
+`
+	if err := tree.Load("ACME-IF-MIB", strings.NewReader(module)); err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+
+	oid, err := tree.Resolve("acmeIfLabel.3")
+	if err != nil || oid.String() != ".1.3.6.1.4.1.99996.1.1.1.3" {
+		t.Errorf("Resolve acmeIfLabel.3: %s %v", oid, err)
+	}
+	n, _ := tree.Lookup("acmeIfLabel")
+	if n == nil || n.Syntax.DisplayHint != "255a" {
+		t.Errorf("acmeIfLabel syntax: %+v", n)
+	}
+	if name := tree.FormatName(".1.3.6.1.2.1.2.2.1.2.3"); name != "IF-MIB::ifDescr.3" {
+		t.Errorf("Base name: %s", name)
+	}
+
+	if _, err := BuiltinMIBs().Lookup("acmeIfLabel"); err == nil {
+		t.Errorf("Expected the registry to be unchanged")
+	}
+}
+
+// Test that requests accept standard names without MIBs set
+func TestBuiltinSymbolicRequests(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.1.5.0":      OctetStringValue("router1"),
+		".1.3.6.1.2.1.2.2.1.2.1":  OctetStringValue("eth0"),
+		".1.3.6.1.2.1.2.2.1.2.2":  OctetStringValue("eth1"),
+		".1.3.6.1.2.1.2.2.1.10.1": Counter32Value(100),
+	})
+	s := agent.client()
+
+	res, err := s.Get("SNMPv2-MIB::sysName.0")
+	if err != nil || res.Variables[0].Value != "router1" {
+		t.Errorf("Get: %v %v", res, err)
+	}
+	pdus, err := s.Walk("ifDescr")
+	if err != nil || len(pdus) != 2 {
+		t.Errorf("Walk: %v %v", pdus, err)
+	}
+	if _, err := s.NewWalkCheckpoint("ifTable", WalkGetNext, 0); err != nil {
+		t.Errorf("NewWalkCheckpoint: %s", err)
+	}
+}
@@ -0,0 +1,824 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+// builtinModules are the standard modules returned by BuiltinMIBs, compiled
+// from their RFCs. Modules are listed after the modules they import from.
+var builtinModules = []builtinModule{
+	{
+		name: "SNMPv2-SMI",
+		objects: []builtinObject{
+			{"ccitt", "", 0, "", "", ""},
+			{"zeroDotZero", "ccitt", 0, "OBJECT-IDENTITY", "", ""},
+			{"iso", "", 1, "", "", ""},
+			{"org", "iso", 3, "", "", ""},
+			{"dod", "org", 6, "", "", ""},
+			{"internet", "dod", 1, "", "", ""},
+			{"directory", "internet", 1, "", "", ""},
+			{"mgmt", "internet", 2, "", "", ""},
+			{"mib-2", "mgmt", 1, "", "", ""},
+			{"transmission", "mib-2", 10, "", "", ""},
+			{"experimental", "internet", 3, "", "", ""},
+			{"private", "internet", 4, "", "", ""},
+			{"enterprises", "private", 1, "", "", ""},
+			{"security", "internet", 5, "", "", ""},
+			{"snmpV2", "internet", 6, "", "", ""},
+			{"snmpDomains", "snmpV2", 1, "", "", ""},
+			{"snmpProxys", "snmpV2", 2, "", "", ""},
+			{"snmpModules", "snmpV2", 3, "", "", ""},
+			{"joint-iso-ccitt", "", 2, "", "", ""},
+		},
+	},
+	{
+		name: "SNMPv2-TC",
+		types: []builtinType{
+			{"DisplayString", "OCTET STRING (SIZE (0..255))", "255a"},
+			{"PhysAddress", "OCTET STRING", "1x:"},
+			{"MacAddress", "OCTET STRING (SIZE (6))", "1x:"},
+			{"TruthValue", "INTEGER { true(1), false(2) }", ""},
+			{"TestAndIncr", "INTEGER (0..2147483647)", ""},
+			{"AutonomousType", "OBJECT IDENTIFIER", ""},
+			{"InstancePointer", "OBJECT IDENTIFIER", ""},
+			{"VariablePointer", "OBJECT IDENTIFIER", ""},
+			{"RowPointer", "OBJECT IDENTIFIER", ""},
+			{"RowStatus", "INTEGER { active(1), notInService(2), notReady(3), createAndGo(4), createAndWait(5), destroy(6) }", ""},
+			{"TimeStamp", "TimeTicks", ""},
+			{"TimeInterval", "INTEGER (0..2147483647)", ""},
+			{"DateAndTime", "OCTET STRING (SIZE (8 | 11))", "2d-1d-1d,1d:1d:1d.1d,1a1d:1d"},
+			{"StorageType", "INTEGER { other(1), volatile(2), nonVolatile(3), permanent(4), readOnly(5) }", ""},
+			{"TDomain", "OBJECT IDENTIFIER", ""},
+			{"TAddress", "OCTET STRING (SIZE (1..255))", ""},
+		},
+	},
+	{
+		name: "SNMP-FRAMEWORK-MIB",
+		types: []builtinType{
+			{"SnmpEngineID", "OCTET STRING (SIZE (5..32))", ""},
+			{"SnmpSecurityModel", "INTEGER (0..2147483647)", ""},
+			{"SnmpMessageProcessingModel", "INTEGER (0..2147483647)", ""},
+			{"SnmpSecurityLevel", "INTEGER { noAuthNoPriv(1), authNoPriv(2), authPriv(3) }", ""},
+			{"SnmpAdminString", "OCTET STRING (SIZE (0..255))", "255t"},
+		},
+		objects: []builtinObject{
+			{"snmpFrameworkMIB", "snmpModules", 10, "MODULE-IDENTITY", "", ""},
+			{"snmpFrameworkAdmin", "snmpFrameworkMIB", 1, "", "", ""},
+			{"snmpFrameworkMIBObjects", "snmpFrameworkMIB", 2, "", "", ""},
+			{"snmpEngine", "snmpFrameworkMIBObjects", 1, "", "", ""},
+			{"snmpEngineID", "snmpEngine", 1, "SnmpEngineID", mibRO, ""},
+			{"snmpEngineBoots", "snmpEngine", 2, "INTEGER (1..2147483647)", mibRO, ""},
+			{"snmpEngineTime", "snmpEngine", 3, "INTEGER (0..2147483647)", mibRO, ""},
+			{"snmpEngineMaxMessageSize", "snmpEngine", 4, "INTEGER (484..2147483647)", mibRO, ""},
+		},
+	},
+	{
+		name: "SNMPv2-MIB",
+		objects: []builtinObject{
+			{"system", "mib-2", 1, "", "", ""},
+			{"sysDescr", "system", 1, "DisplayString (SIZE (0..255))", mibRO, ""},
+			{"sysObjectID", "system", 2, "OBJECT IDENTIFIER", mibRO, ""},
+			{"sysUpTime", "system", 3, "TimeTicks", mibRO, ""},
+			{"sysContact", "system", 4, "DisplayString (SIZE (0..255))", mibRW, ""},
+			{"sysName", "system", 5, "DisplayString (SIZE (0..255))", mibRW, ""},
+			{"sysLocation", "system", 6, "DisplayString (SIZE (0..255))", mibRW, ""},
+			{"sysServices", "system", 7, "INTEGER (0..127)", mibRO, ""},
+			{"sysORLastChange", "system", 8, "TimeStamp", mibRO, ""},
+			{"sysORTable", "system", 9, "SEQUENCE OF SysOREntry", mibNA, ""},
+			{"sysOREntry", "sysORTable", 1, "SysOREntry", mibNA, "sysORIndex"},
+			{"sysORIndex", "sysOREntry", 1, "INTEGER (1..2147483647)", mibNA, ""},
+			{"sysORID", "sysOREntry", 2, "OBJECT IDENTIFIER", mibRO, ""},
+			{"sysORDescr", "sysOREntry", 3, "DisplayString", mibRO, ""},
+			{"sysORUpTime", "sysOREntry", 4, "TimeStamp", mibRO, ""},
+			{"snmp", "mib-2", 11, "", "", ""},
+			{"snmpInPkts", "snmp", 1, "Counter32", mibRO, ""},
+			{"snmpOutPkts", "snmp", 2, "Counter32", mibRO, ""},
+			{"snmpInBadVersions", "snmp", 3, "Counter32", mibRO, ""},
+			{"snmpInBadCommunityNames", "snmp", 4, "Counter32", mibRO, ""},
+			{"snmpInBadCommunityUses", "snmp", 5, "Counter32", mibRO, ""},
+			{"snmpInASNParseErrs", "snmp", 6, "Counter32", mibRO, ""},
+			{"snmpEnableAuthenTraps", "snmp", 30, "INTEGER { enabled(1), disabled(2) }", mibRW, ""},
+			{"snmpSilentDrops", "snmp", 31, "Counter32", mibRO, ""},
+			{"snmpProxyDrops", "snmp", 32, "Counter32", mibRO, ""},
+			{"snmpMIB", "snmpModules", 1, "MODULE-IDENTITY", "", ""},
+			{"snmpMIBObjects", "snmpMIB", 1, "", "", ""},
+			{"snmpTrap", "snmpMIBObjects", 4, "", "", ""},
+			{"snmpTrapOID", "snmpTrap", 1, "OBJECT IDENTIFIER", mibAN, ""},
+			{"snmpTrapEnterprise", "snmpTrap", 3, "OBJECT IDENTIFIER", mibAN, ""},
+			{"snmpTraps", "snmpMIBObjects", 5, "", "", ""},
+			{"coldStart", "snmpTraps", 1, "NOTIFICATION-TYPE", "", ""},
+			{"warmStart", "snmpTraps", 2, "NOTIFICATION-TYPE", "", ""},
+			{"authenticationFailure", "snmpTraps", 5, "NOTIFICATION-TYPE", "", ""},
+			{"snmpSet", "snmpMIBObjects", 6, "", "", ""},
+			{"snmpSetSerialNo", "snmpSet", 1, "TestAndIncr", mibRW, ""},
+		},
+	},
+	{
+		name: "IANAifType-MIB",
+		types: []builtinType{
+			{"IANAifType", `INTEGER {
+				other(1), regular1822(2), hdh1822(3), ddnX25(4), rfc877x25(5),
+				ethernetCsmacd(6), iso88023Csmacd(7), iso88024TokenBus(8),
+				iso88025TokenRing(9), iso88026Man(10), starLan(11),
+				proteon10Mbit(12), proteon80Mbit(13), hyperchannel(14),
+				fddi(15), lapb(16), sdlc(17), ds1(18), e1(19), basicISDN(20),
+				primaryISDN(21), propPointToPointSerial(22), ppp(23),
+				softwareLoopback(24), eon(25), ethernet3Mbit(26), nsip(27),
+				slip(28), ultra(29), ds3(30), sip(31), frameRelay(32),
+				rs232(33), para(34), arcnet(35), arcnetPlus(36), atm(37),
+				miox25(38), sonet(39), x25ple(40), iso88022llc(41),
+				localTalk(42), smdsDxi(43), frameRelayService(44), v35(45),
+				hssi(46), hippi(47), modem(48), aal5(49), sonetPath(50),
+				sonetVT(51), smdsIcip(52), propVirtual(53),
+				propMultiplexor(54), ieee80212(55), fibreChannel(56),
+				fastEther(62), isdn(63), ieee80211(71), fastEtherFX(69),
+				adsl(94), vdsl(97), voiceEncap(103), virtualIpAddress(112),
+				gigabitEthernet(117), docsCableMaclayer(127),
+				docsCableDownstream(128), docsCableUpstream(129), tunnel(131),
+				atmSubInterface(134), l2vlan(135), l3ipvlan(136),
+				l3ipxvlan(137), mplsTunnel(150), usb(160), ieee8023adLag(161),
+				mpls(166), opticalChannel(195), bridge(209),
+				macSecControlledIF(231), macSecUncontrolledIF(232),
+				ieee802154(259)
+			}`, ""},
+		},
+	},
+	{
+		name: "IF-MIB",
+		types: []builtinType{
+			{"InterfaceIndex", "Integer32 (1..2147483647)", "d"},
+			{"InterfaceIndexOrZero", "Integer32 (0..2147483647)", "d"},
+			{"OwnerString", "OCTET STRING (SIZE (0..255))", "255a"},
+		},
+		objects: []builtinObject{
+			{"interfaces", "mib-2", 2, "", "", ""},
+			{"ifNumber", "interfaces", 1, "Integer32", mibRO, ""},
+			{"ifTable", "interfaces", 2, "SEQUENCE OF IfEntry", mibNA, ""},
+			{"ifEntry", "ifTable", 1, "IfEntry", mibNA, "ifIndex"},
+			{"ifIndex", "ifEntry", 1, "InterfaceIndex", mibRO, ""},
+			{"ifDescr", "ifEntry", 2, "DisplayString (SIZE (0..255))", mibRO, ""},
+			{"ifType", "ifEntry", 3, "IANAifType", mibRO, ""},
+			{"ifMtu", "ifEntry", 4, "Integer32", mibRO, ""},
+			{"ifSpeed", "ifEntry", 5, "Gauge32", mibRO, ""},
+			{"ifPhysAddress", "ifEntry", 6, "PhysAddress", mibRO, ""},
+			{"ifAdminStatus", "ifEntry", 7, "INTEGER { up(1), down(2), testing(3) }", mibRW, ""},
+			{"ifOperStatus", "ifEntry", 8, "INTEGER { up(1), down(2), testing(3), unknown(4), dormant(5), notPresent(6), lowerLayerDown(7) }", mibRO, ""},
+			{"ifLastChange", "ifEntry", 9, "TimeTicks", mibRO, ""},
+			{"ifInOctets", "ifEntry", 10, "Counter32", mibRO, ""},
+			{"ifInUcastPkts", "ifEntry", 11, "Counter32", mibRO, ""},
+			{"ifInNUcastPkts", "ifEntry", 12, "Counter32", mibRO, ""},
+			{"ifInDiscards", "ifEntry", 13, "Counter32", mibRO, ""},
+			{"ifInErrors", "ifEntry", 14, "Counter32", mibRO, ""},
+			{"ifInUnknownProtos", "ifEntry", 15, "Counter32", mibRO, ""},
+			{"ifOutOctets", "ifEntry", 16, "Counter32", mibRO, ""},
+			{"ifOutUcastPkts", "ifEntry", 17, "Counter32", mibRO, ""},
+			{"ifOutNUcastPkts", "ifEntry", 18, "Counter32", mibRO, ""},
+			{"ifOutDiscards", "ifEntry", 19, "Counter32", mibRO, ""},
+			{"ifOutErrors", "ifEntry", 20, "Counter32", mibRO, ""},
+			{"ifOutQLen", "ifEntry", 21, "Gauge32", mibRO, ""},
+			{"ifSpecific", "ifEntry", 22, "OBJECT IDENTIFIER", mibRO, ""},
+			{"ifMIB", "mib-2", 31, "MODULE-IDENTITY", "", ""},
+			{"ifMIBObjects", "ifMIB", 1, "", "", ""},
+			{"ifXTable", "ifMIBObjects", 1, "SEQUENCE OF IfXEntry", mibNA, ""},
+			{"ifXEntry", "ifXTable", 1, "IfXEntry", mibNA, "AUGMENTS ifEntry"},
+			{"ifName", "ifXEntry", 1, "DisplayString", mibRO, ""},
+			{"ifInMulticastPkts", "ifXEntry", 2, "Counter32", mibRO, ""},
+			{"ifInBroadcastPkts", "ifXEntry", 3, "Counter32", mibRO, ""},
+			{"ifOutMulticastPkts", "ifXEntry", 4, "Counter32", mibRO, ""},
+			{"ifOutBroadcastPkts", "ifXEntry", 5, "Counter32", mibRO, ""},
+			{"ifHCInOctets", "ifXEntry", 6, "Counter64", mibRO, ""},
+			{"ifHCInUcastPkts", "ifXEntry", 7, "Counter64", mibRO, ""},
+			{"ifHCInMulticastPkts", "ifXEntry", 8, "Counter64", mibRO, ""},
+			{"ifHCInBroadcastPkts", "ifXEntry", 9, "Counter64", mibRO, ""},
+			{"ifHCOutOctets", "ifXEntry", 10, "Counter64", mibRO, ""},
+			{"ifHCOutUcastPkts", "ifXEntry", 11, "Counter64", mibRO, ""},
+			{"ifHCOutMulticastPkts", "ifXEntry", 12, "Counter64", mibRO, ""},
+			{"ifHCOutBroadcastPkts", "ifXEntry", 13, "Counter64", mibRO, ""},
+			{"ifLinkUpDownTrapEnable", "ifXEntry", 14, "INTEGER { enabled(1), disabled(2) }", mibRW, ""},
+			{"ifHighSpeed", "ifXEntry", 15, "Gauge32", mibRO, ""},
+			{"ifPromiscuousMode", "ifXEntry", 16, "TruthValue", mibRW, ""},
+			{"ifConnectorPresent", "ifXEntry", 17, "TruthValue", mibRO, ""},
+			{"ifAlias", "ifXEntry", 18, "DisplayString (SIZE (0..64))", mibRW, ""},
+			{"ifCounterDiscontinuityTime", "ifXEntry", 19, "TimeStamp", mibRO, ""},
+			{"ifStackTable", "ifMIBObjects", 2, "SEQUENCE OF IfStackEntry", mibNA, ""},
+			{"ifStackEntry", "ifStackTable", 1, "IfStackEntry", mibNA, "ifStackHigherLayer ifStackLowerLayer"},
+			{"ifStackHigherLayer", "ifStackEntry", 1, "InterfaceIndexOrZero", mibNA, ""},
+			{"ifStackLowerLayer", "ifStackEntry", 2, "InterfaceIndexOrZero", mibNA, ""},
+			{"ifStackStatus", "ifStackEntry", 3, "RowStatus", mibRC, ""},
+			{"ifTableLastChange", "ifMIBObjects", 5, "TimeTicks", mibRO, ""},
+			{"ifStackLastChange", "ifMIBObjects", 6, "TimeTicks", mibRO, ""},
+			{"linkDown", "snmpTraps", 3, "NOTIFICATION-TYPE", "", "ifIndex ifAdminStatus ifOperStatus"},
+			{"linkUp", "snmpTraps", 4, "NOTIFICATION-TYPE", "", "ifIndex ifAdminStatus ifOperStatus"},
+		},
+	},
+	{
+		name: "INET-ADDRESS-MIB",
+		types: []builtinType{
+			{"InetAddressType", "INTEGER { unknown(0), ipv4(1), ipv6(2), ipv4z(3), ipv6z(4), dns(16) }", ""},
+			{"InetAddress", "OCTET STRING (SIZE (0..255))", ""},
+			{"InetAddressIPv4", "OCTET STRING (SIZE (4))", "1d.1d.1d.1d"},
+			{"InetAddressIPv6", "OCTET STRING (SIZE (16))", "2x:2x:2x:2x:2x:2x:2x:2x"},
+			{"InetAddressDNS", "OCTET STRING (SIZE (1..255))", "255a"},
+			{"InetAddressPrefixLength", "Unsigned32 (0..2040)", "d"},
+			{"InetPortNumber", "Unsigned32 (0..65535)", "d"},
+			{"InetAutonomousSystemNumber", "Unsigned32", "d"},
+			{"InetZoneIndex", "Unsigned32", "d"},
+			{"InetVersion", "INTEGER { unknown(0), ipv4(1), ipv6(2) }", ""},
+		},
+	},
+	{
+		name: "IP-MIB",
+		types: []builtinType{
+			{"IpAddressOriginTC", "INTEGER { other(1), manual(2), dhcp(4), linklayer(5), random(6) }", ""},
+			{"IpAddressStatusTC", "INTEGER { preferred(1), deprecated(2), invalid(3), inaccessible(4), unknown(5), tentative(6), duplicate(7), optimistic(8) }", ""},
+			{"IpAddressPrefixOriginTC", "INTEGER { other(1), manual(2), wellknown(3), dhcp(4), routeradv(5) }", ""},
+		},
+		objects: []builtinObject{
+			{"ip", "mib-2", 4, "", "", ""},
+			{"ipForwarding", "ip", 1, "INTEGER { forwarding(1), notForwarding(2) }", mibRW, ""},
+			{"ipDefaultTTL", "ip", 2, "INTEGER (1..255)", mibRW, ""},
+			{"ipAddrTable", "ip", 20, "SEQUENCE OF IpAddrEntry", mibNA, ""},
+			{"ipAddrEntry", "ipAddrTable", 1, "IpAddrEntry", mibNA, "ipAdEntAddr"},
+			{"ipAdEntAddr", "ipAddrEntry", 1, "IpAddress", mibRO, ""},
+			{"ipAdEntIfIndex", "ipAddrEntry", 2, "INTEGER (1..2147483647)", mibRO, ""},
+			{"ipAdEntNetMask", "ipAddrEntry", 3, "IpAddress", mibRO, ""},
+			{"ipAdEntBcastAddr", "ipAddrEntry", 4, "INTEGER (0..1)", mibRO, ""},
+			{"ipAdEntReasmMaxSize", "ipAddrEntry", 5, "INTEGER (0..65535)", mibRO, ""},
+			{"ipNetToMediaTable", "ip", 22, "SEQUENCE OF IpNetToMediaEntry", mibNA, ""},
+			{"ipNetToMediaEntry", "ipNetToMediaTable", 1, "IpNetToMediaEntry", mibNA, "ipNetToMediaIfIndex ipNetToMediaNetAddress"},
+			{"ipNetToMediaIfIndex", "ipNetToMediaEntry", 1, "INTEGER (1..2147483647)", mibRC, ""},
+			{"ipNetToMediaPhysAddress", "ipNetToMediaEntry", 2, "PhysAddress (SIZE (0..65535))", mibRC, ""},
+			{"ipNetToMediaNetAddress", "ipNetToMediaEntry", 3, "IpAddress", mibRC, ""},
+			{"ipNetToMediaType", "ipNetToMediaEntry", 4, "INTEGER { other(1), invalid(2), dynamic(3), static(4) }", mibRC, ""},
+			{"ipv6IpForwarding", "ip", 25, "INTEGER { forwarding(1), notForwarding(2) }", mibRW, ""},
+			{"ipv6IpDefaultHopLimit", "ip", 26, "INTEGER (0..255)", mibRW, ""},
+			{"ipAddressPrefixTable", "ip", 32, "SEQUENCE OF IpAddressPrefixEntry", mibNA, ""},
+			{"ipAddressPrefixEntry", "ipAddressPrefixTable", 1, "IpAddressPrefixEntry", mibNA, "ipAddressPrefixIfIndex ipAddressPrefixType ipAddressPrefixPrefix ipAddressPrefixLength"},
+			{"ipAddressPrefixIfIndex", "ipAddressPrefixEntry", 1, "InterfaceIndex", mibNA, ""},
+			{"ipAddressPrefixType", "ipAddressPrefixEntry", 2, "InetAddressType", mibNA, ""},
+			{"ipAddressPrefixPrefix", "ipAddressPrefixEntry", 3, "InetAddress", mibNA, ""},
+			{"ipAddressPrefixLength", "ipAddressPrefixEntry", 4, "InetAddressPrefixLength", mibNA, ""},
+			{"ipAddressPrefixOrigin", "ipAddressPrefixEntry", 5, "IpAddressPrefixOriginTC", mibRO, ""},
+			{"ipAddressPrefixOnLinkFlag", "ipAddressPrefixEntry", 6, "TruthValue", mibRO, ""},
+			{"ipAddressPrefixAutonomousFlag", "ipAddressPrefixEntry", 7, "TruthValue", mibRO, ""},
+			{"ipAddressPrefixAdvPreferredLifetime", "ipAddressPrefixEntry", 8, "Unsigned32", mibRO, ""},
+			{"ipAddressPrefixAdvValidLifetime", "ipAddressPrefixEntry", 9, "Unsigned32", mibRO, ""},
+			{"ipAddressSpinLock", "ip", 33, "TestAndIncr", mibRW, ""},
+			{"ipAddressTable", "ip", 34, "SEQUENCE OF IpAddressEntry", mibNA, ""},
+			{"ipAddressEntry", "ipAddressTable", 1, "IpAddressEntry", mibNA, "ipAddressAddrType ipAddressAddr"},
+			{"ipAddressAddrType", "ipAddressEntry", 1, "InetAddressType", mibNA, ""},
+			{"ipAddressAddr", "ipAddressEntry", 2, "InetAddress", mibNA, ""},
+			{"ipAddressIfIndex", "ipAddressEntry", 3, "InterfaceIndex", mibRC, ""},
+			{"ipAddressType", "ipAddressEntry", 4, "INTEGER { unicast(1), anycast(2), broadcast(3) }", mibRC, ""},
+			{"ipAddressPrefix", "ipAddressEntry", 5, "RowPointer", mibRO, ""},
+			{"ipAddressOrigin", "ipAddressEntry", 6, "IpAddressOriginTC", mibRO, ""},
+			{"ipAddressStatus", "ipAddressEntry", 7, "IpAddressStatusTC", mibRC, ""},
+			{"ipAddressCreated", "ipAddressEntry", 8, "TimeStamp", mibRO, ""},
+			{"ipAddressLastChanged", "ipAddressEntry", 9, "TimeStamp", mibRO, ""},
+			{"ipAddressRowStatus", "ipAddressEntry", 10, "RowStatus", mibRC, ""},
+			{"ipAddressStorageType", "ipAddressEntry", 11, "StorageType", mibRC, ""},
+			{"ipNetToPhysicalTable", "ip", 35, "SEQUENCE OF IpNetToPhysicalEntry", mibNA, ""},
+			{"ipNetToPhysicalEntry", "ipNetToPhysicalTable", 1, "IpNetToPhysicalEntry", mibNA, "ipNetToPhysicalIfIndex ipNetToPhysicalNetAddressType ipNetToPhysicalNetAddress"},
+			{"ipNetToPhysicalIfIndex", "ipNetToPhysicalEntry", 1, "InterfaceIndex", mibNA, ""},
+			{"ipNetToPhysicalNetAddressType", "ipNetToPhysicalEntry", 2, "InetAddressType", mibNA, ""},
+			{"ipNetToPhysicalNetAddress", "ipNetToPhysicalEntry", 3, "InetAddress", mibNA, ""},
+			{"ipNetToPhysicalPhysAddress", "ipNetToPhysicalEntry", 4, "PhysAddress (SIZE (0..65535))", mibRC, ""},
+			{"ipNetToPhysicalLastUpdated", "ipNetToPhysicalEntry", 5, "TimeStamp", mibRO, ""},
+			{"ipNetToPhysicalType", "ipNetToPhysicalEntry", 6, "INTEGER { other(1), invalid(2), dynamic(3), static(4), local(5) }", mibRC, ""},
+			{"ipNetToPhysicalState", "ipNetToPhysicalEntry", 7, "INTEGER { reachable(1), stale(2), delay(3), probe(4), invalid(5), unknown(6), incomplete(7) }", mibRO, ""},
+			{"ipNetToPhysicalRowStatus", "ipNetToPhysicalEntry", 8, "RowStatus", mibRC, ""},
+			{"ipMIB", "mib-2", 48, "MODULE-IDENTITY", "", ""},
+		},
+	},
+	{
+		name: "IANA-RTPROTO-MIB",
+		types: []builtinType{
+			{"IANAipRouteProtocol", "INTEGER { other(1), local(2), netmgmt(3), icmp(4), egp(5), ggp(6), hello(7), rip(8), isIs(9), esIs(10), ciscoIgrp(11), bbnSpfIgp(12), ospf(13), bgp(14), idpr(15), ciscoEigrp(16), dvmrp(17), rpl(18), dhcp(19), ttdp(20) }", ""},
+		},
+	},
+	{
+		name: "IP-FORWARD-MIB",
+		objects: []builtinObject{
+			{"ipForward", "ip", 24, "MODULE-IDENTITY", "", ""},
+			{"ipCidrRouteNumber", "ipForward", 3, "Gauge32", mibRO, ""},
+			{"ipCidrRouteTable", "ipForward", 4, "SEQUENCE OF IpCidrRouteEntry", mibNA, ""},
+			{"ipCidrRouteEntry", "ipCidrRouteTable", 1, "IpCidrRouteEntry", mibNA, "ipCidrRouteDest ipCidrRouteMask ipCidrRouteTos ipCidrRouteNextHop"},
+			{"ipCidrRouteDest", "ipCidrRouteEntry", 1, "IpAddress", mibRO, ""},
+			{"ipCidrRouteMask", "ipCidrRouteEntry", 2, "IpAddress", mibRO, ""},
+			{"ipCidrRouteTos", "ipCidrRouteEntry", 3, "Integer32 (0..2147483647)", mibRO, ""},
+			{"ipCidrRouteNextHop", "ipCidrRouteEntry", 4, "IpAddress", mibRO, ""},
+			{"ipCidrRouteIfIndex", "ipCidrRouteEntry", 5, "Integer32", mibRC, ""},
+			{"ipCidrRouteType", "ipCidrRouteEntry", 6, "INTEGER { other(1), reject(2), local(3), remote(4) }", mibRC, ""},
+			{"ipCidrRouteProto", "ipCidrRouteEntry", 7, "INTEGER { other(1), local(2), netmgmt(3), icmp(4), egp(5), ggp(6), hello(7), rip(8), isIs(9), esIs(10), ciscoIgrp(11), bbnSpfIgp(12), ospf(13), bgp(14), idpr(15), ciscoEigrp(16) }", mibRO, ""},
+			{"ipCidrRouteAge", "ipCidrRouteEntry", 8, "Integer32", mibRO, ""},
+			{"ipCidrRouteInfo", "ipCidrRouteEntry", 9, "OBJECT IDENTIFIER", mibRC, ""},
+			{"ipCidrRouteNextHopAS", "ipCidrRouteEntry", 10, "Integer32", mibRC, ""},
+			{"ipCidrRouteMetric1", "ipCidrRouteEntry", 11, "Integer32", mibRC, ""},
+			{"ipCidrRouteMetric2", "ipCidrRouteEntry", 12, "Integer32", mibRC, ""},
+			{"ipCidrRouteMetric3", "ipCidrRouteEntry", 13, "Integer32", mibRC, ""},
+			{"ipCidrRouteMetric4", "ipCidrRouteEntry", 14, "Integer32", mibRC, ""},
+			{"ipCidrRouteMetric5", "ipCidrRouteEntry", 15, "Integer32", mibRC, ""},
+			{"ipCidrRouteStatus", "ipCidrRouteEntry", 16, "RowStatus", mibRC, ""},
+			{"inetCidrRouteNumber", "ipForward", 6, "Gauge32", mibRO, ""},
+			{"inetCidrRouteTable", "ipForward", 7, "SEQUENCE OF InetCidrRouteEntry", mibNA, ""},
+			{"inetCidrRouteEntry", "inetCidrRouteTable", 1, "InetCidrRouteEntry", mibNA, "inetCidrRouteDestType inetCidrRouteDest inetCidrRoutePfxLen inetCidrRoutePolicy inetCidrRouteNextHopType inetCidrRouteNextHop"},
+			{"inetCidrRouteDestType", "inetCidrRouteEntry", 1, "InetAddressType", mibNA, ""},
+			{"inetCidrRouteDest", "inetCidrRouteEntry", 2, "InetAddress", mibNA, ""},
+			{"inetCidrRoutePfxLen", "inetCidrRouteEntry", 3, "InetAddressPrefixLength", mibNA, ""},
+			{"inetCidrRoutePolicy", "inetCidrRouteEntry", 4, "OBJECT IDENTIFIER", mibNA, ""},
+			{"inetCidrRouteNextHopType", "inetCidrRouteEntry", 5, "InetAddressType", mibNA, ""},
+			{"inetCidrRouteNextHop", "inetCidrRouteEntry", 6, "InetAddress", mibNA, ""},
+			{"inetCidrRouteIfIndex", "inetCidrRouteEntry", 7, "InterfaceIndexOrZero", mibRC, ""},
+			{"inetCidrRouteType", "inetCidrRouteEntry", 8, "INTEGER { other(1), reject(2), local(3), remote(4), blackhole(5) }", mibRC, ""},
+			{"inetCidrRouteProto", "inetCidrRouteEntry", 9, "IANAipRouteProtocol", mibRO, ""},
+			{"inetCidrRouteAge", "inetCidrRouteEntry", 10, "Gauge32", mibRO, ""},
+			{"inetCidrRouteNextHopAS", "inetCidrRouteEntry", 11, "InetAutonomousSystemNumber", mibRC, ""},
+			{"inetCidrRouteMetric1", "inetCidrRouteEntry", 12, "Integer32", mibRC, ""},
+			{"inetCidrRouteMetric2", "inetCidrRouteEntry", 13, "Integer32", mibRC, ""},
+			{"inetCidrRouteMetric3", "inetCidrRouteEntry", 14, "Integer32", mibRC, ""},
+			{"inetCidrRouteMetric4", "inetCidrRouteEntry", 15, "Integer32", mibRC, ""},
+			{"inetCidrRouteMetric5", "inetCidrRouteEntry", 16, "Integer32", mibRC, ""},
+			{"inetCidrRouteStatus", "inetCidrRouteEntry", 17, "RowStatus", mibRC, ""},
+		},
+	},
+	{
+		// Only ipRouteTable, which IP-MIB and IP-FORWARD-MIB replaced but
+		// older agents still implement
+		name: "RFC1213-MIB",
+		objects: []builtinObject{
+			{"ipRouteTable", "ip", 21, "SEQUENCE OF IpRouteEntry", mibNA, ""},
+			{"ipRouteEntry", "ipRouteTable", 1, "IpRouteEntry", mibNA, "ipRouteDest"},
+			{"ipRouteDest", "ipRouteEntry", 1, "IpAddress", mibRW, ""},
+			{"ipRouteIfIndex", "ipRouteEntry", 2, "INTEGER", mibRW, ""},
+			{"ipRouteMetric1", "ipRouteEntry", 3, "INTEGER", mibRW, ""},
+			{"ipRouteMetric2", "ipRouteEntry", 4, "INTEGER", mibRW, ""},
+			{"ipRouteMetric3", "ipRouteEntry", 5, "INTEGER", mibRW, ""},
+			{"ipRouteMetric4", "ipRouteEntry", 6, "INTEGER", mibRW, ""},
+			{"ipRouteNextHop", "ipRouteEntry", 7, "IpAddress", mibRW, ""},
+			{"ipRouteType", "ipRouteEntry", 8, "INTEGER { other(1), invalid(2), direct(3), indirect(4) }", mibRW, ""},
+			{"ipRouteProto", "ipRouteEntry", 9, "INTEGER { other(1), local(2), netmgmt(3), icmp(4), egp(5), ggp(6), hello(7), rip(8), is-is(9), es-is(10), ciscoIgrp(11), bbnSpfIgp(12), ospf(13), bgp(14) }", mibRO, ""},
+			{"ipRouteAge", "ipRouteEntry", 10, "INTEGER", mibRW, ""},
+			{"ipRouteMask", "ipRouteEntry", 11, "IpAddress", mibRW, ""},
+			{"ipRouteMetric5", "ipRouteEntry", 12, "INTEGER", mibRW, ""},
+			{"ipRouteInfo", "ipRouteEntry", 13, "OBJECT IDENTIFIER", mibRO, ""},
+		},
+	},
+	{
+		name: "BGP4-MIB",
+		objects: []builtinObject{
+			{"bgp", "mib-2", 15, "MODULE-IDENTITY", "", ""},
+			{"bgpVersion", "bgp", 1, "OCTET STRING (SIZE (1..255))", mibRO, ""},
+			{"bgpLocalAs", "bgp", 2, "INTEGER (0..65535)", mibRO, ""},
+			{"bgpPeerTable", "bgp", 3, "SEQUENCE OF BgpPeerEntry", mibNA, ""},
+			{"bgpPeerEntry", "bgpPeerTable", 1, "BgpPeerEntry", mibNA, "bgpPeerRemoteAddr"},
+			{"bgpPeerIdentifier", "bgpPeerEntry", 1, "IpAddress", mibRO, ""},
+			{"bgpPeerState", "bgpPeerEntry", 2, "INTEGER { idle(1), connect(2), active(3), opensent(4), openconfirm(5), established(6) }", mibRO, ""},
+			{"bgpPeerAdminStatus", "bgpPeerEntry", 3, "INTEGER { stop(1), start(2) }", mibRW, ""},
+			{"bgpPeerNegotiatedVersion", "bgpPeerEntry", 4, "Integer32", mibRO, ""},
+			{"bgpPeerLocalAddr", "bgpPeerEntry", 5, "IpAddress", mibRO, ""},
+			{"bgpPeerLocalPort", "bgpPeerEntry", 6, "INTEGER (0..65535)", mibRO, ""},
+			{"bgpPeerRemoteAddr", "bgpPeerEntry", 7, "IpAddress", mibRO, ""},
+			{"bgpPeerRemotePort", "bgpPeerEntry", 8, "INTEGER (0..65535)", mibRO, ""},
+			{"bgpPeerRemoteAs", "bgpPeerEntry", 9, "INTEGER (0..65535)", mibRO, ""},
+			{"bgpPeerInUpdates", "bgpPeerEntry", 10, "Counter32", mibRO, ""},
+			{"bgpPeerOutUpdates", "bgpPeerEntry", 11, "Counter32", mibRO, ""},
+			{"bgpPeerInTotalMessages", "bgpPeerEntry", 12, "Counter32", mibRO, ""},
+			{"bgpPeerOutTotalMessages", "bgpPeerEntry", 13, "Counter32", mibRO, ""},
+			{"bgpPeerLastError", "bgpPeerEntry", 14, "OCTET STRING (SIZE (2))", mibRO, ""},
+			{"bgpPeerFsmEstablishedTransitions", "bgpPeerEntry", 15, "Counter32", mibRO, ""},
+			{"bgpPeerFsmEstablishedTime", "bgpPeerEntry", 16, "Gauge32", mibRO, ""},
+			{"bgpPeerConnectRetryInterval", "bgpPeerEntry", 17, "INTEGER (1..65535)", mibRW, ""},
+			{"bgpPeerHoldTime", "bgpPeerEntry", 18, "INTEGER (0 | 3..65535)", mibRO, ""},
+			{"bgpPeerKeepAlive", "bgpPeerEntry", 19, "INTEGER (0 | 1..21845)", mibRO, ""},
+			{"bgpPeerHoldTimeConfigured", "bgpPeerEntry", 20, "INTEGER (0 | 3..65535)", mibRW, ""},
+			{"bgpPeerKeepAliveConfigured", "bgpPeerEntry", 21, "INTEGER (0 | 1..21845)", mibRW, ""},
+			{"bgpPeerMinASOriginationInterval", "bgpPeerEntry", 22, "INTEGER (1..65535)", mibRW, ""},
+			{"bgpPeerMinRouteAdvertisementInterval", "bgpPeerEntry", 23, "INTEGER (1..65535)", mibRW, ""},
+			{"bgpPeerInUpdateElapsedTime", "bgpPeerEntry", 24, "Gauge32", mibRO, ""},
+			{"bgpIdentifier", "bgp", 4, "IpAddress", mibRO, ""},
+			{"bgpTraps", "bgp", 7, "", "", ""},
+			{"bgpEstablishedNotification", "bgpTraps", 1, "NOTIFICATION-TYPE", "", ""},
+			{"bgpBackwardTransNotification", "bgpTraps", 2, "NOTIFICATION-TYPE", "", ""},
+		},
+	},
+	{
+		// The objects of RFC 4750 for OSPF interfaces and neighbours
+		name: "OSPF-MIB",
+		types: []builtinType{
+			{"AreaID", "IpAddress", ""},
+			{"RouterID", "IpAddress", ""},
+			{"Status", "INTEGER { enabled(1), disabled(2) }", ""},
+			{"PositiveInteger", "Integer32 (0..2147483647)", ""},
+			{"HelloRange", "Integer32 (1..65535)", ""},
+			{"UpToMaxAge", "Integer32 (0..3600)", ""},
+			{"DesignatedRouterPriority", "Integer32 (0..255)", ""},
+			{"OspfAuthenticationType", "INTEGER { none(0), simplePassword(1), md5(2) }", ""},
+		},
+		objects: []builtinObject{
+			{"ospf", "mib-2", 14, "MODULE-IDENTITY", "", ""},
+			{"ospfGeneralGroup", "ospf", 1, "", "", ""},
+			{"ospfRouterId", "ospfGeneralGroup", 1, "RouterID", mibRW, ""},
+			{"ospfAdminStat", "ospfGeneralGroup", 2, "Status", mibRW, ""},
+			{"ospfIfTable", "ospf", 7, "SEQUENCE OF OspfIfEntry", mibNA, ""},
+			{"ospfIfEntry", "ospfIfTable", 1, "OspfIfEntry", mibNA, "ospfIfIpAddress ospfAddressLessIf"},
+			{"ospfIfIpAddress", "ospfIfEntry", 1, "IpAddress", mibRO, ""},
+			{"ospfAddressLessIf", "ospfIfEntry", 2, "InterfaceIndexOrZero", mibRO, ""},
+			{"ospfIfAreaId", "ospfIfEntry", 3, "AreaID", mibRC, ""},
+			{"ospfIfType", "ospfIfEntry", 4, "INTEGER { broadcast(1), nbma(2), pointToPoint(3), pointToMultipoint(5) }", mibRC, ""},
+			{"ospfIfAdminStat", "ospfIfEntry", 5, "Status", mibRC, ""},
+			{"ospfIfRtrPriority", "ospfIfEntry", 6, "DesignatedRouterPriority", mibRC, ""},
+			{"ospfIfTransitDelay", "ospfIfEntry", 7, "UpToMaxAge", mibRC, ""},
+			{"ospfIfRetransInterval", "ospfIfEntry", 8, "UpToMaxAge", mibRC, ""},
+			{"ospfIfHelloInterval", "ospfIfEntry", 9, "HelloRange", mibRC, ""},
+			{"ospfIfRtrDeadInterval", "ospfIfEntry", 10, "PositiveInteger", mibRC, ""},
+			{"ospfIfPollInterval", "ospfIfEntry", 11, "PositiveInteger", mibRC, ""},
+			{"ospfIfState", "ospfIfEntry", 12, "INTEGER { down(1), loopback(2), waiting(3), pointToPoint(4), designatedRouter(5), backupDesignatedRouter(6), otherDesignatedRouter(7) }", mibRO, ""},
+			{"ospfIfDesignatedRouter", "ospfIfEntry", 13, "IpAddress", mibRO, ""},
+			{"ospfIfBackupDesignatedRouter", "ospfIfEntry", 14, "IpAddress", mibRO, ""},
+			{"ospfIfEvents", "ospfIfEntry", 15, "Counter32", mibRO, ""},
+			{"ospfIfAuthKey", "ospfIfEntry", 16, "OCTET STRING (SIZE (0..256))", mibRC, ""},
+			{"ospfIfStatus", "ospfIfEntry", 17, "RowStatus", mibRC, ""},
+			{"ospfIfMulticastForwarding", "ospfIfEntry", 18, "INTEGER { blocked(1), multicast(2), unicast(3) }", mibRC, ""},
+			{"ospfIfDemand", "ospfIfEntry", 19, "TruthValue", mibRC, ""},
+			{"ospfIfAuthType", "ospfIfEntry", 20, "OspfAuthenticationType", mibRC, ""},
+			{"ospfNbrTable", "ospf", 10, "SEQUENCE OF OspfNbrEntry", mibNA, ""},
+			{"ospfNbrEntry", "ospfNbrTable", 1, "OspfNbrEntry", mibNA, "ospfNbrIpAddr ospfNbrAddressLessIndex"},
+			{"ospfNbrIpAddr", "ospfNbrEntry", 1, "IpAddress", mibRO, ""},
+			{"ospfNbrAddressLessIndex", "ospfNbrEntry", 2, "InterfaceIndexOrZero", mibRO, ""},
+			{"ospfNbrRtrId", "ospfNbrEntry", 3, "RouterID", mibRO, ""},
+			{"ospfNbrOptions", "ospfNbrEntry", 4, "Integer32", mibRO, ""},
+			{"ospfNbrPriority", "ospfNbrEntry", 5, "DesignatedRouterPriority", mibRC, ""},
+			{"ospfNbrState", "ospfNbrEntry", 6, "INTEGER { down(1), attempt(2), init(3), twoWay(4), exchangeStart(5), exchange(6), loading(7), full(8) }", mibRO, ""},
+			{"ospfNbrEvents", "ospfNbrEntry", 7, "Counter32", mibRO, ""},
+			{"ospfNbrLsRetransQLen", "ospfNbrEntry", 8, "Gauge32", mibRO, ""},
+			{"ospfNbmaNbrStatus", "ospfNbrEntry", 9, "RowStatus", mibRC, ""},
+			{"ospfNbmaNbrPermanence", "ospfNbrEntry", 10, "INTEGER { dynamic(1), permanent(2) }", mibRO, ""},
+			{"ospfNbrHelloSuppressed", "ospfNbrEntry", 11, "TruthValue", mibRO, ""},
+		},
+	},
+	{
+		name: "HOST-RESOURCES-MIB",
+		types: []builtinType{
+			{"KBytes", "Integer32 (0..2147483647)", ""},
+			{"ProductID", "OBJECT IDENTIFIER", ""},
+			{"InternationalDisplayString", "OCTET STRING", ""},
+		},
+		objects: []builtinObject{
+			{"host", "mib-2", 25, "", "", ""},
+			{"hrSystem", "host", 1, "", "", ""},
+			{"hrSystemUptime", "hrSystem", 1, "TimeTicks", mibRO, ""},
+			{"hrSystemDate", "hrSystem", 2, "DateAndTime", mibRW, ""},
+			{"hrSystemInitialLoadDevice", "hrSystem", 3, "Integer32 (1..2147483647)", mibRW, ""},
+			{"hrSystemInitialLoadParameters", "hrSystem", 4, "InternationalDisplayString (SIZE (0..128))", mibRW, ""},
+			{"hrSystemNumUsers", "hrSystem", 5, "Gauge32", mibRO, ""},
+			{"hrSystemProcesses", "hrSystem", 6, "Gauge32", mibRO, ""},
+			{"hrSystemMaxProcesses", "hrSystem", 7, "Integer32 (0..2147483647)", mibRO, ""},
+			{"hrStorage", "host", 2, "", "", ""},
+			{"hrMemorySize", "hrStorage", 2, "KBytes", mibRO, ""},
+			{"hrStorageTable", "hrStorage", 3, "SEQUENCE OF HrStorageEntry", mibNA, ""},
+			{"hrStorageEntry", "hrStorageTable", 1, "HrStorageEntry", mibNA, "hrStorageIndex"},
+			{"hrStorageIndex", "hrStorageEntry", 1, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrStorageType", "hrStorageEntry", 2, "AutonomousType", mibRO, ""},
+			{"hrStorageDescr", "hrStorageEntry", 3, "DisplayString", mibRO, ""},
+			{"hrStorageAllocationUnits", "hrStorageEntry", 4, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrStorageSize", "hrStorageEntry", 5, "Integer32 (0..2147483647)", mibRW, ""},
+			{"hrStorageUsed", "hrStorageEntry", 6, "Integer32 (0..2147483647)", mibRO, ""},
+			{"hrStorageAllocationFailures", "hrStorageEntry", 7, "Counter32", mibRO, ""},
+			{"hrDevice", "host", 3, "", "", ""},
+			{"hrDeviceTable", "hrDevice", 2, "SEQUENCE OF HrDeviceEntry", mibNA, ""},
+			{"hrDeviceEntry", "hrDeviceTable", 1, "HrDeviceEntry", mibNA, "hrDeviceIndex"},
+			{"hrDeviceIndex", "hrDeviceEntry", 1, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrDeviceType", "hrDeviceEntry", 2, "AutonomousType", mibRO, ""},
+			{"hrDeviceDescr", "hrDeviceEntry", 3, "DisplayString (SIZE (0..64))", mibRO, ""},
+			{"hrDeviceID", "hrDeviceEntry", 4, "ProductID", mibRO, ""},
+			{"hrDeviceStatus", "hrDeviceEntry", 5, "INTEGER { unknown(1), running(2), warning(3), testing(4), down(5) }", mibRO, ""},
+			{"hrDeviceErrors", "hrDeviceEntry", 6, "Counter32", mibRO, ""},
+			{"hrProcessorTable", "hrDevice", 3, "SEQUENCE OF HrProcessorEntry", mibNA, ""},
+			{"hrProcessorEntry", "hrProcessorTable", 1, "HrProcessorEntry", mibNA, "hrDeviceIndex"},
+			{"hrProcessorFrwID", "hrProcessorEntry", 1, "ProductID", mibRO, ""},
+			{"hrProcessorLoad", "hrProcessorEntry", 2, "Integer32 (0..100)", mibRO, ""},
+			{"hrNetworkTable", "hrDevice", 4, "SEQUENCE OF HrNetworkEntry", mibNA, ""},
+			{"hrNetworkEntry", "hrNetworkTable", 1, "HrNetworkEntry", mibNA, "hrDeviceIndex"},
+			{"hrNetworkIfIndex", "hrNetworkEntry", 1, "InterfaceIndexOrZero", mibRO, ""},
+			{"hrDiskStorageTable", "hrDevice", 6, "SEQUENCE OF HrDiskStorageEntry", mibNA, ""},
+			{"hrDiskStorageEntry", "hrDiskStorageTable", 1, "HrDiskStorageEntry", mibNA, "hrDeviceIndex"},
+			{"hrDiskStorageAccess", "hrDiskStorageEntry", 1, "INTEGER { readWrite(1), readOnly(2) }", mibRO, ""},
+			{"hrDiskStorageMedia", "hrDiskStorageEntry", 2, "INTEGER { other(1), unknown(2), hardDisk(3), floppyDisk(4), opticalDiskROM(5), opticalDiskWORM(6), opticalDiskRW(7), ramDisk(8) }", mibRO, ""},
+			{"hrDiskStorageRemoveble", "hrDiskStorageEntry", 3, "TruthValue", mibRO, ""},
+			{"hrDiskStorageCapacity", "hrDiskStorageEntry", 4, "KBytes", mibRO, ""},
+			{"hrSWRun", "host", 4, "", "", ""},
+			{"hrSWOSIndex", "hrSWRun", 1, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrSWRunTable", "hrSWRun", 2, "SEQUENCE OF HrSWRunEntry", mibNA, ""},
+			{"hrSWRunEntry", "hrSWRunTable", 1, "HrSWRunEntry", mibNA, "hrSWRunIndex"},
+			{"hrSWRunIndex", "hrSWRunEntry", 1, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrSWRunName", "hrSWRunEntry", 2, "InternationalDisplayString (SIZE (0..64))", mibRO, ""},
+			{"hrSWRunID", "hrSWRunEntry", 3, "ProductID", mibRO, ""},
+			{"hrSWRunPath", "hrSWRunEntry", 4, "InternationalDisplayString (SIZE (0..128))", mibRO, ""},
+			{"hrSWRunParameters", "hrSWRunEntry", 5, "InternationalDisplayString (SIZE (0..128))", mibRO, ""},
+			{"hrSWRunType", "hrSWRunEntry", 6, "INTEGER { unknown(1), operatingSystem(2), deviceDriver(3), application(4) }", mibRO, ""},
+			{"hrSWRunStatus", "hrSWRunEntry", 7, "INTEGER { running(1), runnable(2), notRunnable(3), invalid(4) }", mibRW, ""},
+			{"hrSWRunPerf", "host", 5, "", "", ""},
+			{"hrSWRunPerfTable", "hrSWRunPerf", 1, "SEQUENCE OF HrSWRunPerfEntry", mibNA, ""},
+			{"hrSWRunPerfEntry", "hrSWRunPerfTable", 1, "HrSWRunPerfEntry", mibNA, "AUGMENTS hrSWRunEntry"},
+			{"hrSWRunPerfCPU", "hrSWRunPerfEntry", 1, "Integer32 (0..2147483647)", mibRO, ""},
+			{"hrSWRunPerfMem", "hrSWRunPerfEntry", 2, "KBytes", mibRO, ""},
+			{"hrSWInstalled", "host", 6, "", "", ""},
+			{"hrSWInstalledLastChange", "hrSWInstalled", 1, "TimeTicks", mibRO, ""},
+			{"hrSWInstalledLastUpdateTime", "hrSWInstalled", 2, "TimeTicks", mibRO, ""},
+			{"hrSWInstalledTable", "hrSWInstalled", 3, "SEQUENCE OF HrSWInstalledEntry", mibNA, ""},
+			{"hrSWInstalledEntry", "hrSWInstalledTable", 1, "HrSWInstalledEntry", mibNA, "hrSWInstalledIndex"},
+			{"hrSWInstalledIndex", "hrSWInstalledEntry", 1, "Integer32 (1..2147483647)", mibRO, ""},
+			{"hrSWInstalledName", "hrSWInstalledEntry", 2, "InternationalDisplayString (SIZE (0..64))", mibRO, ""},
+			{"hrSWInstalledID", "hrSWInstalledEntry", 3, "ProductID", mibRO, ""},
+			{"hrSWInstalledType", "hrSWInstalledEntry", 4, "INTEGER { unknown(1), operatingSystem(2), deviceDriver(3), application(4) }", mibRO, ""},
+			{"hrSWInstalledDate", "hrSWInstalledEntry", 5, "DateAndTime", mibRO, ""},
+		},
+	},
+	{
+		name: "HOST-RESOURCES-TYPES",
+		objects: []builtinObject{
+			{"hrStorageTypes", "hrStorage", 1, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageOther", "hrStorageTypes", 1, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageRam", "hrStorageTypes", 2, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageVirtualMemory", "hrStorageTypes", 3, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageFixedDisk", "hrStorageTypes", 4, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageRemovableDisk", "hrStorageTypes", 5, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageFloppyDisk", "hrStorageTypes", 6, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageCompactDisc", "hrStorageTypes", 7, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageRamDisk", "hrStorageTypes", 8, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageFlashMemory", "hrStorageTypes", 9, "OBJECT-IDENTITY", "", ""},
+			{"hrStorageNetworkDisk", "hrStorageTypes", 10, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceTypes", "hrDevice", 1, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceOther", "hrDeviceTypes", 1, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceUnknown", "hrDeviceTypes", 2, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceProcessor", "hrDeviceTypes", 3, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceNetwork", "hrDeviceTypes", 4, "OBJECT-IDENTITY", "", ""},
+			{"hrDevicePrinter", "hrDeviceTypes", 5, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceDiskStorage", "hrDeviceTypes", 6, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceVideo", "hrDeviceTypes", 10, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceAudio", "hrDeviceTypes", 11, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceCoprocessor", "hrDeviceTypes", 12, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceKeyboard", "hrDeviceTypes", 13, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceModem", "hrDeviceTypes", 14, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceParallelPort", "hrDeviceTypes", 15, "OBJECT-IDENTITY", "", ""},
+			{"hrDevicePointing", "hrDeviceTypes", 16, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceSerialPort", "hrDeviceTypes", 17, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceTape", "hrDeviceTypes", 18, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceClock", "hrDeviceTypes", 19, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceVolatileMemory", "hrDeviceTypes", 20, "OBJECT-IDENTITY", "", ""},
+			{"hrDeviceNonVolatileMemory", "hrDeviceTypes", 21, "OBJECT-IDENTITY", "", ""},
+		},
+	},
+	{
+		name: "ENTITY-MIB",
+		types: []builtinType{
+			{"PhysicalIndex", "Integer32 (1..2147483647)", ""},
+			{"PhysicalIndexOrZero", "Integer32 (0..2147483647)", ""},
+			{"PhysicalClass", "INTEGER { other(1), unknown(2), chassis(3), backplane(4), container(5), powerSupply(6), fan(7), sensor(8), module(9), port(10), stack(11), cpu(12), energyObject(13), battery(14), storageDrive(15) }", ""},
+			{"SnmpEngineIdOrNone", "OCTET STRING (SIZE (0..32))", ""},
+		},
+		objects: []builtinObject{
+			{"entityMIB", "mib-2", 47, "MODULE-IDENTITY", "", ""},
+			{"entityMIBObjects", "entityMIB", 1, "", "", ""},
+			{"entityPhysical", "entityMIBObjects", 1, "", "", ""},
+			{"entPhysicalTable", "entityPhysical", 1, "SEQUENCE OF EntPhysicalEntry", mibNA, ""},
+			{"entPhysicalEntry", "entPhysicalTable", 1, "EntPhysicalEntry", mibNA, "entPhysicalIndex"},
+			{"entPhysicalIndex", "entPhysicalEntry", 1, "PhysicalIndex", mibNA, ""},
+			{"entPhysicalDescr", "entPhysicalEntry", 2, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalVendorType", "entPhysicalEntry", 3, "AutonomousType", mibRO, ""},
+			{"entPhysicalContainedIn", "entPhysicalEntry", 4, "PhysicalIndexOrZero", mibRO, ""},
+			{"entPhysicalClass", "entPhysicalEntry", 5, "PhysicalClass", mibRO, ""},
+			{"entPhysicalParentRelPos", "entPhysicalEntry", 6, "Integer32 (-1..2147483647)", mibRO, ""},
+			{"entPhysicalName", "entPhysicalEntry", 7, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalHardwareRev", "entPhysicalEntry", 8, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalFirmwareRev", "entPhysicalEntry", 9, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalSoftwareRev", "entPhysicalEntry", 10, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalSerialNum", "entPhysicalEntry", 11, "SnmpAdminString (SIZE (0..32))", mibRW, ""},
+			{"entPhysicalMfgName", "entPhysicalEntry", 12, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalModelName", "entPhysicalEntry", 13, "SnmpAdminString", mibRO, ""},
+			{"entPhysicalAlias", "entPhysicalEntry", 14, "SnmpAdminString (SIZE (0..32))", mibRW, ""},
+			{"entPhysicalAssetID", "entPhysicalEntry", 15, "SnmpAdminString (SIZE (0..32))", mibRW, ""},
+			{"entPhysicalIsFRU", "entPhysicalEntry", 16, "TruthValue", mibRO, ""},
+			{"entPhysicalMfgDate", "entPhysicalEntry", 17, "DateAndTime", mibRO, ""},
+			{"entPhysicalUris", "entPhysicalEntry", 18, "OCTET STRING", mibRW, ""},
+			{"entityLogical", "entityMIBObjects", 2, "", "", ""},
+			{"entLogicalTable", "entityLogical", 1, "SEQUENCE OF EntLogicalEntry", mibNA, ""},
+			{"entLogicalEntry", "entLogicalTable", 1, "EntLogicalEntry", mibNA, "entLogicalIndex"},
+			{"entLogicalIndex", "entLogicalEntry", 1, "Integer32 (1..2147483647)", mibNA, ""},
+			{"entLogicalDescr", "entLogicalEntry", 2, "SnmpAdminString", mibRO, ""},
+			{"entLogicalType", "entLogicalEntry", 3, "AutonomousType", mibRO, ""},
+			{"entLogicalCommunity", "entLogicalEntry", 4, "OCTET STRING (SIZE (0..255))", mibRO, ""},
+			{"entLogicalTAddress", "entLogicalEntry", 5, "TAddress", mibRO, ""},
+			{"entLogicalTDomain", "entLogicalEntry", 6, "TDomain", mibRO, ""},
+			{"entLogicalContextEngineID", "entLogicalEntry", 7, "SnmpEngineIdOrNone", mibRO, ""},
+			{"entLogicalContextName", "entLogicalEntry", 8, "SnmpAdminString", mibRO, ""},
+			{"entityMapping", "entityMIBObjects", 3, "", "", ""},
+			{"entAliasMappingTable", "entityMapping", 2, "SEQUENCE OF EntAliasMappingEntry", mibNA, ""},
+			{"entAliasMappingEntry", "entAliasMappingTable", 1, "EntAliasMappingEntry", mibNA, "entPhysicalIndex entAliasLogicalIndexOrZero"},
+			{"entAliasLogicalIndexOrZero", "entAliasMappingEntry", 1, "Integer32 (0..2147483647)", mibNA, ""},
+			{"entAliasMappingIdentifier", "entAliasMappingEntry", 2, "RowPointer", mibRO, ""},
+			{"entityGeneral", "entityMIBObjects", 4, "", "", ""},
+			{"entLastChangeTime", "entityGeneral", 1, "TimeStamp", mibRO, ""},
+			{"entityMIBTraps", "entityMIB", 2, "", "", ""},
+			{"entityMIBTrapPrefix", "entityMIBTraps", 0, "", "", ""},
+			{"entConfigChange", "entityMIBTrapPrefix", 1, "NOTIFICATION-TYPE", "", ""},
+		},
+	},
+	{
+		name: "ENTITY-SENSOR-MIB",
+		types: []builtinType{
+			{"EntitySensorDataType", "INTEGER { other(1), unknown(2), voltsAC(3), voltsDC(4), amperes(5), watts(6), hertz(7), celsius(8), percentRH(9), rpm(10), cmm(11), truthvalue(12), specialEnum(13), dBm(14) }", ""},
+			{"EntitySensorDataScale", "INTEGER { yocto(1), zepto(2), atto(3), femto(4), pico(5), nano(6), micro(7), milli(8), units(9), kilo(10), mega(11), giga(12), tera(13), exa(14), peta(15), zetta(16), yotta(17) }", ""},
+			{"EntitySensorPrecision", "Integer32 (-8..9)", ""},
+			{"EntitySensorValue", "Integer32 (-1000000000..1000000000)", ""},
+			{"EntitySensorStatus", "INTEGER { ok(1), unavailable(2), nonoperational(3) }", ""},
+		},
+		objects: []builtinObject{
+			{"entitySensorMIB", "mib-2", 99, "MODULE-IDENTITY", "", ""},
+			{"entitySensorObjects", "entitySensorMIB", 1, "", "", ""},
+			{"entPhySensorTable", "entitySensorObjects", 1, "SEQUENCE OF EntPhySensorEntry", mibNA, ""},
+			{"entPhySensorEntry", "entPhySensorTable", 1, "EntPhySensorEntry", mibNA, "entPhysicalIndex"},
+			{"entPhySensorType", "entPhySensorEntry", 1, "EntitySensorDataType", mibRO, ""},
+			{"entPhySensorScale", "entPhySensorEntry", 2, "EntitySensorDataScale", mibRO, ""},
+			{"entPhySensorPrecision", "entPhySensorEntry", 3, "EntitySensorPrecision", mibRO, ""},
+			{"entPhySensorValue", "entPhySensorEntry", 4, "EntitySensorValue", mibRO, ""},
+			{"entPhySensorOperStatus", "entPhySensorEntry", 5, "EntitySensorStatus", mibRO, ""},
+			{"entPhySensorUnitsDisplay", "entPhySensorEntry", 6, "SnmpAdminString", mibRO, ""},
+			{"entPhySensorValueTimeStamp", "entPhySensorEntry", 7, "TimeStamp", mibRO, ""},
+			{"entPhySensorValueUpdateRate", "entPhySensorEntry", 8, "Unsigned32", mibRO, ""},
+		},
+	},
+	{
+		name: "BRIDGE-MIB",
+		types: []builtinType{
+			{"BridgeId", "OCTET STRING (SIZE (8))", ""},
+			{"Timeout", "Integer32", "d"},
+		},
+		objects: []builtinObject{
+			{"dot1dBridge", "mib-2", 17, "", "", ""},
+			{"dot1dBase", "dot1dBridge", 1, "", "", ""},
+			{"dot1dBaseBridgeAddress", "dot1dBase", 1, "MacAddress", mibRO, ""},
+			{"dot1dBaseNumPorts", "dot1dBase", 2, "Integer32", mibRO, ""},
+			{"dot1dBaseType", "dot1dBase", 3, "INTEGER { unknown(1), transparent-only(2), sourceroute-only(3), srt(4) }", mibRO, ""},
+			{"dot1dBasePortTable", "dot1dBase", 4, "SEQUENCE OF Dot1dBasePortEntry", mibNA, ""},
+			{"dot1dBasePortEntry", "dot1dBasePortTable", 1, "Dot1dBasePortEntry", mibNA, "dot1dBasePort"},
+			{"dot1dBasePort", "dot1dBasePortEntry", 1, "Integer32 (1..65535)", mibRO, ""},
+			{"dot1dBasePortIfIndex", "dot1dBasePortEntry", 2, "InterfaceIndex", mibRO, ""},
+			{"dot1dBasePortCircuit", "dot1dBasePortEntry", 3, "OBJECT IDENTIFIER", mibRO, ""},
+			{"dot1dBasePortDelayExceededDiscards", "dot1dBasePortEntry", 4, "Counter32", mibRO, ""},
+			{"dot1dBasePortMtuExceededDiscards", "dot1dBasePortEntry", 5, "Counter32", mibRO, ""},
+			{"dot1dStp", "dot1dBridge", 2, "", "", ""},
+			{"dot1dStpProtocolSpecification", "dot1dStp", 1, "INTEGER { unknown(1), decLb100(2), ieee8021d(3) }", mibRO, ""},
+			{"dot1dStpPriority", "dot1dStp", 2, "Integer32 (0..65535)", mibRW, ""},
+			{"dot1dStpTimeSinceTopologyChange", "dot1dStp", 3, "TimeTicks", mibRO, ""},
+			{"dot1dStpTopChanges", "dot1dStp", 4, "Counter32", mibRO, ""},
+			{"dot1dStpDesignatedRoot", "dot1dStp", 5, "BridgeId", mibRO, ""},
+			{"dot1dStpRootCost", "dot1dStp", 6, "Integer32", mibRO, ""},
+			{"dot1dStpRootPort", "dot1dStp", 7, "Integer32", mibRO, ""},
+			{"dot1dStpPortTable", "dot1dStp", 15, "SEQUENCE OF Dot1dStpPortEntry", mibNA, ""},
+			{"dot1dStpPortEntry", "dot1dStpPortTable", 1, "Dot1dStpPortEntry", mibNA, "dot1dStpPort"},
+			{"dot1dStpPort", "dot1dStpPortEntry", 1, "Integer32 (1..65535)", mibRO, ""},
+			{"dot1dStpPortPriority", "dot1dStpPortEntry", 2, "Integer32 (0..255)", mibRW, ""},
+			{"dot1dStpPortState", "dot1dStpPortEntry", 3, "INTEGER { disabled(1), blocking(2), listening(3), learning(4), forwarding(5), broken(6) }", mibRO, ""},
+			{"dot1dStpPortEnable", "dot1dStpPortEntry", 4, "INTEGER { enabled(1), disabled(2) }", mibRW, ""},
+			{"dot1dStpPortPathCost", "dot1dStpPortEntry", 5, "Integer32 (1..65535)", mibRW, ""},
+			{"dot1dStpPortDesignatedRoot", "dot1dStpPortEntry", 6, "BridgeId", mibRO, ""},
+			{"dot1dStpPortDesignatedCost", "dot1dStpPortEntry", 7, "Integer32", mibRO, ""},
+			{"dot1dStpPortDesignatedBridge", "dot1dStpPortEntry", 8, "BridgeId", mibRO, ""},
+			{"dot1dStpPortDesignatedPort", "dot1dStpPortEntry", 9, "OCTET STRING (SIZE (2))", mibRO, ""},
+			{"dot1dStpPortForwardTransitions", "dot1dStpPortEntry", 10, "Counter32", mibRO, ""},
+			{"dot1dTp", "dot1dBridge", 4, "", "", ""},
+			{"dot1dTpLearnedEntryDiscards", "dot1dTp", 1, "Counter32", mibRO, ""},
+			{"dot1dTpAgingTime", "dot1dTp", 2, "Integer32 (10..1000000)", mibRW, ""},
+			{"dot1dTpFdbTable", "dot1dTp", 3, "SEQUENCE OF Dot1dTpFdbEntry", mibNA, ""},
+			{"dot1dTpFdbEntry", "dot1dTpFdbTable", 1, "Dot1dTpFdbEntry", mibNA, "dot1dTpFdbAddress"},
+			{"dot1dTpFdbAddress", "dot1dTpFdbEntry", 1, "MacAddress", mibRO, ""},
+			{"dot1dTpFdbPort", "dot1dTpFdbEntry", 2, "Integer32", mibRO, ""},
+			{"dot1dTpFdbStatus", "dot1dTpFdbEntry", 3, "INTEGER { other(1), invalid(2), learned(3), self(4), mgmt(5) }", mibRO, ""},
+		},
+	},
+	{
+		name: "Q-BRIDGE-MIB",
+		types: []builtinType{
+			{"PortList", "OCTET STRING", ""},
+			{"VlanIndex", "Unsigned32", ""},
+			{"VlanId", "Integer32 (1..4094)", ""},
+		},
+		objects: []builtinObject{
+			{"qBridgeMIB", "dot1dBridge", 7, "MODULE-IDENTITY", "", ""},
+			{"qBridgeMIBObjects", "qBridgeMIB", 1, "", "", ""},
+			{"dot1qBase", "qBridgeMIBObjects", 1, "", "", ""},
+			{"dot1qVlanVersionNumber", "dot1qBase", 1, "INTEGER { version1(1) }", mibRO, ""},
+			{"dot1qMaxVlanId", "dot1qBase", 2, "VlanId", mibRO, ""},
+			{"dot1qMaxSupportedVlans", "dot1qBase", 3, "Unsigned32", mibRO, ""},
+			{"dot1qNumVlans", "dot1qBase", 4, "Unsigned32", mibRO, ""},
+			{"dot1qTp", "qBridgeMIBObjects", 2, "", "", ""},
+			{"dot1qFdbTable", "dot1qTp", 1, "SEQUENCE OF Dot1qFdbEntry", mibNA, ""},
+			{"dot1qFdbEntry", "dot1qFdbTable", 1, "Dot1qFdbEntry", mibNA, "dot1qFdbId"},
+			{"dot1qFdbId", "dot1qFdbEntry", 1, "Unsigned32", mibNA, ""},
+			{"dot1qFdbDynamicCount", "dot1qFdbEntry", 2, "Counter32", mibRO, ""},
+			{"dot1qTpFdbTable", "dot1qTp", 2, "SEQUENCE OF Dot1qTpFdbEntry", mibNA, ""},
+			{"dot1qTpFdbEntry", "dot1qTpFdbTable", 1, "Dot1qTpFdbEntry", mibNA, "dot1qFdbId dot1qTpFdbAddress"},
+			{"dot1qTpFdbAddress", "dot1qTpFdbEntry", 1, "MacAddress", mibNA, ""},
+			{"dot1qTpFdbPort", "dot1qTpFdbEntry", 2, "Integer32 (0..65535)", mibRO, ""},
+			{"dot1qTpFdbStatus", "dot1qTpFdbEntry", 3, "INTEGER { other(1), invalid(2), learned(3), self(4), mgmt(5) }", mibRO, ""},
+			{"dot1qVlan", "qBridgeMIBObjects", 4, "", "", ""},
+			{"dot1qVlanCurrentTable", "dot1qVlan", 2, "SEQUENCE OF Dot1qVlanCurrentEntry", mibNA, ""},
+			{"dot1qVlanCurrentEntry", "dot1qVlanCurrentTable", 1, "Dot1qVlanCurrentEntry", mibNA, "dot1qVlanTimeMark dot1qVlanIndex"},
+			{"dot1qVlanTimeMark", "dot1qVlanCurrentEntry", 1, "TimeTicks", mibNA, ""},
+			{"dot1qVlanIndex", "dot1qVlanCurrentEntry", 2, "VlanIndex", mibNA, ""},
+			{"dot1qVlanFdbId", "dot1qVlanCurrentEntry", 3, "Unsigned32", mibRO, ""},
+			{"dot1qVlanCurrentEgressPorts", "dot1qVlanCurrentEntry", 4, "PortList", mibRO, ""},
+			{"dot1qVlanCurrentUntaggedPorts", "dot1qVlanCurrentEntry", 5, "PortList", mibRO, ""},
+			{"dot1qVlanStatus", "dot1qVlanCurrentEntry", 6, "INTEGER { other(1), permanent(2), dynamicGvrp(3) }", mibRO, ""},
+			{"dot1qVlanCreationTime", "dot1qVlanCurrentEntry", 7, "TimeTicks", mibRO, ""},
+			{"dot1qVlanStaticTable", "dot1qVlan", 3, "SEQUENCE OF Dot1qVlanStaticEntry", mibNA, ""},
+			{"dot1qVlanStaticEntry", "dot1qVlanStaticTable", 1, "Dot1qVlanStaticEntry", mibNA, "dot1qVlanIndex"},
+			{"dot1qVlanStaticName", "dot1qVlanStaticEntry", 1, "SnmpAdminString (SIZE (0..32))", mibRC, ""},
+			{"dot1qVlanStaticEgressPorts", "dot1qVlanStaticEntry", 2, "PortList", mibRC, ""},
+			{"dot1qVlanForbiddenEgressPorts", "dot1qVlanStaticEntry", 3, "PortList", mibRC, ""},
+			{"dot1qVlanStaticUntaggedPorts", "dot1qVlanStaticEntry", 4, "PortList", mibRC, ""},
+			{"dot1qVlanStaticRowStatus", "dot1qVlanStaticEntry", 5, "RowStatus", mibRC, ""},
+			{"dot1qPortVlanTable", "dot1qVlan", 5, "SEQUENCE OF Dot1qPortVlanEntry", mibNA, ""},
+			{"dot1qPortVlanEntry", "dot1qPortVlanTable", 1, "Dot1qPortVlanEntry", mibNA, "AUGMENTS dot1dBasePortEntry"},
+			{"dot1qPvid", "dot1qPortVlanEntry", 1, "VlanIndex", mibRW, ""},
+		},
+	},
+	{
+		name: "IANA-ADDRESS-FAMILY-NUMBERS-MIB",
+		types: []builtinType{
+			{"AddressFamilyNumbers", "INTEGER { other(0), ipV4(1), ipV6(2), nsap(3), hdlc(4), bbn1822(5), all802(6), e163(7), e164(8), f69(9), x121(10), ipx(11), appleTalk(12), decnetIV(13), banyanVines(14), e164withNsap(15), dns(16), distinguishedName(17), asNumber(18) }", ""},
+		},
+	},
+	{
+		name: "RMON2-MIB",
+		types: []builtinType{
+			{"TimeFilter", "TimeTicks", ""},
+		},
+	},
+	{
+		name: "LLDP-MIB",
+		types: []builtinType{
+			{"LldpChassisIdSubtype", "INTEGER { chassisComponent(1), interfaceAlias(2), portComponent(3), macAddress(4), networkAddress(5), interfaceName(6), local(7) }", ""},
+			{"LldpChassisId", "OCTET STRING (SIZE (1..255))", ""},
+			{"LldpPortIdSubtype", "INTEGER { interfaceAlias(1), portComponent(2), macAddress(3), networkAddress(4), interfaceName(5), agentCircuitId(6), local(7) }", ""},
+			{"LldpPortId", "OCTET STRING (SIZE (1..255))", ""},
+			{"LldpManAddrIfSubtype", "INTEGER { unknown(1), ifIndex(2), systemPortNumber(3) }", ""},
+			{"LldpManAddress", "OCTET STRING (SIZE (1..31))", ""},
+			{"LldpSystemCapabilitiesMap", "BITS { other(0), repeater(1), bridge(2), wlanAccessPoint(3), router(4), telephone(5), docsisCableDevice(6), stationOnly(7) }", ""},
+			{"LldpPortNumber", "Integer32 (1..4096)", ""},
+			{"LldpPortList", "OCTET STRING (SIZE (0..512))", ""},
+		},
+		objects: []builtinObject{
+			{"std", "iso", 0, "", "", ""},
+			{"iso8802", "std", 8802, "", "", ""},
+			{"ieee802dot1", "iso8802", 1, "", "", ""},
+			{"ieee802dot1mibs", "ieee802dot1", 1, "", "", ""},
+			{"lldpMIB", "ieee802dot1mibs", 2, "MODULE-IDENTITY", "", ""},
+			{"lldpObjects", "lldpMIB", 1, "", "", ""},
+			{"lldpLocalSystemData", "lldpObjects", 3, "", "", ""},
+			{"lldpLocChassisIdSubtype", "lldpLocalSystemData", 1, "LldpChassisIdSubtype", mibRO, ""},
+			{"lldpLocChassisId", "lldpLocalSystemData", 2, "LldpChassisId", mibRO, ""},
+			{"lldpLocSysName", "lldpLocalSystemData", 3, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpLocSysDesc", "lldpLocalSystemData", 4, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpLocSysCapSupported", "lldpLocalSystemData", 5, "LldpSystemCapabilitiesMap", mibRO, ""},
+			{"lldpLocSysCapEnabled", "lldpLocalSystemData", 6, "LldpSystemCapabilitiesMap", mibRO, ""},
+			{"lldpLocPortTable", "lldpLocalSystemData", 7, "SEQUENCE OF LldpLocPortEntry", mibNA, ""},
+			{"lldpLocPortEntry", "lldpLocPortTable", 1, "LldpLocPortEntry", mibNA, "lldpLocPortNum"},
+			{"lldpLocPortNum", "lldpLocPortEntry", 1, "LldpPortNumber", mibNA, ""},
+			{"lldpLocPortIdSubtype", "lldpLocPortEntry", 2, "LldpPortIdSubtype", mibRO, ""},
+			{"lldpLocPortId", "lldpLocPortEntry", 3, "LldpPortId", mibRO, ""},
+			{"lldpLocPortDesc", "lldpLocPortEntry", 4, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpLocManAddrTable", "lldpLocalSystemData", 8, "SEQUENCE OF LldpLocManAddrEntry", mibNA, ""},
+			{"lldpLocManAddrEntry", "lldpLocManAddrTable", 1, "LldpLocManAddrEntry", mibNA, "lldpLocManAddrSubtype lldpLocManAddr"},
+			{"lldpLocManAddrSubtype", "lldpLocManAddrEntry", 1, "AddressFamilyNumbers", mibNA, ""},
+			{"lldpLocManAddr", "lldpLocManAddrEntry", 2, "LldpManAddress", mibNA, ""},
+			{"lldpLocManAddrLen", "lldpLocManAddrEntry", 3, "Integer32", mibRO, ""},
+			{"lldpLocManAddrIfSubtype", "lldpLocManAddrEntry", 4, "LldpManAddrIfSubtype", mibRO, ""},
+			{"lldpLocManAddrIfId", "lldpLocManAddrEntry", 5, "Integer32", mibRO, ""},
+			{"lldpLocManAddrOID", "lldpLocManAddrEntry", 6, "OBJECT IDENTIFIER", mibRO, ""},
+			{"lldpRemoteSystemsData", "lldpObjects", 4, "", "", ""},
+			{"lldpRemTable", "lldpRemoteSystemsData", 1, "SEQUENCE OF LldpRemEntry", mibNA, ""},
+			{"lldpRemEntry", "lldpRemTable", 1, "LldpRemEntry", mibNA, "lldpRemTimeMark lldpRemLocalPortNum lldpRemIndex"},
+			{"lldpRemTimeMark", "lldpRemEntry", 1, "TimeFilter", mibNA, ""},
+			{"lldpRemLocalPortNum", "lldpRemEntry", 2, "LldpPortNumber", mibNA, ""},
+			{"lldpRemIndex", "lldpRemEntry", 3, "Integer32 (1..2147483647)", mibNA, ""},
+			{"lldpRemChassisIdSubtype", "lldpRemEntry", 4, "LldpChassisIdSubtype", mibRO, ""},
+			{"lldpRemChassisId", "lldpRemEntry", 5, "LldpChassisId", mibRO, ""},
+			{"lldpRemPortIdSubtype", "lldpRemEntry", 6, "LldpPortIdSubtype", mibRO, ""},
+			{"lldpRemPortId", "lldpRemEntry", 7, "LldpPortId", mibRO, ""},
+			{"lldpRemPortDesc", "lldpRemEntry", 8, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpRemSysName", "lldpRemEntry", 9, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpRemSysDesc", "lldpRemEntry", 10, "SnmpAdminString (SIZE (0..255))", mibRO, ""},
+			{"lldpRemSysCapSupported", "lldpRemEntry", 11, "LldpSystemCapabilitiesMap", mibRO, ""},
+			{"lldpRemSysCapEnabled", "lldpRemEntry", 12, "LldpSystemCapabilitiesMap", mibRO, ""},
+			{"lldpRemManAddrTable", "lldpRemoteSystemsData", 2, "SEQUENCE OF LldpRemManAddrEntry", mibNA, ""},
+			{"lldpRemManAddrEntry", "lldpRemManAddrTable", 1, "LldpRemManAddrEntry", mibNA, "lldpRemTimeMark lldpRemLocalPortNum lldpRemIndex lldpRemManAddrSubtype lldpRemManAddr"},
+			{"lldpRemManAddrSubtype", "lldpRemManAddrEntry", 1, "AddressFamilyNumbers", mibNA, ""},
+			{"lldpRemManAddr", "lldpRemManAddrEntry", 2, "LldpManAddress", mibNA, ""},
+			{"lldpRemManAddrIfSubtype", "lldpRemManAddrEntry", 3, "LldpManAddrIfSubtype", mibRO, ""},
+			{"lldpRemManAddrIfId", "lldpRemManAddrEntry", 4, "Integer32", mibRO, ""},
+			{"lldpRemManAddrOID", "lldpRemManAddrEntry", 5, "OBJECT IDENTIFIER", mibRO, ""},
+		},
+	},
+}
@@ -0,0 +1,277 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Resolve turns a name such as "IF-MIB::ifDescr.3", "sysUpTime.0" or
+// "ifTable" into an OID. Numeric OIDs are accepted as they are.
+//
+// The instance suffix is either numeric or written the way Format renders
+// it, with each index component in the form matching its syntax: an integer,
+// a dotted IpAddress, a MacAddress such as 00:1b:21:ff:80:01 or a quoted
+// string such as "public" for octet strings and InetAddresses.
+func (t *MIBTree) Resolve(name string) (OID, error) {
+	if oid, err := ParseOID(name); err == nil {
+		return oid, nil
+	}
+
+	object, suffix := name, ""
+	start := 0
+	if i := strings.Index(name, "::"); i >= 0 {
+		start = i + 2
+	}
+	if i := strings.IndexByte(name[start:], '.'); i >= 0 {
+		object, suffix = name[:start+i], name[start+i+1:]
+	}
+
+	node, err := t.Lookup(object)
+	if err != nil {
+		return nil, err
+	}
+	if suffix == "" {
+		return node.OID.Copy(), nil
+	}
+
+	arcs, err := parseSuffix(node, suffix)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to resolve %q: %s", name, err.Error())
+	}
+	return node.OID.Append(arcs...), nil
+}
+
+// Format renders an OID symbolically as MODULE::name followed by its
+// instance suffix, formatted according to the index of the table the object
+// belongs to. OIDs outside the known tree are returned in numeric form.
+func (t *MIBTree) Format(oid OID) string {
+	node, suffix := t.Longest(oid)
+	if node == nil {
+		return oid.String()
+	}
+	if len(suffix) == 0 {
+		return node.String()
+	}
+	return node.String() + "." + formatSuffix(node, suffix)
+}
+
+// FormatName renders an SnmpPDU.Name symbolically. Names that cannot be
+// parsed are returned unchanged.
+func (t *MIBTree) FormatName(name string) string {
+	oid, err := ParseOID(name)
+	if err != nil {
+		return name
+	}
+	return t.Format(oid)
+}
+
+// formatSuffix renders an instance suffix by index component, falling back
+// to numeric arcs if it does not match the index of the node
+func formatSuffix(node *MIBNode, suffix OID) string {
+	numeric := suffix.String()[1:]
+	if node.Kind != MIBObjectType {
+		return numeric
+	}
+	spec, err := node.IndexSpec()
+	if err != nil {
+		return numeric
+	}
+
+	var parts []string
+	rest := suffix
+	for _, c := range spec {
+		value, remaining, err := c.decode(rest)
+		if err != nil {
+			return numeric
+		}
+		arcs := rest[:len(rest)-len(remaining)]
+		rest = remaining
+
+		switch v := value.(type) {
+		case uint32:
+			parts = append(parts, strconv.FormatUint(uint64(v), 10))
+		case net.HardwareAddr:
+			parts = append(parts, v.String())
+		case []byte:
+			if isPrintable(v) {
+				parts = append(parts, strconv.Quote(string(v)))
+				continue
+			}
+			parts = append(parts, arcs.String()[1:])
+		case InetAddress:
+			if ip := v.IP(); ip != nil && v.Type != InetIPv4z && v.Type != InetIPv6z {
+				parts = append(parts, strconv.Quote(ip.String()))
+			} else if v.Type == InetDNS && isPrintable(v.Address) {
+				parts = append(parts, strconv.Quote(string(v.Address)))
+			} else {
+				parts = append(parts, arcs.String()[1:])
+			}
+		default:
+			// IpAddress and OID components read naturally as arcs
+			parts = append(parts, arcs.String()[1:])
+		}
+	}
+	if len(rest) > 0 {
+		return numeric
+	}
+	return strings.Join(parts, ".")
+}
+
+// parseSuffix parses an instance suffix written by formatSuffix, or in
+// numeric form, into arcs
+func parseSuffix(node *MIBNode, suffix string) (OID, error) {
+	tokens, err := splitSuffix(suffix)
+	if err != nil {
+		return nil, err
+	}
+	numeric := true
+	for _, tok := range tokens {
+		if _, err := strconv.ParseUint(tok, 10, 32); err != nil {
+			numeric = false
+		}
+	}
+	if numeric {
+		return ParseOID(suffix)
+	}
+
+	spec, err := node.IndexSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	var arcs OID
+	for i, c := range spec {
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("missing index component %d", i+1)
+		}
+
+		// Numeric components take as many arcs as their syntax requires
+		var run OID
+		for _, tok := range tokens {
+			n, err := strconv.ParseUint(tok, 10, 32)
+			if err != nil {
+				break
+			}
+			run = append(run, uint32(n))
+		}
+		if len(run) > 0 {
+			_, rest, err := c.decode(run)
+			if err != nil {
+				return nil, fmt.Errorf("index component %d: %s", i+1, err.Error())
+			}
+			used := len(run) - len(rest)
+			arcs = append(arcs, run[:used]...)
+			tokens = tokens[used:]
+			continue
+		}
+
+		value, err := parseIndexValue(c, tokens[0])
+		if err != nil {
+			return nil, fmt.Errorf("index component %d: %s", i+1, err.Error())
+		}
+		encoded, err := IndexSpec{c}.Encode(value)
+		if err != nil {
+			return nil, err
+		}
+		arcs = append(arcs, encoded...)
+		tokens = tokens[1:]
+	}
+	if len(tokens) > 0 {
+		return nil, fmt.Errorf("%d trailing index components", len(tokens))
+	}
+	return arcs, nil
+}
+
+// parseIndexValue parses a non-numeric index component: a quoted string or
+// a MAC address
+func parseIndexValue(c IndexComponent, tok string) (interface{}, error) {
+	if strings.HasPrefix(tok, "\"") {
+		s, err := strconv.Unquote(tok)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", tok)
+		}
+		switch c.Kind {
+		case IndexOctetString:
+			return []byte(s), nil
+		case IndexInetAddress:
+			if ip := net.ParseIP(s); ip != nil {
+				return ip, nil
+			}
+			return InetAddress{InetDNS, []byte(s)}, nil
+		}
+	} else if c.Kind == IndexMACAddress || c.Kind == IndexOctetString {
+		octets, err := parseHexOctets(tok)
+		if err != nil || c.Kind == IndexMACAddress && len(octets) != 6 {
+			return nil, fmt.Errorf("invalid hex octets %s", tok)
+		}
+		if c.Kind == IndexOctetString {
+			return octets, nil
+		}
+		return net.HardwareAddr(octets), nil
+	}
+	return nil, fmt.Errorf("unexpected %s", tok)
+}
+
+// parseHexOctets parses colon separated hex octets such as 0:1b:21, with
+// one or two digits each
+func parseHexOctets(s string) ([]byte, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("no colon")
+	}
+	octets := make([]byte, len(parts))
+	for i, part := range parts {
+		if len(part) < 1 || len(part) > 2 {
+			return nil, fmt.Errorf("invalid octet %q", part)
+		}
+		n, err := strconv.ParseUint(part, 16, 8)
+		if err != nil {
+			return nil, err
+		}
+		octets[i] = byte(n)
+	}
+	return octets, nil
+}
+
+// splitSuffix splits an instance suffix at its dots, keeping quoted strings
+// intact
+func splitSuffix(s string) ([]string, error) {
+	var tokens []string
+	for len(s) > 0 {
+		end := strings.IndexByte(s, '.')
+		if s[0] == '"' {
+			end = 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string in %s", s)
+			}
+			end++
+			if end < len(s) && s[end] != '.' {
+				return nil, fmt.Errorf("unexpected %q after string", s[end])
+			}
+		}
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("empty index component")
+		}
+		tokens = append(tokens, s[:end])
+		if end < len(s) {
+			end++
+		}
+		s = s[end:]
+	}
+	return tokens, nil
+}
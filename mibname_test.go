@@ -0,0 +1,144 @@
+package gosnmp
+
+import (
+	"reflect"
+	"testing"
+)
+
+var mibNameTests = []struct {
+	name string
+	oid  string
+	// formatted is the canonical form Format renders the OID in
+	formatted string
+}{
+	{"testName.0", ".1.3.6.1.2.1.9999.1.1.0", "TEST-MIB::testName.0"},
+	{"TEST-MIB::testTable", ".1.3.6.1.2.1.9999.1.2", "TEST-MIB::testTable"},
+	{".1.3.6.1.2.1.9999.1.2.1.4", ".1.3.6.1.2.1.9999.1.2.1.4", "TEST-MIB::testStatus"},
+	{
+		`testStatus.7.00:1b:21:ff:80:01."eth0"`,
+		".1.3.6.1.2.1.9999.1.2.1.4.7.0.27.33.255.128.1.101.116.104.48",
+		`TEST-MIB::testStatus.7.00:1b:21:ff:80:01."eth0"`,
+	},
+	{
+		// Numeric suffixes are decoded by index component
+		"testStatus.7.0.27.33.255.128.1.101.116.104.48",
+		".1.3.6.1.2.1.9999.1.2.1.4.7.0.27.33.255.128.1.101.116.104.48",
+		`TEST-MIB::testStatus.7.00:1b:21:ff:80:01."eth0"`,
+	},
+	{
+		// Quoted strings may hold dots
+		`TEST-MIB::testOctets.1.0:0:0:0:0:1."a.b"`,
+		".1.3.6.1.2.1.9999.1.2.1.5.1.0.0.0.0.0.1.97.46.98",
+		`TEST-MIB::testOctets.1.00:00:00:00:00:01."a.b"`,
+	},
+	{
+		// Strings that are not printable are left numeric
+		"testStatus.7.0.27.33.255.128.1.1.2",
+		".1.3.6.1.2.1.9999.1.2.1.4.7.0.27.33.255.128.1.1.2",
+		"TEST-MIB::testStatus.7.00:1b:21:ff:80:01.1.2",
+	},
+	{"acmeTemp.0", ".1.3.6.1.4.1.99998.1.0", "TEST-V1-MIB::acmeTemp.0"},
+	{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.1.0"},
+}
+
+// Test resolving names to OIDs and formatting OIDs as names
+func TestMIBNames(t *testing.T) {
+	tree := loadTestMIBs(t)
+
+	for _, test := range mibNameTests {
+		oid, err := tree.Resolve(test.name)
+		if err != nil {
+			t.Errorf("Resolve %s: %s", test.name, err)
+			continue
+		}
+		if oid.String() != test.oid {
+			t.Errorf("Resolve %s:\n\twant: %s\n\tgot : %s", test.name, test.oid, oid)
+		}
+		if formatted := tree.Format(oid); formatted != test.formatted {
+			t.Errorf("Format %s:\n\twant: %s\n\tgot : %s", test.oid, test.formatted, formatted)
+		}
+	}
+
+	for _, name := range []string{"noSuchObject", "testStatus.7.x", `testStatus.7."unterminated`, "testStatus.7.1:2:3.\"x\"", "testName.0.\"x\""} {
+		if oid, err := tree.Resolve(name); err == nil {
+			t.Errorf("Expected error resolving %s, got %s", name, oid)
+		}
+	}
+
+	if name := tree.FormatName(".1.3.6.1.2.1.9999.1.2.1.6.1.0.0.0.0.0.1.120"); name != `TEST-MIB::testFlags.1.00:00:00:00:00:01."x"` {
+		t.Errorf("FormatName: %s", name)
+	}
+}
+
+// Test requests and walks given symbolic names
+func TestSymbolicRequests(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.9999.1.1.0":                         OctetStringValue("test"),
+		".1.3.6.1.2.1.9999.1.2.1.4.1.0.0.0.0.0.1.97":      Integer32Value(1),
+		".1.3.6.1.2.1.9999.1.2.1.4.2.0.0.0.0.0.2.98":      Integer32Value(2),
+		".1.3.6.1.2.1.9999.1.2.1.5.1.0.0.0.0.0.1.97":      Counter32Value(10),
+		".1.3.6.1.2.1.9999.1.2.1.5.2.0.0.0.0.0.2.98":      Counter32Value(20),
+		".1.3.6.1.4.1.99998.1.0":                          Integer32Value(40),
+		".1.3.6.1.2.1.9999.1.2.1.6.1.0.0.0.0.0.1.97.1000": Integer32Value(0),
+	})
+	s := agent.client()
+	s.MIBs = loadTestMIBs(t)
+
+	res, err := s.GetMulti([]string{"testName.0", "TEST-V1-MIB::acmeTemp.0"})
+	if err != nil {
+		t.Fatalf("GetMulti: %s", err)
+	}
+	if len(res.Variables) != 2 || res.Variables[0].Value != "test" || res.Variables[1].Value != 40 {
+		t.Errorf("GetMulti: %v", res.Variables)
+	}
+
+	res, err = s.Get(`testStatus.2.00:00:00:00:00:02."b"`)
+	if err != nil || res.Variables[0].Value != 2 {
+		t.Errorf("Get by index: %v %v", res, err)
+	}
+	if name := s.MIBs.FormatName(res.Variables[0].Name); name != `TEST-MIB::testStatus.2.00:00:00:00:00:02."b"` {
+		t.Errorf("Response name: %s", name)
+	}
+
+	pdus, err := s.Walk("testStatus")
+	if err != nil || len(pdus) != 2 {
+		t.Errorf("Walk: %v %v", pdus, err)
+	}
+	pdus, err = s.BulkWalk(10, "TEST-MIB::testEntry")
+	var names []string
+	for _, pdu := range pdus {
+		names = append(names, s.MIBs.FormatName(pdu.Name))
+	}
+	want := []string{
+		`TEST-MIB::testStatus.1.00:00:00:00:00:01."a"`,
+		`TEST-MIB::testStatus.2.00:00:00:00:00:02."b"`,
+		`TEST-MIB::testOctets.1.00:00:00:00:00:01."a"`,
+		`TEST-MIB::testOctets.2.00:00:00:00:00:02."b"`,
+		// The trailing arc does not fit the index
+		"TEST-MIB::testFlags.1.0.0.0.0.0.1.97.1000",
+	}
+	if err != nil || !reflect.DeepEqual(names, want) {
+		t.Errorf("BulkWalk:\n\twant: %v\n\tgot : %v (%v)", want, names, err)
+	}
+
+	// Checkpoints resolve names with the MIBs of the client too
+	cp, err := s.NewWalkCheckpoint("testStatus", WalkGetBulk, 10)
+	if err != nil || cp.Root.String() != ".1.3.6.1.2.1.9999.1.2.1.4" {
+		t.Errorf("NewWalkCheckpoint: %+v %v", cp, err)
+	}
+
+	if _, err := s.Get("noSuchObject.0"); err == nil {
+		t.Errorf("Expected error for unknown name")
+	}
+
+	res, err = s.Set("testName.0", OctetStringValue("changed"))
+	if err != nil || SnmpError(res.Error) != NoError {
+		t.Fatalf("Set: %v %v", res, err)
+	}
+	if res, err := s.Get("testName.0"); err != nil || res.Variables[0].Value != "changed" {
+		t.Errorf("Get after Set: %v %v", res, err)
+	}
+	if res, err := s.Set("acmeTemp.1", Integer32Value(1)); err != nil || SnmpError(res.Error) != NoCreation {
+		t.Errorf("Set of missing variable: %v %v", res, err)
+	}
+}
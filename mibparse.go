@@ -0,0 +1,914 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// mibModule holds the definitions of one loaded MIB module
+type mibModule struct {
+	name string
+	// imports maps imported symbols to the module they are imported from
+	imports map[string]string
+	defs    map[string]*mibDef
+	types   map[string]*mibType
+	// order holds the defined names in order of appearance
+	order []string
+}
+
+// mibDef is a value assignment defining a node, such as an OBJECT-TYPE
+type mibDef struct {
+	name   string
+	module *mibModule
+	// node is the parsed definition, replaced by the node in the tree once
+	// the OID is resolved
+	node  *MIBNode
+	value []oidComponent
+}
+
+// mibType is a type assignment, such as a TEXTUAL-CONVENTION or SEQUENCE
+type mibType struct {
+	syntax *MIBSyntax
+	hint   string
+	tc     bool
+}
+
+// oidComponent is one component of an OID value, such as mib-2, 2 or org(3)
+type oidComponent struct {
+	name     string
+	number   uint32
+	numbered bool
+}
+
+// smiRoots are the well-known OIDs of the SMI, used to resolve modules
+// importing them from SNMPv2-SMI or RFC1155-SMI when those are not loaded
+var smiRoots = map[string]OID{
+	"ccitt":           {0},
+	"zeroDotZero":     {0, 0},
+	"iso":             {1},
+	"org":             {1, 3},
+	"dod":             {1, 3, 6},
+	"internet":        {1, 3, 6, 1},
+	"directory":       {1, 3, 6, 1, 1},
+	"mgmt":            {1, 3, 6, 1, 2},
+	"mib-2":           {1, 3, 6, 1, 2, 1},
+	"transmission":    {1, 3, 6, 1, 2, 1, 10},
+	"experimental":    {1, 3, 6, 1, 3},
+	"private":         {1, 3, 6, 1, 4},
+	"enterprises":     {1, 3, 6, 1, 4, 1},
+	"security":        {1, 3, 6, 1, 5},
+	"snmpV2":          {1, 3, 6, 1, 6},
+	"snmpDomains":     {1, 3, 6, 1, 6, 1},
+	"snmpProxys":      {1, 3, 6, 1, 6, 2},
+	"snmpModules":     {1, 3, 6, 1, 6, 3},
+	"joint-iso-ccitt": {2},
+}
+
+// smiBaseTypes are the types a syntax ultimately resolves to
+var smiBaseTypes = map[string]bool{
+	"INTEGER":           true,
+	"OCTET STRING":      true,
+	"OBJECT IDENTIFIER": true,
+	"BITS":              true,
+	"SEQUENCE":          true,
+	"Integer32":         true,
+	"Unsigned32":        true,
+	"Counter32":         true,
+	"Counter64":         true,
+	"Gauge32":           true,
+	"TimeTicks":         true,
+	"IpAddress":         true,
+	"Opaque":            true,
+	"Counter":           true,
+	"Gauge":             true,
+	"NetworkAddress":    true,
+}
+
+var mibMacroKinds = map[string]MIBNodeKind{
+	"OBJECT-TYPE":        MIBObjectType,
+	"MODULE-IDENTITY":    MIBModuleIdentity,
+	"OBJECT-IDENTITY":    MIBObjectIdentity,
+	"NOTIFICATION-TYPE":  MIBNotificationType,
+	"TRAP-TYPE":          MIBTrapType,
+	"OBJECT-GROUP":       MIBObjectGroup,
+	"NOTIFICATION-GROUP": MIBNotificationGroup,
+	"MODULE-COMPLIANCE":  MIBModuleCompliance,
+	"AGENT-CAPABILITIES": MIBAgentCapabilities,
+}
+
+// LoadDir loads the MIB modules of all files in a directory, resolving the
+// imports between them. Files that do not contain a module are ignored.
+// Modules that fail to parse are reported in the error, but the others are
+// still loaded.
+func (t *MIBTree) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("Unable to read MIB directory: %s", err.Error())
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	return t.LoadFiles(paths...)
+}
+
+// LoadFiles loads the MIB modules of the given files. Modules that import
+// from each other should be loaded in the same call, or in dependency order.
+func (t *MIBTree) LoadFiles(paths ...string) error {
+	var problems []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if !bytes.Contains(data, []byte("DEFINITIONS")) {
+			continue
+		}
+		if err := t.parse(path, data); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return t.finish(problems)
+}
+
+// Load loads the MIB modules read from r. The name is used in errors.
+func (t *MIBTree) Load(name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Unable to read MIB %s: %s", name, err.Error())
+	}
+	var problems []string
+	if err := t.parse(name, data); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return t.finish(problems)
+}
+
+// finish resolves what was loaded and reports all problems as one error
+func (t *MIBTree) finish(problems []string) error {
+	problems = append(problems, t.resolve()...)
+	if len(problems) > 0 {
+		return fmt.Errorf("Unable to load MIBs: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// parse parses the modules of a file and adds them to the tree
+func (t *MIBTree) parse(name string, data []byte) error {
+	toks, err := lexMIB(data)
+	if err != nil {
+		return fmt.Errorf("%s: %s", name, err.Error())
+	}
+
+	p := &mibParser{toks: toks}
+	for p.peek().kind != mibEOF {
+		m, err := p.parseModule()
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err.Error())
+		}
+		t.add(m)
+	}
+	return nil
+}
+
+// add adds a module to the tree, leaving its definitions to be resolved. A
+// module that is already loaded is skipped.
+func (t *MIBTree) add(m *mibModule) {
+	if _, ok := t.modules[m.name]; ok {
+		return
+	}
+	t.modules[m.name] = m
+	t.order = append(t.order, m.name)
+	for _, n := range m.order {
+		t.pending = append(t.pending, m.defs[n])
+	}
+}
+
+// resolve places the pending definitions whose OID can be resolved in the
+// tree, then resolves the syntaxes of all objects. It returns a description
+// of the definitions that remain unresolved.
+func (t *MIBTree) resolve() []string {
+	for progress := true; progress; {
+		progress = false
+		var pending []*mibDef
+		for _, def := range t.pending {
+			if oid, ok := t.resolveOID(def); ok {
+				t.insert(def, oid)
+				progress = true
+			} else {
+				pending = append(pending, def)
+			}
+		}
+		t.pending = pending
+	}
+
+	for _, name := range t.order {
+		m := t.modules[name]
+		for _, n := range m.order {
+			if node := m.defs[n].node; node.Module == m.name && node.Syntax != nil {
+				t.resolveSyntax(m, node.Syntax)
+			}
+		}
+	}
+
+	var problems []string
+	for i, def := range t.pending {
+		if i == 10 {
+			problems = append(problems, fmt.Sprintf("and %d more", len(t.pending)-i))
+			break
+		}
+		problems = append(problems, fmt.Sprintf("Unable to resolve the OID of %s::%s", def.module.name, def.name))
+	}
+	return problems
+}
+
+// resolveOID computes the OID of a definition from its value, if the node it
+// is relative to is known
+func (t *MIBTree) resolveOID(def *mibDef) (OID, bool) {
+	if len(def.value) == 0 {
+		return nil, false
+	}
+
+	var oid OID
+	if first := def.value[0]; first.numbered {
+		oid = OID{first.number}
+	} else if base, ok := t.resolveName(def.module, first.name); ok {
+		oid = base.Copy()
+	} else {
+		return nil, false
+	}
+
+	for _, c := range def.value[1:] {
+		if !c.numbered {
+			return nil, false
+		}
+		oid = append(oid, c.number)
+	}
+	return oid, true
+}
+
+// resolveName finds the OID of a name as seen from a module
+func (t *MIBTree) resolveName(m *mibModule, name string) (OID, bool) {
+	if def, ok := m.defs[name]; ok {
+		return def.node.OID, def.node.tree != nil
+	}
+	if from, ok := m.imports[name]; ok {
+		if fm, ok := t.modules[from]; ok {
+			if def, ok := fm.defs[name]; ok {
+				return def.node.OID, def.node.tree != nil
+			}
+		}
+		if t.base != nil {
+			if n, err := t.base.Lookup(from + "::" + name); err == nil {
+				return n.OID, true
+			}
+		}
+	}
+	oid, ok := smiRoots[name]
+	return oid, ok
+}
+
+// resolveSyntax follows the chain of types a syntax refers to, filling in
+// its base type, textual convention, display hint, enumerations and size
+func (t *MIBTree) resolveSyntax(m *mibModule, s *MIBSyntax) {
+	s.Base, s.TC, s.DisplayHint = "", "", ""
+	name := s.Type
+
+	// Bound the chain in case of circular type definitions
+	for depth := 0; depth < 16; depth++ {
+		if strings.HasPrefix(name, "SEQUENCE OF ") {
+			s.Base = "SEQUENCE OF"
+			return
+		}
+		if smiBaseTypes[name] {
+			s.Base = name
+			return
+		}
+
+		typ, tm := t.findType(m, name)
+		if typ == nil {
+			return
+		}
+		if typ.tc && s.TC == "" {
+			s.TC = name
+		}
+		if s.DisplayHint == "" {
+			s.DisplayHint = typ.hint
+		}
+		if len(s.Enums) == 0 {
+			s.Enums = typ.syntax.Enums
+		}
+		if s.Size == 0 {
+			s.Size = typ.syntax.Size
+		}
+		name, m = typ.syntax.Type, tm
+	}
+}
+
+// findType finds a type as seen from a module: defined in it, imported by it
+// or, as SMIv1 modules do not always import their types, in any module
+func (t *MIBTree) findType(m *mibModule, name string) (*mibType, *mibModule) {
+	if typ, ok := m.types[name]; ok {
+		return typ, m
+	}
+	for tree := t; tree != nil; tree = tree.base {
+		if from, ok := m.imports[name]; ok {
+			if fm, ok := tree.modules[from]; ok {
+				if typ, ok := fm.types[name]; ok {
+					return typ, fm
+				}
+			}
+		}
+		for _, mn := range tree.order {
+			if typ, ok := tree.modules[mn].types[name]; ok {
+				return typ, tree.modules[mn]
+			}
+		}
+	}
+	return nil, nil
+}
+
+type mibTokenKind uint8
+
+const (
+	mibEOF mibTokenKind = iota
+	mibIdent
+	mibNumber
+	mibString
+	mibSymbol
+)
+
+type mibToken struct {
+	kind mibTokenKind
+	text string
+	line int
+}
+
+// lexMIB splits a MIB file into tokens, dropping comments
+func lexMIB(src []byte) ([]mibToken, error) {
+	var toks []mibToken
+	line := 1
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		start := i
+
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && i+1 < len(src) && src[i+1] == '-':
+			// A comment runs to the end of the line or to the next "--"
+			for i += 2; i < len(src) && src[i] != '\n'; i++ {
+				if src[i] == '-' && i+1 < len(src) && src[i+1] == '-' {
+					i += 2
+					break
+				}
+			}
+		case c == '"':
+			startLine := line
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\n' {
+					line++
+				}
+			}
+			if i == len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", startLine)
+			}
+			i++
+			toks = append(toks, mibToken{mibString, string(src[start+1 : i-1]), startLine})
+		case c == '\'':
+			// Binary and hexadecimal strings, such as '00'H
+			for i++; i < len(src) && src[i] != '\''; i++ {
+			}
+			if i+1 < len(src) {
+				i += 2
+			}
+			toks = append(toks, mibToken{mibString, string(src[start:i]), line})
+		case isMIBLetter(c):
+			for i++; i < len(src) && (isMIBLetter(src[i]) || isMIBDigit(src[i]) || src[i] == '-' || src[i] == '_'); i++ {
+				if src[i] == '-' && i+1 < len(src) && src[i+1] == '-' {
+					break
+				}
+			}
+			toks = append(toks, mibToken{mibIdent, string(src[start:i]), line})
+		case isMIBDigit(c) || c == '-' && i+1 < len(src) && isMIBDigit(src[i+1]):
+			for i++; i < len(src) && isMIBDigit(src[i]); i++ {
+			}
+			toks = append(toks, mibToken{mibNumber, string(src[start:i]), line})
+		case bytes.HasPrefix(src[i:], []byte("::=")):
+			i += 3
+			toks = append(toks, mibToken{mibSymbol, "::=", line})
+		case bytes.HasPrefix(src[i:], []byte("..")):
+			i += 2
+			toks = append(toks, mibToken{mibSymbol, "..", line})
+		default:
+			i++
+			toks = append(toks, mibToken{mibSymbol, string(c), line})
+		}
+	}
+	return toks, nil
+}
+
+func isMIBLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isMIBDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// mibParser parses the tokens of a MIB file. It understands the SMIv1 and
+// SMIv2 macros and skips constructs it has no use for, such as MACRO
+// definitions and DEFVAL clauses.
+type mibParser struct {
+	toks []mibToken
+	pos  int
+}
+
+func (p *mibParser) peek() mibToken {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return mibToken{kind: mibEOF, text: "end of file"}
+}
+
+func (p *mibParser) next() mibToken {
+	tok := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is text
+func (p *mibParser) accept(text string) bool {
+	if tok := p.peek(); tok.kind != mibEOF && tok.kind != mibString && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *mibParser) expect(text string) error {
+	if !p.accept(text) {
+		return p.errorf("expected %q, got %q", text, p.peek().text)
+	}
+	return nil
+}
+
+func (p *mibParser) expectKind(kind mibTokenKind, what string) (string, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return "", p.errorf("expected %s, got %q", what, tok.text)
+	}
+	return tok.text, nil
+}
+
+func (p *mibParser) errorf(format string, args ...interface{}) error {
+	line := p.peek().line
+	if p.pos > 0 && p.pos <= len(p.toks) {
+		line = p.toks[p.pos-1].line
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// skipPast consumes tokens up to and including text
+func (p *mibParser) skipPast(text string) error {
+	for tok := p.next(); tok.kind != mibEOF; tok = p.next() {
+		if tok.kind != mibString && tok.text == text {
+			return nil
+		}
+	}
+	return p.errorf("expected %q before end of file", text)
+}
+
+// skipBalanced consumes a bracketed construct, such as a DEFVAL value or
+// a range constraint
+func (p *mibParser) skipBalanced() error {
+	depth := 0
+	for {
+		tok := p.next()
+		if tok.kind == mibEOF {
+			return p.errorf("unbalanced brackets")
+		}
+		if tok.kind != mibSymbol {
+			continue
+		}
+		switch tok.text {
+		case "{", "(", "[":
+			depth++
+		case "}", ")", "]":
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func (p *mibParser) parseModule() (*mibModule, error) {
+	name, err := p.expectKind(mibIdent, "module name")
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().text == "{" {
+		if err := p.skipBalanced(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect("DEFINITIONS"); err != nil {
+		return nil, err
+	}
+	// Skip tagging defaults, such as IMPLICIT TAGS
+	if err := p.skipPast("::="); err != nil {
+		return nil, err
+	}
+	if err := p.expect("BEGIN"); err != nil {
+		return nil, err
+	}
+
+	m := &mibModule{
+		name:    name,
+		imports: make(map[string]string),
+		defs:    make(map[string]*mibDef),
+		types:   make(map[string]*mibType),
+	}
+	for {
+		var err error
+		switch tok := p.peek(); {
+		case tok.kind == mibEOF:
+			return nil, p.errorf("module %s has no END", name)
+		case p.accept("END"):
+			return m, nil
+		case p.accept("IMPORTS"):
+			err = p.parseImports(m)
+		case p.accept("EXPORTS"):
+			err = p.skipPast(";")
+		default:
+			err = p.parseAssignment(m)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *mibParser) parseImports(m *mibModule) error {
+	var symbols []string
+	for {
+		tok := p.next()
+		switch {
+		case tok.kind == mibEOF:
+			return p.errorf("unterminated IMPORTS")
+		case tok.text == ";":
+			return nil
+		case tok.text == ",":
+		case tok.text == "FROM":
+			from, err := p.expectKind(mibIdent, "module name")
+			if err != nil {
+				return err
+			}
+			for _, s := range symbols {
+				m.imports[s] = from
+			}
+			symbols = nil
+		default:
+			symbols = append(symbols, tok.text)
+		}
+	}
+}
+
+func (p *mibParser) parseAssignment(m *mibModule) error {
+	name, err := p.expectKind(mibIdent, "definition")
+	if err != nil {
+		return err
+	}
+
+	switch tok := p.peek(); {
+	case p.accept("MACRO"):
+		// Macro definitions, found in the SMI modules, describe the syntax
+		// of the macros this parser already knows
+		return p.skipPast("END")
+	case p.accept("::="):
+		return p.parseTypeAssignment(m, name)
+	case p.accept("OBJECT"):
+		if err := p.expect("IDENTIFIER"); err != nil {
+			return err
+		}
+		return p.parseValue(m, name, &MIBNode{Kind: MIBObjectIdentifier})
+	case mibMacroKinds[tok.text] != MIBUnknown:
+		p.next()
+		node := &MIBNode{Kind: mibMacroKinds[tok.text]}
+		_, enterprise, err := p.parseClauses(node, false)
+		if err != nil {
+			return err
+		}
+		if node.Kind != MIBTrapType {
+			return p.parseValue(m, name, node)
+		}
+
+		// SMIv1 traps are numbered under their enterprise (RFC 3584 3.1)
+		if err := p.expect("::="); err != nil {
+			return err
+		}
+		number, err := p.parseNumber()
+		if err != nil {
+			return err
+		}
+		m.define(name, node, []oidComponent{{name: enterprise}, {numbered: true}, {number: number, numbered: true}})
+		return nil
+	}
+
+	// Other value assignments, such as integer constants, are skipped
+	if err := p.skipPast("::="); err != nil {
+		return err
+	}
+	if p.peek().text == "{" {
+		return p.skipBalanced()
+	}
+	p.next()
+	return nil
+}
+
+// parseTypeAssignment parses what follows "Name ::=": a TEXTUAL-CONVENTION,
+// a SEQUENCE or a plain type
+func (p *mibParser) parseTypeAssignment(m *mibModule, name string) error {
+	typ := &mibType{}
+	if p.accept("TEXTUAL-CONVENTION") {
+		typ.tc = true
+		node := &MIBNode{}
+		hint, _, err := p.parseClauses(node, true)
+		if err != nil {
+			return err
+		}
+		if node.Syntax == nil {
+			return p.errorf("textual convention %s has no SYNTAX", name)
+		}
+		typ.syntax, typ.hint = node.Syntax, hint
+	} else {
+		syntax, err := p.parseSyntax()
+		if err != nil {
+			return err
+		}
+		typ.syntax = syntax
+	}
+	m.types[name] = typ
+	return nil
+}
+
+// parseClauses parses the clauses of a macro invocation up to its "::=",
+// returning the DISPLAY-HINT and ENTERPRISE clauses, which are not kept in
+// the node. A TEXTUAL-CONVENTION has no value and ends with its SYNTAX.
+func (p *mibParser) parseClauses(node *MIBNode, tc bool) (hint, enterprise string, err error) {
+	for p.peek().text != "::=" && !(tc && node.Syntax != nil) {
+		tok := p.next()
+		if tok.kind == mibEOF {
+			return "", "", p.errorf("unexpected end of file")
+		}
+
+		// Clauses such as REVISION and the nested ones of compliance
+		// statements repeat keywords; the first occurrence is kept
+		switch tok.text {
+		case "SYNTAX":
+			var syntax *MIBSyntax
+			if syntax, err = p.parseSyntax(); err == nil && node.Syntax == nil {
+				node.Syntax = syntax
+			}
+		case "UNITS":
+			err = p.parseString(&node.Units)
+		case "MAX-ACCESS", "ACCESS":
+			err = p.parseWord(&node.Access)
+		case "STATUS":
+			err = p.parseWord(&node.Status)
+		case "DESCRIPTION":
+			err = p.parseString(&node.Description)
+		case "DISPLAY-HINT":
+			err = p.parseString(&hint)
+		case "ENTERPRISE":
+			err = p.parseWord(&enterprise)
+		case "INDEX":
+			node.Index, node.Implied, err = p.parseNames()
+		case "AUGMENTS":
+			var names []string
+			if names, _, err = p.parseNames(); err == nil && len(names) > 0 {
+				node.Augments = names[0]
+			}
+		case "OBJECTS", "VARIABLES", "NOTIFICATIONS":
+			if node.Objects == nil {
+				node.Objects, _, err = p.parseNames()
+			} else {
+				_, _, err = p.parseNames()
+			}
+		case "{", "(":
+			// The values of skipped clauses, such as DEFVAL
+			p.pos--
+			err = p.skipBalanced()
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return hint, enterprise, nil
+}
+
+func (p *mibParser) parseString(dst *string) error {
+	s, err := p.expectKind(mibString, "string")
+	if err == nil && *dst == "" {
+		*dst = s
+	}
+	return err
+}
+
+func (p *mibParser) parseWord(dst *string) error {
+	s, err := p.expectKind(mibIdent, "identifier")
+	if err == nil && *dst == "" {
+		*dst = s
+	}
+	return err
+}
+
+func (p *mibParser) parseNumber() (uint32, error) {
+	s, err := p.expectKind(mibNumber, "number")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, p.errorf("invalid number %s", s)
+	}
+	return uint32(n), nil
+}
+
+// parseNames parses a list of names such as "{ ifIndex }", as found in
+// INDEX and OBJECTS clauses
+func (p *mibParser) parseNames() (names []string, implied bool, err error) {
+	if err := p.expect("{"); err != nil {
+		return nil, false, err
+	}
+	for !p.accept("}") {
+		switch tok := p.next(); {
+		case tok.kind == mibEOF:
+			return nil, false, p.errorf("unterminated list")
+		case tok.text == "IMPLIED":
+			implied = true
+		case tok.kind == mibIdent:
+			names = append(names, tok.text)
+		}
+	}
+	return names, implied, nil
+}
+
+// parseSyntax parses a type, with its enumerations or named bits and its
+// constraints
+func (p *mibParser) parseSyntax() (*MIBSyntax, error) {
+	s := &MIBSyntax{}
+
+	// Tagged types, as used by the SMI modules themselves
+	if p.peek().text == "[" {
+		if err := p.skipBalanced(); err != nil {
+			return nil, err
+		}
+		if !p.accept("IMPLICIT") {
+			p.accept("EXPLICIT")
+		}
+	}
+
+	tok := p.next()
+	switch {
+	case tok.text == "OCTET" || tok.text == "OBJECT":
+		second := map[string]string{"OCTET": "STRING", "OBJECT": "IDENTIFIER"}[tok.text]
+		if err := p.expect(second); err != nil {
+			return nil, err
+		}
+		s.Type = tok.text + " " + second
+	case tok.text == "SEQUENCE" && p.accept("OF"):
+		entry, err := p.expectKind(mibIdent, "type")
+		if err != nil {
+			return nil, err
+		}
+		s.Type = "SEQUENCE OF " + entry
+		return s, nil
+	case tok.text == "SEQUENCE" || tok.text == "CHOICE":
+		s.Type = tok.text
+		return s, p.skipBalanced()
+	case tok.kind == mibIdent:
+		s.Type = tok.text
+	default:
+		return nil, p.errorf("expected type, got %q", tok.text)
+	}
+
+	if p.peek().text == "{" {
+		enums, err := p.parseNamedNumbers()
+		if err != nil {
+			return nil, err
+		}
+		s.Enums = enums
+	}
+	if p.peek().text == "(" {
+		// A single SIZE value, as in (SIZE (6)), fixes the length
+		start := p.pos
+		if err := p.skipBalanced(); err != nil {
+			return nil, err
+		}
+		if c := p.toks[start:p.pos]; len(c) == 6 && c[1].text == "SIZE" && c[3].kind == mibNumber {
+			s.Size, _ = strconv.Atoi(c[3].text)
+		}
+	}
+	return s, nil
+}
+
+// parseNamedNumbers parses enumerations such as "{ up(1), down(2) }"
+func (p *mibParser) parseNamedNumbers() ([]NamedNumber, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var enums []NamedNumber
+	for !p.accept("}") {
+		name, err := p.expectKind(mibIdent, "enumeration label")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		number, err := p.expectKind(mibNumber, "number")
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", number)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		enums = append(enums, NamedNumber{name, value})
+		p.accept(",")
+	}
+	return enums, nil
+}
+
+// parseValue parses the OID value of a definition, such as
+// "::= { ifEntry 2 }" or "::= { iso org(3) dod(6) 1 }"
+func (p *mibParser) parseValue(m *mibModule, name string, node *MIBNode) error {
+	if err := p.expect("::="); err != nil {
+		return err
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	var value []oidComponent
+	for !p.accept("}") {
+		tok := p.next()
+		switch tok.kind {
+		case mibNumber:
+			n, err := strconv.ParseUint(tok.text, 10, 32)
+			if err != nil {
+				return p.errorf("invalid OID component %s", tok.text)
+			}
+			value = append(value, oidComponent{number: uint32(n), numbered: true})
+		case mibIdent:
+			c := oidComponent{name: tok.text}
+			if p.accept("(") {
+				n, err := p.parseNumber()
+				if err != nil {
+					return err
+				}
+				if err := p.expect(")"); err != nil {
+					return err
+				}
+				c.number, c.numbered = n, true
+			}
+			value = append(value, c)
+		default:
+			return p.errorf("unexpected %q in OID value of %s", tok.text, name)
+		}
+	}
+	m.define(name, node, value)
+	return nil
+}
+
+func (m *mibModule) define(name string, node *MIBNode, value []oidComponent) {
+	node.Name, node.Module = name, m.name
+	if _, ok := m.defs[name]; !ok {
+		m.order = append(m.order, name)
+	}
+	m.defs[name] = &mibDef{name: name, module: m, node: node, value: value}
+}
@@ -0,0 +1,155 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OID is an SNMP object identifier, stored as its sequence of arcs
+type OID []uint32
+
+// ParseOID parses a dotted OID such as ".1.3.6.1.2.1.1.1.0". The leading
+// dot is optional.
+func ParseOID(s string) (OID, error) {
+	s = strings.Trim(strings.TrimSpace(s), ".")
+	if s == "" {
+		return nil, fmt.Errorf("Unable to parse OID: empty OID")
+	}
+
+	parts := strings.Split(s, ".")
+	oid := make(OID, len(parts))
+	for i, part := range parts {
+		arc, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse OID %q: invalid arc %q", s, part)
+		}
+		oid[i] = uint32(arc)
+	}
+	return oid, nil
+}
+
+// MustParseOID is like ParseOID but panics if the OID cannot be parsed. It
+// is intended for OID constants.
+func MustParseOID(s string) OID {
+	oid, err := ParseOID(s)
+	if err != nil {
+		panic(err)
+	}
+	return oid
+}
+
+// String returns the OID in dotted form with a leading dot, the same format
+// used for SnmpPDU.Name
+func (o OID) String() string {
+	var buf strings.Builder
+	for _, arc := range o {
+		buf.WriteByte('.')
+		buf.WriteString(strconv.FormatUint(uint64(arc), 10))
+	}
+	return buf.String()
+}
+
+// Compare compares two OIDs lexicographically, arc by arc. It returns -1 if
+// o sorts before other, 1 if it sorts after it and 0 if they are equal.
+func (o OID) Compare(other OID) int {
+	for i := 0; i < len(o) && i < len(other); i++ {
+		if o[i] < other[i] {
+			return -1
+		}
+		if o[i] > other[i] {
+			return 1
+		}
+	}
+	switch {
+	case len(o) < len(other):
+		return -1
+	case len(o) > len(other):
+		return 1
+	}
+	return 0
+}
+
+// Equal returns true if both OIDs have the same arcs
+func (o OID) Equal(other OID) bool {
+	return o.Compare(other) == 0
+}
+
+// HasPrefix returns true if the OID lies within the subtree rooted at
+// prefix. Arcs are compared whole, so .1.3.6.1.2.1.2 is not a prefix of
+// .1.3.6.1.2.1.20.
+func (o OID) HasPrefix(prefix OID) bool {
+	if len(prefix) > len(o) {
+		return false
+	}
+	return o[:len(prefix)].Equal(prefix)
+}
+
+// Parent returns the OID with its last arc removed. The parent of an empty
+// OID is an empty OID.
+func (o OID) Parent() OID {
+	if len(o) == 0 {
+		return OID{}
+	}
+	return o.Copy()[:len(o)-1]
+}
+
+// Append returns a new OID with the given arcs appended. The receiver is
+// never modified.
+func (o OID) Append(arcs ...uint32) OID {
+	ret := make(OID, len(o), len(o)+len(arcs))
+	copy(ret, o)
+	return append(ret, arcs...)
+}
+
+// Copy returns a copy of the OID that does not share memory with it
+func (o OID) Copy() OID {
+	return o.Append()
+}
+
+// OID returns the name of the PDU as an OID, or nil if it cannot be parsed
+func (pdu SnmpPDU) OID() OID {
+	oid, err := ParseOID(pdu.Name)
+	if err != nil {
+		return nil
+	}
+	return oid
+}
+
+// ints converts the OID into the integer arcs used by the BER encoder
+func (o OID) ints() []int {
+	ret := make([]int, len(o))
+	for i, arc := range o {
+		ret[i] = int(arc)
+	}
+	return ret
+}
+
+// intsToOID converts integer arcs produced by the BER decoder into an OID
+func intsToOID(arcs []int) OID {
+	ret := make(OID, len(arcs))
+	for i, arc := range arcs {
+		ret[i] = uint32(arc)
+	}
+	return ret
+}
+
+// MarshalText implements encoding.TextMarshaler, so OIDs are serialised in
+// their dotted form
+func (o OID) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (o *OID) UnmarshalText(text []byte) error {
+	oid, err := ParseOID(string(text))
+	if err != nil {
+		return err
+	}
+	*o = oid
+	return nil
+}
@@ -0,0 +1,131 @@
+package gosnmp
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Test OID parsing and formatting
+func TestParseOID(t *testing.T) {
+	for _, s := range []string{".1.3.6.1.2.1.1.1.0", "1.3.6.1.2.1.1.1.0"} {
+		oid, err := ParseOID(s)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %s", s, err)
+		}
+		if oid.String() != ".1.3.6.1.2.1.1.1.0" {
+			t.Errorf("OID string:\n\twant: %q\n\tgot : %q", ".1.3.6.1.2.1.1.1.0", oid)
+		}
+	}
+
+	if oid, err := ParseOID(".1.3.6.1.4.1.4294967295"); err != nil || oid[len(oid)-1] != 4294967295 {
+		t.Errorf("Unable to parse maximum sub-identifier: %v %v", oid, err)
+	}
+
+	for _, s := range []string{"", ".", "1.3.x", "1..3", "1.3.4294967296"} {
+		if _, err := ParseOID(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		}
+	}
+}
+
+// Test lexicographic comparison of OIDs
+func TestOIDCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{".1.3.6.1", ".1.3.6.1", 0},
+		{".1.3.6.1", ".1.3.6.1.2", -1},
+		{".1.3.6.1.2.1.2", ".1.3.6.1.2.1.10", -1},
+		{".1.3.6.1.2.1.20", ".1.3.6.1.2.1.2.2", 1},
+	}
+
+	for _, test := range tests {
+		if got := MustParseOID(test.a).Compare(MustParseOID(test.b)); got != test.want {
+			t.Errorf("Compare(%s, %s):\n\twant: %d\n\tgot : %d", test.a, test.b, test.want, got)
+		}
+	}
+}
+
+// Test arc-aware subtree membership
+func TestOIDHasPrefix(t *testing.T) {
+	root := MustParseOID(".1.3.6.1.2.1.2")
+
+	tests := map[string]bool{
+		".1.3.6.1.2.1.2":           true,
+		".1.3.6.1.2.1.2.2.1.2.1":   true,
+		".1.3.6.1.2.1.20.1":        false,
+		".1.3.6.1.2.1.25.1.1.0":    false,
+		".1.3.6.1.2.1":             false,
+		".1.3.6.1.4.1.1.3.6.1.2.1": false,
+	}
+
+	for s, want := range tests {
+		if got := MustParseOID(s).HasPrefix(root); got != want {
+			t.Errorf("%s HasPrefix %s:\n\twant: %t\n\tgot : %t", s, root, want, got)
+		}
+	}
+}
+
+// Test Parent and Append do not modify the receiver
+func TestOIDParentAppend(t *testing.T) {
+	oid := MustParseOID(".1.3.6.1.2.1.2.2.1")
+	column := oid.Append(2)
+	row := column.Append(10)
+
+	if !column.Equal(MustParseOID(".1.3.6.1.2.1.2.2.1.2")) {
+		t.Errorf("Append:\n\twant: %s\n\tgot : %s", ".1.3.6.1.2.1.2.2.1.2", column)
+	}
+	if !row.Parent().Equal(column) {
+		t.Errorf("Parent:\n\twant: %s\n\tgot : %s", column, row.Parent())
+	}
+	if !oid.Equal(MustParseOID(".1.3.6.1.2.1.2.2.1")) {
+		t.Errorf("Append modified receiver: %s", oid)
+	}
+}
+
+// Test BER encoding across the full range of arcs
+func TestOIDEncoding(t *testing.T) {
+	tests := []struct {
+		oid     string
+		encoded string
+	}{
+		{".1.3.6.1.2.1.1.1.0", "2b06010201010100"},
+		{".0.39", "27"},
+		{".2.100.3", "813403"},
+		{".2.999", "8837"},
+		{".1.3.6.1.4.1.4294967295", "2b06010401" + "8fffffff7f"},
+		{".2.4294967295.1", "908080804f01"},
+	}
+
+	for _, test := range tests {
+		oid := MustParseOID(test.oid)
+		encoded, err := marshalObjectIdentifier(oid)
+		if err != nil {
+			t.Errorf("Unable to marshal %s: %s", test.oid, err)
+			continue
+		}
+		if got := hex.EncodeToString(encoded); got != test.encoded {
+			t.Errorf("Marshal %s:\n\twant: %s\n\tgot : %s", test.oid, test.encoded, got)
+		}
+
+		decoded, err := parseObjectIdentifier(encoded)
+		if err != nil {
+			t.Errorf("Unable to parse %s: %s", test.oid, err)
+			continue
+		}
+		if !decoded.Equal(oid) {
+			t.Errorf("Parse:\n\twant: %s\n\tgot : %s", oid, decoded)
+		}
+	}
+
+	for _, s := range []string{".3.1", ".0.40", ".1.40.1", ".1"} {
+		if _, err := marshalObjectIdentifier(MustParseOID(s)); err == nil {
+			t.Errorf("Expected error marshaling %s", s)
+		}
+	}
+
+	if _, err := parseObjectIdentifier([]byte{0x2b, 0x90, 0x80, 0x80, 0x80, 0x00}); err == nil {
+		t.Errorf("Expected error parsing sub-identifier larger than 32 bits")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+var (
+	oidOspfIfEntry  = MustParseOID(".1.3.6.1.2.1.14.7.1")
+	oidOspfNbrEntry = MustParseOID(".1.3.6.1.2.1.14.10.1")
+)
+
+// Columns of ospfIfTable and ospfNbrTable (RFC 4750)
+const (
+	ospfIfAreaId                 = 3
+	ospfIfType                   = 4
+	ospfIfAdminStat              = 5
+	ospfIfRtrPriority            = 6
+	ospfIfHelloInterval          = 9
+	ospfIfRtrDeadInterval        = 10
+	ospfIfState                  = 12
+	ospfIfDesignatedRouter       = 13
+	ospfIfBackupDesignatedRouter = 14
+	ospfIfEvents                 = 15
+
+	ospfNbrRtrId         = 3
+	ospfNbrOptions       = 4
+	ospfNbrPriority      = 5
+	ospfNbrState         = 6
+	ospfNbrEvents        = 7
+	ospfNbrLsRetransQLen = 8
+)
+
+// OSPFNeighborState is the state of the adjacency with an OSPF neighbour
+type OSPFNeighborState int
+
+const (
+	OSPFNeighborDown          OSPFNeighborState = 1
+	OSPFNeighborAttempt       OSPFNeighborState = 2
+	OSPFNeighborInit          OSPFNeighborState = 3
+	OSPFNeighborTwoWay        OSPFNeighborState = 4
+	OSPFNeighborExchangeStart OSPFNeighborState = 5
+	OSPFNeighborExchange      OSPFNeighborState = 6
+	OSPFNeighborLoading       OSPFNeighborState = 7
+	OSPFNeighborFull          OSPFNeighborState = 8
+)
+
+// String returns the label of the state, such as "full"
+func (s OSPFNeighborState) String() string {
+	if name, ok := BuiltinMIBs().syntaxOf(oidOspfNbrEntry.Append(ospfNbrState)).EnumName(int64(s)); ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// OSPFInterfaceState is the state of the OSPF interface state machine
+type OSPFInterfaceState int
+
+const (
+	OSPFInterfaceDown     OSPFInterfaceState = 1
+	OSPFInterfaceLoopback OSPFInterfaceState = 2
+	OSPFInterfaceWaiting  OSPFInterfaceState = 3
+	// OSPFInterfacePointToPoint is the state of interfaces to point to
+	// point and point to multipoint networks
+	OSPFInterfacePointToPoint OSPFInterfaceState = 4
+	OSPFInterfaceDR           OSPFInterfaceState = 5
+	OSPFInterfaceBackupDR     OSPFInterfaceState = 6
+	OSPFInterfaceOtherDR      OSPFInterfaceState = 7
+)
+
+// String returns the label of the state, such as "designatedRouter"
+func (s OSPFInterfaceState) String() string {
+	if name, ok := BuiltinMIBs().syntaxOf(oidOspfIfEntry.Append(ospfIfState)).EnumName(int64(s)); ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// OSPFNeighbor is a neighbour of an OSPF router, from a row of ospfNbrTable
+type OSPFNeighbor struct {
+	Address net.IP
+	// AddressLessIndex is the ifIndex of the interface of a neighbour on an
+	// unnumbered link, or zero
+	AddressLessIndex int
+	RouterID         net.IP
+	// Options is the bit mask of the Options field of the neighbour's hello
+	// packets
+	Options  int
+	Priority int
+	State    OSPFNeighborState
+	// Events is the number of state changes or errors of the neighbour
+	Events uint64
+	// LsRetransQLen is the length of the link state retransmission queue
+	LsRetransQLen uint64
+}
+
+// Up returns true if there is two-way communication with the neighbour:
+// the adjacency is full, or it is in OSPFNeighborTwoWay or forming one.
+// Routers that are not the designated router or its backup stay in
+// OSPFNeighborTwoWay with each other.
+func (n *OSPFNeighbor) Up() bool {
+	return n.State >= OSPFNeighborTwoWay
+}
+
+// OSPFInterface is an interface of an OSPF router, from a row of
+// ospfIfTable
+type OSPFInterface struct {
+	Address net.IP
+	// AddressLessIf is the ifIndex of an unnumbered interface, or zero
+	AddressLessIf int
+	AreaID        net.IP
+	// Type is broadcast(1), nbma(2), pointToPoint(3) or
+	// pointToMultipoint(5)
+	Type int
+	// Enabled is set if ospfIfAdminStat is enabled
+	Enabled                bool
+	Priority               int
+	HelloInterval          time.Duration
+	DeadInterval           time.Duration
+	State                  OSPFInterfaceState
+	DesignatedRouter       net.IP
+	BackupDesignatedRouter net.IP
+	// Events is the number of state changes or errors of the interface
+	Events uint64
+}
+
+// OSPFNeighbors retrieves the OSPF neighbours of the target from OSPF-MIB
+// ospfNbrTable. Agents without OSPF-MIB return no neighbours.
+//
+// If a request fails, the neighbours retrieved so far are returned along
+// with the error.
+func (x *GoSNMP) OSPFNeighbors() ([]*OSPFNeighbor, error) {
+	t, err := x.BulkGetTableOID(0, oidOspfNbrEntry.Parent(),
+		ospfNbrRtrId, ospfNbrOptions, ospfNbrPriority, ospfNbrState, ospfNbrEvents, ospfNbrLsRetransQLen)
+	if t == nil {
+		return nil, err
+	}
+	var neighbors []*OSPFNeighbor
+	// Indexed by ospfNbrIpAddr and ospfNbrAddressLessIndex
+	for _, row := range t.Rows {
+		if len(row.Index) != 5 {
+			continue
+		}
+		n := &OSPFNeighbor{Address: oidIP(row.Index[:4]), AddressLessIndex: int(row.Index[4])}
+		n.RouterID = tableIP(t, row, ospfNbrRtrId)
+		n.Options = tableInt(t, row, ospfNbrOptions)
+		n.Priority = tableInt(t, row, ospfNbrPriority)
+		n.State = OSPFNeighborState(tableInt(t, row, ospfNbrState))
+		n.Events, _ = tableUint(t, row, ospfNbrEvents)
+		n.LsRetransQLen, _ = tableUint(t, row, ospfNbrLsRetransQLen)
+		neighbors = append(neighbors, n)
+	}
+	return neighbors, err
+}
+
+// OSPFInterfaces retrieves the OSPF interfaces of the target from OSPF-MIB
+// ospfIfTable. Agents without OSPF-MIB return no interfaces.
+//
+// If a request fails, the interfaces retrieved so far are returned along
+// with the error.
+func (x *GoSNMP) OSPFInterfaces() ([]*OSPFInterface, error) {
+	t, err := x.BulkGetTableOID(0, oidOspfIfEntry.Parent(),
+		ospfIfAreaId, ospfIfType, ospfIfAdminStat, ospfIfRtrPriority, ospfIfHelloInterval,
+		ospfIfRtrDeadInterval, ospfIfState, ospfIfDesignatedRouter, ospfIfBackupDesignatedRouter, ospfIfEvents)
+	if t == nil {
+		return nil, err
+	}
+	var interfaces []*OSPFInterface
+	// Indexed by ospfIfIpAddress and ospfAddressLessIf
+	for _, row := range t.Rows {
+		if len(row.Index) != 5 {
+			continue
+		}
+		i := &OSPFInterface{Address: oidIP(row.Index[:4]), AddressLessIf: int(row.Index[4])}
+		i.AreaID = tableIP(t, row, ospfIfAreaId)
+		i.Type = tableInt(t, row, ospfIfType)
+		// Status enabled(1), disabled(2)
+		i.Enabled = tableInt(t, row, ospfIfAdminStat) == 1
+		i.Priority = tableInt(t, row, ospfIfRtrPriority)
+		i.HelloInterval = time.Duration(tableInt(t, row, ospfIfHelloInterval)) * time.Second
+		i.DeadInterval = time.Duration(tableInt(t, row, ospfIfRtrDeadInterval)) * time.Second
+		i.State = OSPFInterfaceState(tableInt(t, row, ospfIfState))
+		i.DesignatedRouter = tableIP(t, row, ospfIfDesignatedRouter)
+		i.BackupDesignatedRouter = tableIP(t, row, ospfIfBackupDesignatedRouter)
+		i.Events, _ = tableUint(t, row, ospfIfEvents)
+		interfaces = append(interfaces, i)
+	}
+	return interfaces, err
+}
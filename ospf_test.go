@@ -0,0 +1,65 @@
+package gosnmp
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test reading OSPF neighbours and interfaces
+func TestOSPF(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.14.10.1.3.10.0.0.2.0":  IPAddressValue(net.ParseIP("2.2.2.2")),
+		".1.3.6.1.2.1.14.10.1.5.10.0.0.2.0":  Integer32Value(1),
+		".1.3.6.1.2.1.14.10.1.6.10.0.0.2.0":  Integer32Value(8),
+		".1.3.6.1.2.1.14.10.1.7.10.0.0.2.0":  Counter32Value(6),
+		".1.3.6.1.2.1.14.7.1.3.10.0.0.1.0":   IPAddressValue(net.ParseIP("0.0.0.0")),
+		".1.3.6.1.2.1.14.7.1.4.10.0.0.1.0":   Integer32Value(1),
+		".1.3.6.1.2.1.14.7.1.5.10.0.0.1.0":   Integer32Value(1),
+		".1.3.6.1.2.1.14.7.1.9.10.0.0.1.0":   Integer32Value(10),
+		".1.3.6.1.2.1.14.7.1.10.10.0.0.1.0":  Integer32Value(40),
+		".1.3.6.1.2.1.14.7.1.12.10.0.0.1.0":  Integer32Value(5),
+		".1.3.6.1.2.1.14.7.1.13.10.0.0.1.0":  IPAddressValue(net.ParseIP("10.0.0.1")),
+		".1.3.6.1.2.1.14.7.1.14.10.0.0.1.0":  IPAddressValue(net.ParseIP("10.0.0.2")),
+		".1.3.6.1.2.1.14.10.1.6.10.0.0.3.0":  Integer32Value(2),
+		".1.3.6.1.2.1.14.10.1.3.10.0.0.3.0":  IPAddressValue(net.ParseIP("3.3.3.3")),
+		".1.3.6.1.2.1.14.10.1.6.0.0.0.0.12":  Integer32Value(4),
+		".1.3.6.1.2.1.14.10.1.3.0.0.0.0.12":  IPAddressValue(net.ParseIP("4.4.4.4")),
+		".1.3.6.1.2.1.14.10.1.7.0.0.0.0.12":  Counter32Value(1),
+		".1.3.6.1.2.1.14.10.1.8.0.0.0.0.12":  Gauge32Value(0),
+		".1.3.6.1.2.1.14.10.1.11.10.0.0.2.0": Integer32Value(2),
+	})
+	s := agent.client()
+	neighbors, err := s.OSPFNeighbors()
+	if err != nil {
+		t.Fatalf("OSPFNeighbors: %s", err)
+	}
+	if len(neighbors) != 3 {
+		t.Fatalf("OSPFNeighbors: %+v", neighbors)
+	}
+	want := &OSPFNeighbor{Address: net.IP{10, 0, 0, 2}, RouterID: net.IP{2, 2, 2, 2}, Priority: 1, State: OSPFNeighborFull, Events: 6}
+	if !reflect.DeepEqual(neighbors[1], want) || !neighbors[1].Up() {
+		t.Errorf("Neighbor:\n\twant: %+v\n\tgot : %+v", want, neighbors[1])
+	}
+	if n := neighbors[0]; n.AddressLessIndex != 12 || n.State.String() != "twoWay" || !n.Up() {
+		t.Errorf("Unnumbered neighbor: %+v", n)
+	}
+	if n := neighbors[2]; n.State.String() != "attempt" || n.Up() {
+		t.Errorf("Down neighbor: %+v", n)
+	}
+
+	interfaces, err := s.OSPFInterfaces()
+	if err != nil {
+		t.Fatalf("OSPFInterfaces: %s", err)
+	}
+	if len(interfaces) != 1 {
+		t.Fatalf("OSPFInterfaces: %+v", interfaces)
+	}
+	i := interfaces[0]
+	if i.Address.String() != "10.0.0.1" || i.AreaID.String() != "0.0.0.0" || i.Type != 1 || !i.Enabled ||
+		i.HelloInterval != 10*time.Second || i.DeadInterval != 40*time.Second || i.State != OSPFInterfaceDR ||
+		i.State.String() != "designatedRouter" || i.DesignatedRouter.String() != "10.0.0.1" || i.BackupDesignatedRouter.String() != "10.0.0.2" {
+		t.Errorf("Interface: %+v", i)
+	}
+}
@@ -4,9 +4,6 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
-	"math"
-	"strconv"
-	"strings"
 
 	l "github.com/alouca/gologger"
 )
@@ -27,6 +24,45 @@ func (s SnmpVersion) String() string {
 	return "U"
 }
 
+// SnmpError is the error-status of an SNMP response
+type SnmpError uint8
+
+const (
+	NoError             SnmpError = 0
+	TooBig              SnmpError = 1
+	NoSuchName          SnmpError = 2
+	BadValue            SnmpError = 3
+	ReadOnly            SnmpError = 4
+	GenErr              SnmpError = 5
+	NoAccess            SnmpError = 6
+	WrongType           SnmpError = 7
+	WrongLength         SnmpError = 8
+	WrongEncoding       SnmpError = 9
+	WrongValue          SnmpError = 10
+	NoCreation          SnmpError = 11
+	InconsistentValue   SnmpError = 12
+	ResourceUnavailable SnmpError = 13
+	CommitFailed        SnmpError = 14
+	UndoFailed          SnmpError = 15
+	AuthorizationError  SnmpError = 16
+	NotWritable         SnmpError = 17
+	InconsistentName    SnmpError = 18
+)
+
+var snmpErrorStrings = []string{
+	"noError", "tooBig", "noSuchName", "badValue", "readOnly", "genErr",
+	"noAccess", "wrongType", "wrongLength", "wrongEncoding", "wrongValue",
+	"noCreation", "inconsistentValue", "resourceUnavailable", "commitFailed",
+	"undoFailed", "authorizationError", "notWritable", "inconsistentName",
+}
+
+func (e SnmpError) String() string {
+	if int(e) < len(snmpErrorStrings) {
+		return snmpErrorStrings[e]
+	}
+	return fmt.Sprintf("error(%d)", uint8(e))
+}
+
 type SnmpPacket struct {
 	Version        SnmpVersion
 	Community      string
@@ -37,6 +73,10 @@ type SnmpPacket struct {
 	NonRepeaters   uint8
 	MaxRepetitions uint8
 	Variables      []SnmpPDU
+	VarBinds       []VarBind
+
+	// size of the packet when it was unmarshaled
+	size int
 }
 
 type SnmpPDU struct {
@@ -45,20 +85,38 @@ type SnmpPDU struct {
 	Value interface{}
 }
 
+// Unmarshal decodes a raw SNMP packet, storing the variable bindings in
+// Variables. Exception values (noSuchObject, noSuchInstance) are reported as
+// errors.
 func Unmarshal(packet []byte) (*SnmpPacket, error) {
+	return unmarshal(packet, false)
+}
+
+// UnmarshalTyped decodes a raw SNMP packet, storing the variable bindings as
+// typed values in VarBinds. Exception values are returned as values.
+func UnmarshalTyped(packet []byte) (*SnmpPacket, error) {
+	return unmarshal(packet, true)
+}
+
+func unmarshal(packet []byte, typed bool) (*SnmpPacket, error) {
 	log := l.GetDefaultLogger()
 
 	log.Debug("Begin SNMP Packet unmarshal\n")
 
 	//var err error
 	response := new(SnmpPacket)
-	response.Variables = make([]SnmpPDU, 0, 5)
+	response.size = len(packet)
+	if typed {
+		response.VarBinds = make([]VarBind, 0, 5)
+	} else {
+		response.Variables = make([]SnmpPDU, 0, 5)
+	}
 
 	// Start parsing the packet
 	var cursor uint64 = 0
 
 	// First bytes should be 0x30
-	if Asn1BER(packet[0]) == Sequence {
+	if len(packet) > 0 && Asn1BER(packet[0]) == Sequence {
 		// Parse packet length
 		ber, err := parseField(packet)
 
@@ -95,10 +153,10 @@ func Unmarshal(packet []byte) (*SnmpPacket, error) {
 			log.Debug("Parsed community %s\n", community)
 		}
 
-		rawPDU, err := parseField(packet[cursor:])
+		rawPDU, err := parseHeader(packet[cursor:])
 
 		if err != nil {
-			log.Debug("Unable to parse SNMP PDU: %s\n", err.Error())
+			return nil, fmt.Errorf("Unable to parse SNMP PDU: %s", err.Error())
 		}
 		response.RequestType = rawPDU.Type
 
@@ -106,7 +164,7 @@ func Unmarshal(packet []byte) (*SnmpPacket, error) {
 		default:
 			log.Debug("Unsupported SNMP Packet Type %s\n", rawPDU.Type.String())
 			log.Debug("PDU Size is %d\n", rawPDU.DataLength)
-		case GetRequest, GetResponse, GetBulkRequest:
+		case GetRequest, GetNextRequest, GetResponse, SetRequest, GetBulkRequest:
 			log.Debug("SNMP Packet is %s\n", rawPDU.Type.String())
 			log.Debug("PDU Size is %d\n", rawPDU.DataLength)
 			cursor += rawPDU.HeaderLength
@@ -182,6 +240,31 @@ func Unmarshal(packet []byte) (*SnmpPacket, error) {
 
 				log.Debug("OID (%v) Field was %d bytes\n", rawOid, rawOid.DataLength)
 
+				oid, err := parseObjectIdentifier(rawOid.Data)
+
+				if err != nil {
+					return nil, fmt.Errorf("Unable to decode varbind name: %s", err.Error())
+				}
+
+				if typed {
+					rawValue, err := parseHeader(packet[cursor:])
+
+					if err != nil {
+						return nil, err
+					}
+					cursor += rawValue.HeaderLength + rawValue.DataLength
+
+					value, err := decodeBERValue(rawValue.Type, rawValue.Data)
+
+					if err != nil {
+						return nil, fmt.Errorf("Unable to decode value: %s", err.Error())
+					}
+
+					log.Debug("Varbind decoding success\n")
+					response.VarBinds = append(response.VarBinds, VarBind{oid, value})
+					continue
+				}
+
 				rawValue, err := parseField(packet[cursor:])
 
 				if err != nil {
@@ -191,10 +274,8 @@ func Unmarshal(packet []byte) (*SnmpPacket, error) {
 
 				log.Debug("Value field was %d bytes\n", rawValue.DataLength)
 
-				if oid, ok := rawOid.BERVariable.Value.([]int); ok {
-					log.Debug("Varbind decoding success\n")
-					response.Variables = append(response.Variables, SnmpPDU{oidToString(oid), rawValue.Type, rawValue.BERVariable.Value})
-				}
+				log.Debug("Varbind decoding success\n")
+				response.Variables = append(response.Variables, SnmpPDU{oid.String(), rawValue.Type, rawValue.BERVariable.Value})
 			}
 
 		}
@@ -215,11 +296,29 @@ type RawBER struct {
 
 // Parses a given field, return the ASN.1 BER Type, its header length and the data
 func parseField(data []byte) (*RawBER, error) {
-	log := l.GetDefaultLogger()
 	var err error
 
-	if len(data) == 0 {
-		return nil, fmt.Errorf("Unable to parse BER: Data length 0")
+	ber, err := parseHeader(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ber.BERVariable, err = decodeValue(ber.Type, ber.Data)
+
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode value: %s\n", err.Error())
+	}
+
+	return ber, nil
+}
+
+// Parses the type and length of a given field without decoding its value
+func parseHeader(data []byte) (*RawBER, error) {
+	log := l.GetDefaultLogger()
+
+	if len(data) < 2 {
+		return nil, fmt.Errorf("Unable to parse BER: Data length %d", len(data))
 	}
 
 	ber := new(RawBER)
@@ -233,6 +332,9 @@ func parseField(data []byte) (*RawBER, error) {
 	if length > 0x80 {
 		length = length - 0x80
 		log.Debug("Field length is padded to %d bytes\n", length)
+		if len(data) < 2+int(length) {
+			return nil, fmt.Errorf("Unable to parse BER: truncated length field")
+		}
 		ber.DataLength = Uvarint(data[2 : 2+length])
 		log.Debug("Decoded final length: %d\n", ber.DataLength)
 
@@ -244,29 +346,45 @@ func parseField(data []byte) (*RawBER, error) {
 	}
 
 	// Do sanity checks
-	if ber.DataLength > uint64(len(data)) {
+	if ber.HeaderLength+ber.DataLength > uint64(len(data)) {
 		return nil, fmt.Errorf("Unable to parse BER: provided data length is longer than actual data (%d vs %d)", ber.DataLength, len(data))
 	}
 
 	ber.Data = data[ber.HeaderLength : ber.HeaderLength+ber.DataLength]
 
-	ber.BERVariable, err = decodeValue(ber.Type, ber.Data)
-
-	if err != nil {
-		return nil, fmt.Errorf("Unable to decode value: %s\n", err.Error())
-	}
-
 	return ber, nil
 }
 
 func (packet *SnmpPacket) marshal() ([]byte, error) {
+	// Marshal the variable bindings, preferring the typed ones when present
+	varbindBuf := new(bytes.Buffer)
+	if packet.VarBinds != nil {
+		for i := range packet.VarBinds {
+			vb, err := marshalVarBind(&packet.VarBinds[i])
+
+			if err != nil {
+				return nil, err
+			}
+			varbindBuf.Write(vb)
+		}
+	} else {
+		for _, varlist := range packet.Variables {
+			pdu, err := marshalPDU(&varlist)
+
+			if err != nil {
+				return nil, err
+			}
+			varbindBuf.Write(pdu)
+		}
+	}
+
 	// Marshal the SNMP PDU
 	snmpPduBuffer := make([]byte, 0, 1024)
 	snmpPduBuf := bytes.NewBuffer(snmpPduBuffer)
 
 	requestIDBytes := make([]byte, 4)
 	binary.BigEndian.PutUint32(requestIDBytes, packet.RequestID)
-	snmpPduBuf.Write(append([]byte{byte(packet.RequestType), 0, 2, 4}, requestIDBytes...))
+	snmpPduBuf.Write(append([]byte{2, 4}, requestIDBytes...))
 
 	switch packet.RequestType {
 	case GetBulkRequest:
@@ -281,73 +399,24 @@ func (packet *SnmpPacket) marshal() ([]byte, error) {
 		})
 	}
 
-	snmpPduBuf.Write([]byte{byte(Sequence), 0})
-
-	pduLength := 0
-	for _, varlist := range packet.Variables {
-		pdu, err := marshalPDU(&varlist)
-
-		if err != nil {
-			return nil, err
-		}
-		pduLength += len(pdu)
-		snmpPduBuf.Write(pdu)
-	}
-
-	pduBytes := snmpPduBuf.Bytes()
-	// Varbind list length
-	pduBytes[15] = byte(pduLength)
-	// SNMP PDU length (PDU header + varbind list length)
-	pduBytes[1] = byte(pduLength + 14)
+	// Varbind list
+	snmpPduBuf.Write(marshalTLV(Sequence, varbindBuf.Bytes()))
 
 	// Prepare the buffer to send
 	buffer := make([]byte, 0, 1024)
 	buf := bytes.NewBuffer(buffer)
 
-	// Write the message type 0x30
-	buf.Write([]byte{byte(Sequence)})
-
-	// Find the size of the whole data
-	// The extra 5 bytes are snmp verion (3 bytes) + community string type and
-	// community string length
-	dataLength := len(pduBytes) + len(packet.Community) + 5
-
-	// If the data is 128 bytes or larger then we need to use 2 or more bytes
-	// to represent the length
-	if dataLength >= 128 {
-		// Work out how many bytes we require
-		bytesNeeded := int(math.Ceil(math.Log2(float64(dataLength)) / 8))
-		// Set the most significant bit to 1 to show we are using the long for,
-		// then the 7 least significant bits to show how many bytes will be used
-		// to represent the length
-		lengthIdentifier := 128 + bytesNeeded
-		buf.Write([]byte{uint8(lengthIdentifier)})
-		lengthBytes := make([]byte, bytesNeeded)
-		for i := bytesNeeded; i >= 1; i-- {
-			lengthBytes[i-1] = uint8(dataLength % 256)
-			dataLength = dataLength >> 8
-		}
-		buf.Write(lengthBytes)
-	} else {
-		buf.Write([]byte{uint8(dataLength)})
-	}
-
 	// Write the Version
 	buf.Write([]byte{2, 1, byte(packet.Version)})
 
 	// Write Community
-	buf.Write([]byte{4, uint8(len(packet.Community))})
-	buf.WriteString(packet.Community)
+	buf.Write(marshalTLV(OctetString, []byte(packet.Community)))
 
 	// Write the PDU
-	buf.Write(pduBytes)
+	buf.Write(marshalTLV(packet.RequestType, snmpPduBuf.Bytes()))
 
-	// Write the
-	//buf.Write([]byte{packet.RequestType, uint8(17 + len(mOid)), 2, 1, 1, 2, 1, 0, 2, 1, 0, 0x30, uint8(6 + len(mOid)), 0x30, uint8(4 + len(mOid)), 6, uint8(len(mOid))})
-	//buf.Write(mOid)
-	//buf.Write([]byte{5, 0})
-
-	return buf.Bytes(), nil
+	// Wrap everything in the message sequence 0x30
+	return marshalTLV(Sequence, buf.Bytes()), nil
 }
 
 func marshalPDU(pdu *SnmpPDU) ([]byte, error) {
@@ -362,39 +431,45 @@ func marshalPDU(pdu *SnmpPDU) ([]byte, error) {
 	// Mashal the PDU type into the appropriate BER
 	switch pdu.Type {
 	case Null:
-		pduBuf.Write([]byte{byte(Sequence), byte(len(oid) + 4)})
-		pduBuf.Write([]byte{byte(ObjectIdentifier), byte(len(oid))})
-		pduBuf.Write(oid)
+		pduBuf.Write(marshalTLV(ObjectIdentifier, oid))
 		pduBuf.Write([]byte{Null, 0x00})
 	default:
 		return nil, fmt.Errorf("Unable to marshal PDU: unknown BER type %d", pdu.Type)
 	}
 
-	return pduBuf.Bytes(), nil
+	return marshalTLV(Sequence, pduBuf.Bytes()), nil
+}
+
+// marshalTLV encodes a BER field from its type and contents
+func marshalTLV(berType Asn1BER, data []byte) []byte {
+	ret := make([]byte, 0, len(data)+6)
+	ret = append(ret, byte(berType))
+	ret = append(ret, marshalLength(len(data))...)
+	return append(ret, data...)
 }
 
-func oidToString(oid []int) (ret string) {
-	values := make([]interface{}, len(oid))
-	for i, v := range oid {
-		values[i] = v
+// marshalLength encodes a BER length, using the long form for lengths of
+// 128 bytes or more. The long form sets the most significant bit of the
+// first byte and uses the 7 least significant bits to show how many bytes
+// will be used to represent the length.
+func marshalLength(length int) []byte {
+	if length < 128 {
+		return []byte{byte(length)}
 	}
-	return fmt.Sprintf(strings.Repeat(".%d", len(oid)), values...)
+
+	var lengthBytes []byte
+	for ; length > 0; length >>= 8 {
+		lengthBytes = append([]byte{byte(length)}, lengthBytes...)
+	}
+	return append([]byte{byte(0x80 | len(lengthBytes))}, lengthBytes...)
 }
 
 func marshalOID(oid string) ([]byte, error) {
-	var err error
-
 	// Encode the oid
-	oid = strings.Trim(oid, ".")
-	oidParts := strings.Split(oid, ".")
-	oidBytes := make([]int, len(oidParts))
+	oidBytes, err := ParseOID(oid)
 
-	// Convert the string OID to an array of integers
-	for i := 0; i < len(oidParts); i++ {
-		oidBytes[i], err = strconv.Atoi(oidParts[i])
-		if err != nil {
-			return nil, fmt.Errorf("Unable to parse OID: %s\n", err.Error())
-		}
+	if err != nil {
+		return nil, err
 	}
 
 	mOid, err := marshalObjectIdentifier(oidBytes)
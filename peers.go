@@ -0,0 +1,161 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// PeerSnapshot is the state of the routing protocol sessions of a router at
+// one time
+type PeerSnapshot struct {
+	Time          time.Time
+	BGPPeers      []*BGPPeer
+	OSPFNeighbors []*OSPFNeighbor
+}
+
+// PeerEventType is the kind of change of a routing protocol session
+type PeerEventType uint8
+
+const (
+	// PeerUp marks a session that came up
+	PeerUp PeerEventType = iota
+	// PeerDown marks a session that went down or disappeared
+	PeerDown
+	// PeerFlapped marks a BGP session that went down and came back up
+	// between the snapshots
+	PeerFlapped
+)
+
+var peerEventTypeStrings = map[PeerEventType]string{
+	PeerUp:      "Up",
+	PeerDown:    "Down",
+	PeerFlapped: "Flapped",
+}
+
+func (t PeerEventType) String() string {
+	return peerEventTypeStrings[t]
+}
+
+// PeerEvent is a change of a routing protocol session between two snapshots
+type PeerEvent struct {
+	Type PeerEventType
+	// Protocol is "bgp" or "ospf"
+	Protocol string
+	// Peer is the remote address of a BGP peer or the address of an OSPF
+	// neighbour
+	Peer net.IP
+	// From and To are the states of the session in the old and new
+	// snapshot, such as "established" and "idle", or empty if it is not in
+	// the snapshot
+	From string
+	To   string
+}
+
+func (e PeerEvent) String() string {
+	return fmt.Sprintf("%s %s %s (%s -> %s)", e.Protocol, e.Peer, e.Type, e.From, e.To)
+}
+
+// PeerSnapshot retrieves the BGP peers and OSPF neighbours of the target.
+// Agents without BGP4-MIB or OSPF-MIB return a snapshot without them.
+//
+// If a request fails, what was retrieved so far is returned along with the
+// error.
+func (x *GoSNMP) PeerSnapshot() (*PeerSnapshot, error) {
+	s := &PeerSnapshot{Time: time.Now()}
+	var err error
+	if s.BGPPeers, err = x.BGPPeers(); err != nil {
+		return s, err
+	}
+	s.OSPFNeighbors, err = x.OSPFNeighbors()
+	return s, err
+}
+
+// ComparePeers returns the sessions that came up, went down or flapped
+// between two snapshots of the same router, BGP peers first. A BGP peer is
+// up while it is established and an OSPF neighbour while OSPFNeighbor.Up is
+// true. There are no events if before is nil, as for the first poll.
+func ComparePeers(before, after *PeerSnapshot) []PeerEvent {
+	if before == nil || after == nil {
+		return nil
+	}
+	var events []PeerEvent
+
+	oldBGP := make(map[string]*BGPPeer)
+	for _, p := range before.BGPPeers {
+		oldBGP[p.RemoteAddr.String()] = p
+	}
+	for _, p := range after.BGPPeers {
+		key := p.RemoteAddr.String()
+		prev := oldBGP[key]
+		delete(oldBGP, key)
+		e := PeerEvent{Protocol: "bgp", Peer: p.RemoteAddr, To: p.State.String()}
+		switch {
+		case prev == nil:
+			if !p.Established() {
+				continue
+			}
+			e.Type = PeerUp
+		case prev.Established() && p.Established():
+			// Unless the session was reset between the snapshots
+			if p.EstablishedTransitions <= prev.EstablishedTransitions && p.EstablishedTime >= prev.EstablishedTime {
+				continue
+			}
+			e.Type = PeerFlapped
+		case prev.Established() != p.Established():
+			e.Type = PeerDown
+			if p.Established() {
+				e.Type = PeerUp
+			}
+		default:
+			continue
+		}
+		if prev != nil {
+			e.From = prev.State.String()
+		}
+		events = append(events, e)
+	}
+	for _, p := range before.BGPPeers {
+		if oldBGP[p.RemoteAddr.String()] != nil && p.Established() {
+			events = append(events, PeerEvent{Type: PeerDown, Protocol: "bgp", Peer: p.RemoteAddr, From: p.State.String()})
+		}
+	}
+
+	oldOSPF := make(map[string]*OSPFNeighbor)
+	for _, n := range before.OSPFNeighbors {
+		oldOSPF[ospfNeighborKey(n)] = n
+	}
+	for _, n := range after.OSPFNeighbors {
+		key := ospfNeighborKey(n)
+		prev := oldOSPF[key]
+		delete(oldOSPF, key)
+		wasUp := prev != nil && prev.Up()
+		if wasUp == n.Up() {
+			continue
+		}
+		e := PeerEvent{Type: PeerDown, Protocol: "ospf", Peer: n.Address, To: n.State.String()}
+		if n.Up() {
+			e.Type = PeerUp
+		}
+		if prev != nil {
+			e.From = prev.State.String()
+		}
+		events = append(events, e)
+	}
+	for _, n := range before.OSPFNeighbors {
+		if oldOSPF[ospfNeighborKey(n)] != nil && n.Up() {
+			events = append(events, PeerEvent{Type: PeerDown, Protocol: "ospf", Peer: n.Address, From: n.State.String()})
+		}
+	}
+	return events
+}
+
+// ospfNeighborKey identifies a neighbour by its ospfNbrTable index
+func ospfNeighborKey(n *OSPFNeighbor) string {
+	return n.Address.String() + "/" + strconv.Itoa(n.AddressLessIndex)
+}
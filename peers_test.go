@@ -0,0 +1,55 @@
+package gosnmp
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test detecting sessions that came up, went down or flapped
+func TestComparePeers(t *testing.T) {
+	a, b, c, d := net.IP{192, 0, 2, 1}, net.IP{192, 0, 2, 2}, net.IP{192, 0, 2, 3}, net.IP{192, 0, 2, 4}
+	before := &PeerSnapshot{
+		BGPPeers: []*BGPPeer{
+			{RemoteAddr: a, State: BGPEstablished, EstablishedTransitions: 1, EstablishedTime: time.Hour},
+			{RemoteAddr: b, State: BGPActive},
+			{RemoteAddr: c, State: BGPEstablished, EstablishedTransitions: 1, EstablishedTime: time.Hour},
+			{RemoteAddr: d, State: BGPEstablished, EstablishedTransitions: 2, EstablishedTime: time.Hour},
+		},
+		OSPFNeighbors: []*OSPFNeighbor{
+			{Address: a, State: OSPFNeighborFull},
+			{Address: b, State: OSPFNeighborTwoWay},
+		},
+	}
+	after := &PeerSnapshot{
+		BGPPeers: []*BGPPeer{
+			{RemoteAddr: a, State: BGPIdle, EstablishedTransitions: 1},
+			{RemoteAddr: b, State: BGPEstablished, EstablishedTransitions: 1},
+			{RemoteAddr: c, State: BGPEstablished, EstablishedTransitions: 2, EstablishedTime: time.Minute},
+		},
+		OSPFNeighbors: []*OSPFNeighbor{
+			{Address: a, State: OSPFNeighborInit},
+			{Address: b, State: OSPFNeighborFull},
+			{Address: c, State: OSPFNeighborLoading},
+		},
+	}
+	want := []PeerEvent{
+		{Type: PeerDown, Protocol: "bgp", Peer: a, From: "established", To: "idle"},
+		{Type: PeerUp, Protocol: "bgp", Peer: b, From: "active", To: "established"},
+		{Type: PeerFlapped, Protocol: "bgp", Peer: c, From: "established", To: "established"},
+		{Type: PeerDown, Protocol: "bgp", Peer: d, From: "established"},
+		{Type: PeerDown, Protocol: "ospf", Peer: a, From: "full", To: "init"},
+		{Type: PeerUp, Protocol: "ospf", Peer: c, To: "loading"},
+	}
+	events := ComparePeers(before, after)
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("ComparePeers:\n\twant: %v\n\tgot : %v", want, events)
+	}
+	if s := events[0].String(); s != "bgp 192.0.2.1 Down (established -> idle)" {
+		t.Errorf("String: %s", s)
+	}
+	if events := ComparePeers(nil, after); events != nil {
+		t.Errorf("First snapshot: %v", events)
+	}
+}
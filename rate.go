@@ -0,0 +1,190 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"sync"
+	"time"
+)
+
+// CounterSample is one poll of a Counter32 or Counter64 variable
+type CounterSample struct {
+	// Time is when the counter was polled
+	Time    time.Time
+	Counter SnmpPDU
+	// SysUpTime is SNMPv2-MIB::sysUpTime.0, polled in the same request as
+	// the counter. It is used to detect agent restarts, and ignored unless
+	// it is a TimeTicks.
+	SysUpTime SnmpPDU
+	// DiscontinuityTime is the discontinuity indicator of the counter, such
+	// as IF-MIB::ifCounterDiscontinuityTime, polled in the same request. It
+	// is used to detect counter resets, and ignored unless it is a
+	// TimeTicks.
+	DiscontinuityTime SnmpPDU
+}
+
+// CounterFlag tells whether a counter delta can be trusted, and if not, why
+type CounterFlag uint8
+
+const (
+	// CounterValid marks a delta that can be trusted
+	CounterValid CounterFlag = iota
+	// CounterNoPrevious marks the first sample of a counter
+	CounterNoPrevious
+	// CounterNotCounter marks samples that are not both Counter32 or both
+	// Counter64, such as exception values
+	CounterNotCounter
+	// CounterNoInterval marks a sample that is not later than the previous
+	CounterNoInterval
+	// CounterRestarted marks a sample taken after the agent restarted, as
+	// sysUpTime advanced by less than the interval
+	CounterRestarted
+	// CounterDiscontinuity marks a sample taken after the discontinuity time
+	// of the counter changed
+	CounterDiscontinuity
+	// CounterReset marks a Counter64 that went backwards, which it does not
+	// do by wrapping in practice
+	CounterReset
+)
+
+var counterFlagStrings = map[CounterFlag]string{
+	CounterValid:         "Valid",
+	CounterNoPrevious:    "NoPrevious",
+	CounterNotCounter:    "NotCounter",
+	CounterNoInterval:    "NoInterval",
+	CounterRestarted:     "Restarted",
+	CounterDiscontinuity: "Discontinuity",
+	CounterReset:         "Reset",
+}
+
+func (f CounterFlag) String() string {
+	return counterFlagStrings[f]
+}
+
+// CounterChange is the change of a counter between two samples
+type CounterChange struct {
+	Delta    uint64
+	Interval time.Duration
+	// Wrapped is set if a Counter32 wrapped around between the samples
+	Wrapped bool
+	// Flag is CounterValid unless the delta cannot be trusted, in which case
+	// Delta is zero
+	Flag CounterFlag
+}
+
+// Valid returns true if the change can be trusted
+func (c CounterChange) Valid() bool {
+	return c.Flag == CounterValid
+}
+
+// Rate returns the change per second, or zero if it cannot be trusted
+func (c CounterChange) Rate() float64 {
+	if !c.Valid() || c.Interval <= 0 {
+		return 0
+	}
+	return float64(c.Delta) / c.Interval.Seconds()
+}
+
+// CounterDelta computes the change of a counter between two successive
+// samples. A Counter32 that went backwards is taken to have wrapped once,
+// unless sysUpTime or the discontinuity time shows that the agent restarted
+// or the counter was reset. The interval is measured by the sample times.
+//
+// The agent is taken to have restarted if sysUpTime advanced by clearly less
+// than the interval, by more than a tenth of it plus a second, as it does
+// when it went backwards or when the agent rebooted early in the interval.
+// sysUpTime wraps around after 2^32 hundredths of a second, about 497 days;
+// it going backwards is taken as a wrap rather than a restart if the time it
+// advanced by when wrapped matches the interval within the same margin.
+func CounterDelta(prev, cur CounterSample) CounterChange {
+	change := CounterChange{Interval: cur.Time.Sub(prev.Time)}
+
+	before, ok1 := prev.Counter.Value.(uint64)
+	after, ok2 := cur.Counter.Value.(uint64)
+	switch {
+	case !ok1 || !ok2 || prev.Counter.Type != cur.Counter.Type ||
+		(cur.Counter.Type != Counter32 && cur.Counter.Type != Counter64):
+		change.Flag = CounterNotCounter
+	case change.Interval <= 0:
+		change.Flag = CounterNoInterval
+	case sysUpTimeRestarted(prev.SysUpTime, cur.SysUpTime, change.Interval):
+		change.Flag = CounterRestarted
+	case timeTicksChanged(prev.DiscontinuityTime, cur.DiscontinuityTime):
+		change.Flag = CounterDiscontinuity
+	case after >= before:
+		change.Delta = after - before
+	case cur.Counter.Type == Counter32:
+		change.Delta = after + 1<<32 - before
+		change.Wrapped = true
+	default:
+		change.Flag = CounterReset
+	}
+	return change
+}
+
+// timeTicks returns the value of a TimeTicks variable
+func timeTicks(pdu SnmpPDU) (int, bool) {
+	n, ok := pdu.Value.(int)
+	return n, ok && pdu.Type == TimeTicks
+}
+
+// sysUpTimeRestarted returns true if sysUpTime advanced by less than the
+// interval, or went backwards other than by wrapping around
+func sysUpTimeRestarted(prev, cur SnmpPDU, interval time.Duration) bool {
+	before, ok1 := timeTicks(prev)
+	after, ok2 := timeTicks(cur)
+	if !ok1 || !ok2 {
+		return false
+	}
+	// Computed on the unsigned TimeTicks, as int is 32 bits on some
+	// platforms, so that a wrap is accounted for
+	advanced := TimeTicksValue(uint32(after) - uint32(before)).Duration()
+	margin := interval/10 + time.Second
+	if advanced < interval-margin {
+		return true
+	}
+	return uint32(after) < uint32(before) && advanced > interval+margin
+}
+
+func timeTicksChanged(prev, cur SnmpPDU) bool {
+	before, ok1 := timeTicks(prev)
+	after, ok2 := timeTicks(cur)
+	return ok1 && ok2 && after != before
+}
+
+// CounterTracker keeps the last sample of each counter, by name, to compute
+// the change of each new sample. It is safe for concurrent use.
+type CounterTracker struct {
+	mu   sync.Mutex
+	last map[string]CounterSample
+}
+
+// NewCounterTracker returns an empty CounterTracker
+func NewCounterTracker() *CounterTracker {
+	return &CounterTracker{last: make(map[string]CounterSample)}
+}
+
+// Add records a sample and returns the change since the previous sample of
+// the same counter. The first sample of a counter is flagged
+// CounterNoPrevious.
+func (t *CounterTracker) Add(s CounterSample) CounterChange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev, ok := t.last[s.Counter.Name]
+	t.last[s.Counter.Name] = s
+	if !ok {
+		return CounterChange{Flag: CounterNoPrevious}
+	}
+	return CounterDelta(prev, s)
+}
+
+// Forget drops the last sample of a counter, such as one of an interface
+// that was removed
+func (t *CounterTracker) Forget(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.last, name)
+}
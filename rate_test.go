@@ -0,0 +1,145 @@
+package gosnmp
+
+import (
+	"testing"
+	"time"
+)
+
+func counterSample(at time.Duration, typ Asn1BER, value uint64, uptime int) CounterSample {
+	s := CounterSample{
+		Time:    time.Unix(1700000000, 0).Add(at),
+		Counter: SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.10.1", Type: typ, Value: value},
+	}
+	if uptime >= 0 {
+		s.SysUpTime = SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: TimeTicks, Value: uptime}
+	}
+	return s
+}
+
+// Test computing counter deltas across wraps, restarts and resets
+func TestCounterDelta(t *testing.T) {
+	// sysUpTime 5 seconds before it wraps around, set after counterSample
+	// as it is negative as an int on 32-bit platforms
+	ticks := uint32(1<<32 - 500)
+	beforeWrap := counterSample(0, Counter32, 1000, -1)
+	beforeWrap.SysUpTime = SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: TimeTicks, Value: int(ticks)}
+	tests := []struct {
+		desc      string
+		prev, cur CounterSample
+		want      CounterChange
+	}{
+		{
+			"increase",
+			counterSample(0, Counter32, 1000, 100),
+			counterSample(10*time.Second, Counter32, 11000, 1100),
+			CounterChange{Delta: 10000, Interval: 10 * time.Second},
+		},
+		{
+			"32-bit wrap",
+			counterSample(0, Counter32, 0xfffffff0, 100),
+			counterSample(10*time.Second, Counter32, 0x10, 1100),
+			CounterChange{Delta: 0x20, Interval: 10 * time.Second, Wrapped: true},
+		},
+		{
+			"32-bit wrap without sysUpTime",
+			counterSample(0, Counter32, 0xfffffff0, -1),
+			counterSample(10*time.Second, Counter32, 0x10, -1),
+			CounterChange{Delta: 0x20, Interval: 10 * time.Second, Wrapped: true},
+		},
+		{
+			"agent restart",
+			counterSample(0, Counter32, 0xfffffff0, 100000),
+			counterSample(10*time.Second, Counter32, 0x10, 500),
+			CounterChange{Interval: 10 * time.Second, Flag: CounterRestarted},
+		},
+		{
+			"sysUpTime wrap",
+			beforeWrap,
+			counterSample(10*time.Second, Counter32, 11000, 500),
+			CounterChange{Delta: 10000, Interval: 10 * time.Second},
+		},
+		{
+			"agent restart near sysUpTime wrap",
+			beforeWrap,
+			counterSample(10*time.Second, Counter32, 11000, 100),
+			CounterChange{Interval: 10 * time.Second, Flag: CounterRestarted},
+		},
+		{
+			"agent restart early in the interval",
+			counterSample(0, Counter32, 0xfffffff0, 1000),
+			counterSample(5*time.Minute, Counter32, 0x10, 6000),
+			CounterChange{Interval: 5 * time.Minute, Flag: CounterRestarted},
+		},
+		{
+			"64-bit increase",
+			counterSample(0, Counter64, 1<<40, 100),
+			counterSample(5*time.Second, Counter64, 1<<40+500, 600),
+			CounterChange{Delta: 500, Interval: 5 * time.Second},
+		},
+		{
+			"64-bit reset",
+			counterSample(0, Counter64, 1<<40, 100),
+			counterSample(5*time.Second, Counter64, 10, 600),
+			CounterChange{Interval: 5 * time.Second, Flag: CounterReset},
+		},
+		{
+			"type change",
+			counterSample(0, Counter32, 10, 100),
+			counterSample(5*time.Second, Counter64, 20, 600),
+			CounterChange{Interval: 5 * time.Second, Flag: CounterNotCounter},
+		},
+		{
+			"exception value",
+			counterSample(0, Counter32, 10, 100),
+			CounterSample{Time: time.Unix(1700000005, 0), Counter: SnmpPDU{Type: NoSuchInstance}},
+			CounterChange{Interval: 5 * time.Second, Flag: CounterNotCounter},
+		},
+		{
+			"out of order",
+			counterSample(5*time.Second, Counter32, 10, 100),
+			counterSample(0, Counter32, 20, 600),
+			CounterChange{Interval: -5 * time.Second, Flag: CounterNoInterval},
+		},
+	}
+
+	for _, test := range tests {
+		if got := CounterDelta(test.prev, test.cur); got != test.want {
+			t.Errorf("%s:\n\twant: %+v\n\tgot : %+v", test.desc, test.want, got)
+		}
+	}
+
+	prev := counterSample(0, Counter32, 10, 100)
+	cur := counterSample(10*time.Second, Counter32, 20, 1100)
+	prev.DiscontinuityTime = SnmpPDU{Type: TimeTicks, Value: 0}
+	cur.DiscontinuityTime = SnmpPDU{Type: TimeTicks, Value: 1050}
+	if got := CounterDelta(prev, cur); got.Flag != CounterDiscontinuity || got.Valid() || got.Rate() != 0 {
+		t.Errorf("Discontinuity: %+v", got)
+	}
+}
+
+// Test tracking successive samples of several counters
+func TestCounterTracker(t *testing.T) {
+	tracker := NewCounterTracker()
+
+	if got := tracker.Add(counterSample(0, Counter64, 1000, 100)); got.Flag != CounterNoPrevious {
+		t.Errorf("First sample: %+v", got)
+	}
+	got := tracker.Add(counterSample(4*time.Second, Counter64, 3000, 500))
+	if !got.Valid() || got.Rate() != 500 {
+		t.Errorf("Second sample: %+v rate %v", got, got.Rate())
+	}
+
+	other := counterSample(4*time.Second, Counter64, 5, 500)
+	other.Counter.Name = ".1.3.6.1.2.1.2.2.1.10.2"
+	if got := tracker.Add(other); got.Flag != CounterNoPrevious {
+		t.Errorf("Other counter: %+v", got)
+	}
+
+	tracker.Forget(other.Counter.Name)
+	if got := tracker.Add(other); got.Flag != CounterNoPrevious {
+		t.Errorf("Forgotten counter: %+v", got)
+	}
+	if CounterRestarted.String() != "Restarted" {
+		t.Errorf("CounterFlag.String: %s", CounterRestarted)
+	}
+}
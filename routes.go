@@ -0,0 +1,319 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+var (
+	oidInetCidrRouteTable   = MustParseOID(".1.3.6.1.2.1.4.24.7")
+	oidIpCidrRouteTable     = MustParseOID(".1.3.6.1.2.1.4.24.4")
+	oidIpRouteTable         = MustParseOID(".1.3.6.1.2.1.4.21")
+	oidIpAddressTable       = MustParseOID(".1.3.6.1.2.1.4.34")
+	oidIpAddrTable          = MustParseOID(".1.3.6.1.2.1.4.20")
+	oidIpAddressPrefixEntry = MustParseOID(".1.3.6.1.2.1.4.32.1")
+)
+
+// Columns of inetCidrRouteTable and ipCidrRouteTable (RFC 4292), ipRouteTable
+// (RFC 1213), and ipAddressTable and ipAddrTable (RFC 4293)
+const (
+	inetCidrRouteIfIndex   = 7
+	inetCidrRouteType      = 8
+	inetCidrRouteProto     = 9
+	inetCidrRouteAge       = 10
+	inetCidrRouteNextHopAS = 11
+	inetCidrRouteMetric1   = 12
+
+	ipCidrRouteIfIndex   = 5
+	ipCidrRouteType      = 6
+	ipCidrRouteProto     = 7
+	ipCidrRouteAge       = 8
+	ipCidrRouteNextHopAS = 10
+	ipCidrRouteMetric1   = 11
+
+	ipRouteIfIndex = 2
+	ipRouteMetric1 = 3
+	ipRouteNextHop = 7
+	ipRouteType    = 8
+	ipRouteProto   = 9
+	ipRouteAge     = 10
+	ipRouteMask    = 11
+
+	ipAddressIfIndex = 3
+	ipAddressType    = 4
+	ipAddressPrefix  = 5
+	ipAddressOrigin  = 6
+	ipAddressStatus  = 7
+
+	ipAdEntIfIndex = 2
+	ipAdEntNetMask = 3
+)
+
+// Route types of inetCidrRouteType. The types of ipCidrRouteType and
+// ipRouteType, whose direct(3) and indirect(4) are local and remote, are
+// mapped to them.
+const (
+	RouteOther     = 1
+	RouteReject    = 2
+	RouteLocal     = 3
+	RouteRemote    = 4
+	RouteBlackhole = 5
+)
+
+// RouteProtocol is the routing protocol a route was learned through, an
+// IANAipRouteProtocol such as 13 for ospf
+type RouteProtocol int
+
+// String returns the label of the protocol, such as "ospf"
+func (p RouteProtocol) String() string {
+	if name, ok := BuiltinMIBs().typeSyntax("IANAipRouteProtocol").EnumName(int64(p)); ok {
+		return name
+	}
+	return strconv.Itoa(int(p))
+}
+
+// Route is an entry of the IP routing table of a device
+type Route struct {
+	Dest *net.IPNet
+	// NextHop is the unspecified address, 0.0.0.0 or ::, for routes to
+	// directly connected networks
+	NextHop net.IP
+	// IfIndex is the interface of the next hop, or zero if unknown
+	IfIndex int
+	// Type is RouteOther, RouteReject, RouteLocal, RouteRemote or
+	// RouteBlackhole
+	Type  int
+	Proto RouteProtocol
+	// Age is the time since the route was last updated
+	Age time.Duration
+	// NextHopAS is the autonomous system of the next hop, or zero if
+	// unknown
+	NextHopAS uint32
+	// Metric1 is the primary metric of the routing protocol, or -1 if
+	// unused
+	Metric1 int
+}
+
+// InterfaceAddress is an address of an interface of a device
+type InterfaceAddress struct {
+	Address net.IP
+	// Prefix is the subnet of the address, or nil if unknown
+	Prefix  *net.IPNet
+	IfIndex int
+	// Type is unicast(1), anycast(2) or broadcast(3)
+	Type int
+	// Origin is other(1), manual(2), dhcp(4), linklayer(5) or random(6),
+	// and Status preferred(1) or another IpAddressStatusTC. Both are zero
+	// for addresses of ipAddrTable.
+	Origin int
+	Status int
+}
+
+// Routes retrieves the IPv4 and IPv6 routing table of the target from
+// inetCidrRouteTable, falling back to the IPv4 only ipCidrRouteTable and
+// ipRouteTable on older agents. Routes of address types other than IPv4
+// and IPv6, and invalid routes of ipRouteTable, are left out.
+//
+// If a request fails, the routes retrieved so far are returned along with
+// the error.
+func (x *GoSNMP) Routes() ([]*Route, error) {
+	var routes []*Route
+	table, err := x.BulkGetTableOID(0, oidInetCidrRouteTable,
+		inetCidrRouteIfIndex, inetCidrRouteType, inetCidrRouteProto, inetCidrRouteAge, inetCidrRouteNextHopAS, inetCidrRouteMetric1)
+	if table == nil {
+		return nil, err
+	}
+	// Indexed by destination, prefix length, policy and next hop
+	spec := IndexSpec{{Kind: IndexInetAddress}, {Kind: IndexInteger}, {Kind: IndexOID}, {Kind: IndexInetAddress}}
+	for _, row := range table.Rows {
+		values, decodeErr := row.DecodeIndex(spec)
+		if decodeErr != nil {
+			continue
+		}
+		dest := prefixOf(values[0].(InetAddress).IP(), int(values[1].(uint32)))
+		nextHop := values[3].(InetAddress).IP()
+		if dest == nil || nextHop == nil {
+			continue
+		}
+		r := &Route{Dest: dest, NextHop: nextHop}
+		r.IfIndex = tableInt(table, row, inetCidrRouteIfIndex)
+		r.Type = tableInt(table, row, inetCidrRouteType)
+		r.Proto = RouteProtocol(tableInt(table, row, inetCidrRouteProto))
+		age, _ := tableUint(table, row, inetCidrRouteAge)
+		r.Age = time.Duration(age) * time.Second
+		as, _ := tableUint(table, row, inetCidrRouteNextHopAS)
+		r.NextHopAS = uint32(as)
+		r.Metric1 = tableMetric(table, row, inetCidrRouteMetric1)
+		routes = append(routes, r)
+	}
+	if err != nil || len(routes) > 0 {
+		return routes, err
+	}
+
+	table, err = x.BulkGetTableOID(0, oidIpCidrRouteTable,
+		ipCidrRouteIfIndex, ipCidrRouteType, ipCidrRouteProto, ipCidrRouteAge, ipCidrRouteNextHopAS, ipCidrRouteMetric1)
+	if table == nil {
+		return nil, err
+	}
+	// Indexed by destination, mask, TOS and next hop
+	for _, row := range table.Rows {
+		if len(row.Index) != 13 {
+			continue
+		}
+		r := &Route{
+			Dest:    &net.IPNet{IP: oidIP(row.Index[0:4]), Mask: net.IPMask(oidIP(row.Index[4:8]))},
+			NextHop: oidIP(row.Index[9:13]),
+		}
+		r.IfIndex = tableInt(table, row, ipCidrRouteIfIndex)
+		r.Type = tableInt(table, row, ipCidrRouteType)
+		r.Proto = RouteProtocol(tableInt(table, row, ipCidrRouteProto))
+		r.Age = time.Duration(tableInt(table, row, ipCidrRouteAge)) * time.Second
+		r.NextHopAS = uint32(tableInt(table, row, ipCidrRouteNextHopAS))
+		r.Metric1 = tableMetric(table, row, ipCidrRouteMetric1)
+		routes = append(routes, r)
+	}
+	if err != nil || len(routes) > 0 {
+		return routes, err
+	}
+
+	table, err = x.BulkGetTableOID(0, oidIpRouteTable,
+		ipRouteIfIndex, ipRouteMetric1, ipRouteNextHop, ipRouteType, ipRouteProto, ipRouteAge, ipRouteMask)
+	if table == nil {
+		return nil, err
+	}
+	// Indexed by destination, so only one route per destination is listed
+	for _, row := range table.Rows {
+		// ipRouteType invalid(2)
+		if len(row.Index) != 4 || tableInt(table, row, ipRouteType) == 2 {
+			continue
+		}
+		r := &Route{Dest: &net.IPNet{IP: oidIP(row.Index), Mask: net.IPv4Mask(255, 255, 255, 255)}}
+		if mask := tableIP(table, row, ipRouteMask); mask != nil {
+			r.Dest.Mask = net.IPMask(mask)
+		}
+		r.NextHop = tableIP(table, row, ipRouteNextHop)
+		r.IfIndex = tableInt(table, row, ipRouteIfIndex)
+		r.Type = tableInt(table, row, ipRouteType)
+		r.Proto = RouteProtocol(tableInt(table, row, ipRouteProto))
+		r.Age = time.Duration(tableInt(table, row, ipRouteAge)) * time.Second
+		r.Metric1 = tableMetric(table, row, ipRouteMetric1)
+		routes = append(routes, r)
+	}
+	return routes, err
+}
+
+// IPAddresses retrieves the IPv4 and IPv6 addresses of the interfaces of the
+// target from ipAddressTable, falling back to the IPv4 only ipAddrTable on
+// older agents. The subnet of an address is taken from the
+// ipAddressPrefixTable row it points to, or from ipAdEntNetMask.
+//
+// If a request fails, the addresses retrieved so far are returned along
+// with the error.
+func (x *GoSNMP) IPAddresses() ([]*InterfaceAddress, error) {
+	var addresses []*InterfaceAddress
+	table, err := x.BulkGetTableOID(0, oidIpAddressTable,
+		ipAddressIfIndex, ipAddressType, ipAddressPrefix, ipAddressOrigin, ipAddressStatus)
+	if table == nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		values, decodeErr := row.DecodeIndex(IndexSpec{{Kind: IndexInetAddress}})
+		if decodeErr != nil {
+			continue
+		}
+		ip := values[0].(InetAddress).IP()
+		if ip == nil {
+			continue
+		}
+		a := &InterfaceAddress{Address: ip}
+		a.IfIndex = tableInt(table, row, ipAddressIfIndex)
+		a.Type = tableInt(table, row, ipAddressType)
+		a.Prefix = addressPrefix(tableOID(table, row, ipAddressPrefix))
+		a.Origin = tableInt(table, row, ipAddressOrigin)
+		a.Status = tableInt(table, row, ipAddressStatus)
+		addresses = append(addresses, a)
+	}
+	if err != nil || len(addresses) > 0 {
+		return addresses, err
+	}
+
+	table, err = x.BulkGetTableOID(0, oidIpAddrTable, ipAdEntIfIndex, ipAdEntNetMask)
+	if table == nil {
+		return nil, err
+	}
+	for _, row := range table.Rows {
+		if len(row.Index) != 4 {
+			continue
+		}
+		// ipAddrTable only holds unicast(1) addresses
+		a := &InterfaceAddress{Address: oidIP(row.Index), Type: 1}
+		a.IfIndex = tableInt(table, row, ipAdEntIfIndex)
+		if mask := tableIP(table, row, ipAdEntNetMask); mask != nil {
+			a.Prefix = &net.IPNet{IP: a.Address.Mask(net.IPMask(mask)), Mask: net.IPMask(mask)}
+		}
+		addresses = append(addresses, a)
+	}
+	return addresses, err
+}
+
+// addressPrefix decodes the prefix an ipAddressPrefix RowPointer points to,
+// a column of ipAddressPrefixTable indexed by interface, prefix and length.
+// It returns nil for zeroDotZero, which means the prefix is unknown.
+func addressPrefix(pointer OID) *net.IPNet {
+	if !pointer.HasPrefix(oidIpAddressPrefixEntry) || len(pointer) <= len(oidIpAddressPrefixEntry) {
+		return nil
+	}
+	index := pointer[len(oidIpAddressPrefixEntry)+1:]
+	values, err := IndexSpec{{Kind: IndexInteger}, {Kind: IndexInetAddress}, {Kind: IndexInteger}}.Decode(index)
+	if err != nil {
+		return nil
+	}
+	return prefixOf(values[1].(InetAddress).IP(), int(values[2].(uint32)))
+}
+
+// prefixOf returns the network of the given prefix length, or nil if the
+// address is nil or the length does not fit it
+func prefixOf(ip net.IP, length int) *net.IPNet {
+	bits := 8 * net.IPv6len
+	if len(ip) == net.IPv4len {
+		bits = 8 * net.IPv4len
+	}
+	if ip == nil || length > bits {
+		return nil
+	}
+	mask := net.CIDRMask(length, bits)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// oidIP converts the four arcs of an IpAddress index to the address
+func oidIP(arcs OID) net.IP {
+	ip := make(net.IP, len(arcs))
+	for i, arc := range arcs {
+		ip[i] = byte(arc)
+	}
+	return ip
+}
+
+// tableIP returns an IpAddress cell
+func tableIP(t *Table, row *TableRow, column uint32) net.IP {
+	pdu, _ := t.Cell(row, column)
+	if ip, ok := pdu.Value.(net.IP); ok {
+		return ip.To4()
+	}
+	return nil
+}
+
+// tableMetric returns a routing metric cell, or -1 if it is missing
+func tableMetric(t *Table, row *TableRow, column uint32) int {
+	pdu, ok := t.Cell(row, column)
+	n, isInt := pdu.Value.(int)
+	if !ok || !isInt {
+		return -1
+	}
+	return n
+}
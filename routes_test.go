@@ -0,0 +1,104 @@
+package gosnmp
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// Test reading IPv4 and IPv6 routes from inetCidrRouteTable
+func TestRoutes(t *testing.T) {
+	v4 := ".1.4.10.0.0.0.8.2.0.0.1.4.192.0.2.1"
+	v6 := ".2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.0.32.2.0.0.2.16.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0"
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.24.7.1.7" + v4:  Integer32Value(2),
+		".1.3.6.1.2.1.4.24.7.1.8" + v4:  Integer32Value(RouteRemote),
+		".1.3.6.1.2.1.4.24.7.1.9" + v4:  Integer32Value(13),
+		".1.3.6.1.2.1.4.24.7.1.10" + v4: Gauge32Value(60),
+		".1.3.6.1.2.1.4.24.7.1.11" + v4: Gauge32Value(64512),
+		".1.3.6.1.2.1.4.24.7.1.12" + v4: Integer32Value(20),
+		".1.3.6.1.2.1.4.24.7.1.7" + v6:  Integer32Value(3),
+		".1.3.6.1.2.1.4.24.7.1.8" + v6:  Integer32Value(RouteLocal),
+		".1.3.6.1.2.1.4.24.7.1.9" + v6:  Integer32Value(2),
+	})
+	routes, err := agent.client().Routes()
+	if err != nil {
+		t.Fatalf("Routes: %s", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("Routes: %+v", routes)
+	}
+
+	r := routes[0]
+	if r.Dest.String() != "10.0.0.0/8" || !r.NextHop.Equal(net.ParseIP("192.0.2.1")) || r.IfIndex != 2 ||
+		r.Type != RouteRemote || r.Proto.String() != "ospf" || r.Age != time.Minute || r.NextHopAS != 64512 || r.Metric1 != 20 {
+		t.Errorf("IPv4 route: %+v", r)
+	}
+	r = routes[1]
+	if r.Dest.String() != "2001:db8::/32" || r.NextHop.String() != "::" || r.IfIndex != 3 ||
+		r.Type != RouteLocal || r.Proto.String() != "local" || r.Metric1 != -1 {
+		t.Errorf("IPv6 route: %+v", r)
+	}
+}
+
+// Test falling back to ipRouteTable, leaving out invalid routes
+func TestRoutesIPRouteTable(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.21.1.2.192.168.1.0":  Integer32Value(4),
+		".1.3.6.1.2.1.4.21.1.3.192.168.1.0":  Integer32Value(1),
+		".1.3.6.1.2.1.4.21.1.7.192.168.1.0":  IPAddressValue(net.ParseIP("192.168.0.1")),
+		".1.3.6.1.2.1.4.21.1.8.192.168.1.0":  Integer32Value(4),
+		".1.3.6.1.2.1.4.21.1.9.192.168.1.0":  Integer32Value(3),
+		".1.3.6.1.2.1.4.21.1.11.192.168.1.0": IPAddressValue(net.ParseIP("255.255.255.0")),
+		".1.3.6.1.2.1.4.21.1.8.192.168.2.0":  Integer32Value(2),
+	})
+	routes, err := agent.client().Routes()
+	if err != nil {
+		t.Fatalf("Routes: %s", err)
+	}
+	if len(routes) != 1 {
+		t.Fatalf("Routes: %+v", routes)
+	}
+	r := routes[0]
+	if r.Dest.String() != "192.168.1.0/24" || r.NextHop.String() != "192.168.0.1" || r.IfIndex != 4 ||
+		r.Type != RouteRemote || r.Proto.String() != "netmgmt" || r.Metric1 != 1 {
+		t.Errorf("ipRouteTable:\n\twant: %s via %s\n\tgot : %+v", "192.168.1.0/24", "192.168.0.1", r)
+	}
+}
+
+// Test reading interface addresses from ipAddressTable and ipAddrTable
+func TestIPAddresses(t *testing.T) {
+	addr := ".1.4.192.0.2.5"
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.34.1.3" + addr: Integer32Value(2),
+		".1.3.6.1.2.1.4.34.1.4" + addr: Integer32Value(1),
+		".1.3.6.1.2.1.4.34.1.5" + addr: ObjectIdentifierValue(MustParseOID(".1.3.6.1.2.1.4.32.1.5.2.1.4.192.0.2.0.24")),
+		".1.3.6.1.2.1.4.34.1.6" + addr: Integer32Value(2),
+		".1.3.6.1.2.1.4.34.1.7" + addr: Integer32Value(1),
+	})
+	addresses, err := agent.client().IPAddresses()
+	if err != nil {
+		t.Fatalf("IPAddresses: %s", err)
+	}
+	if len(addresses) != 1 {
+		t.Fatalf("IPAddresses: %+v", addresses)
+	}
+	a := addresses[0]
+	if a.Address.String() != "192.0.2.5" || a.Prefix.String() != "192.0.2.0/24" || a.IfIndex != 2 ||
+		a.Type != 1 || a.Origin != 2 || a.Status != 1 {
+		t.Errorf("ipAddressTable: %+v", a)
+	}
+
+	agent = newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.4.20.1.2.198.51.100.7": Integer32Value(5),
+		".1.3.6.1.2.1.4.20.1.3.198.51.100.7": IPAddressValue(net.ParseIP("255.255.255.128")),
+	})
+	addresses, err = agent.client().IPAddresses()
+	if err != nil {
+		t.Fatalf("IPAddresses: %s", err)
+	}
+	if len(addresses) != 1 || addresses[0].Address.String() != "198.51.100.7" ||
+		addresses[0].Prefix.String() != "198.51.100.0/25" || addresses[0].IfIndex != 5 {
+		t.Errorf("ipAddrTable: %+v", addresses)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scalars of the system group (RFC 3418)
+var (
+	oidSysDescr    = MustParseOID(".1.3.6.1.2.1.1.1.0")
+	oidSysObjectID = MustParseOID(".1.3.6.1.2.1.1.2.0")
+	oidSysContact  = MustParseOID(".1.3.6.1.2.1.1.4.0")
+	oidSysName     = MustParseOID(".1.3.6.1.2.1.1.5.0")
+	oidSysLocation = MustParseOID(".1.3.6.1.2.1.1.6.0")
+	oidSysServices = MustParseOID(".1.3.6.1.2.1.1.7.0")
+)
+
+// SystemInfo is the system group of SNMPv2-MIB. Fields are left empty for
+// objects the agent does not have.
+type SystemInfo struct {
+	Descr    string
+	ObjectID OID
+	UpTime   time.Duration
+	Contact  string
+	Name     string
+	Location string
+	Services Services
+}
+
+// Services is sysServices, the set of OSI layers the device offers services
+// at. Layer L is set as bit 2^(L-1).
+type Services uint8
+
+var serviceLayerNames = []string{
+	1: "physical",
+	2: "datalink",
+	3: "internet",
+	4: "end-to-end",
+	5: "session",
+	6: "presentation",
+	7: "applications",
+}
+
+// Has returns true if the device offers services at the layer, from 1 to 7
+func (s Services) Has(layer int) bool {
+	return layer >= 1 && layer <= 7 && s&(1<<uint(layer-1)) != 0
+}
+
+// Layers returns the layers the device offers services at, in order
+func (s Services) Layers() []int {
+	var layers []int
+	for layer := 1; layer <= 7; layer++ {
+		if s.Has(layer) {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+// String returns the layers by name, such as "datalink, internet"
+func (s Services) String() string {
+	var names []string
+	for _, layer := range s.Layers() {
+		names = append(names, serviceLayerNames[layer])
+	}
+	return strings.Join(names, ", ")
+}
+
+// GetSystemInfo retrieves the system group of the target in a single
+// request. Objects the agent does not have are left empty; for SNMPv1
+// agents, which reject the whole request, they are dropped and the request
+// is repeated.
+func (x *GoSNMP) GetSystemInfo() (*SystemInfo, error) {
+	oids := []OID{oidSysDescr, oidSysObjectID, oidSysUpTime, oidSysContact, oidSysName, oidSysLocation, oidSysServices}
+
+	varbinds, err := x.getScalars(oids)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SystemInfo{}
+	for _, vb := range varbinds {
+		switch v := vb.Value.(type) {
+		case OctetStringValue:
+			switch {
+			case vb.Name.Equal(oidSysDescr):
+				info.Descr = string(v)
+			case vb.Name.Equal(oidSysContact):
+				info.Contact = string(v)
+			case vb.Name.Equal(oidSysName):
+				info.Name = string(v)
+			case vb.Name.Equal(oidSysLocation):
+				info.Location = string(v)
+			}
+		case ObjectIdentifierValue:
+			if vb.Name.Equal(oidSysObjectID) {
+				info.ObjectID = OID(v)
+			}
+		case TimeTicksValue:
+			if vb.Name.Equal(oidSysUpTime) {
+				info.UpTime = v.Duration()
+			}
+		case Integer32Value:
+			if vb.Name.Equal(oidSysServices) {
+				info.Services = Services(v)
+			}
+		}
+	}
+	return info, nil
+}
+
+// getScalars retrieves variables in a single request. Variables an SNMPv1
+// agent does not have are dropped and the request repeated, so that they are
+// missing from the result as they would be exceptions with SNMPv2c.
+func (x *GoSNMP) getScalars(oids []OID) ([]VarBind, error) {
+	oids = append([]OID(nil), oids...)
+	for len(oids) > 0 {
+		res, err := x.GetValues(oids...)
+		if err != nil {
+			return nil, err
+		}
+		if SnmpError(res.Error) == NoError {
+			return res.VarBinds, nil
+		}
+		i := int(res.ErrorIndex) - 1
+		if SnmpError(res.Error) != NoSuchName || i < 0 || i >= len(oids) {
+			return nil, fmt.Errorf("Agent returned %s at index %d", SnmpError(res.Error), res.ErrorIndex)
+		}
+		oids = append(oids[:i], oids[i+1:]...)
+	}
+	return nil, nil
+}
@@ -0,0 +1,73 @@
+package gosnmp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test retrieving the system group as a SystemInfo
+func TestGetSystemInfo(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.1.1.0": OctetStringValue("Linux router 5.10"),
+		".1.3.6.1.2.1.1.2.0": ObjectIdentifierValue{1, 3, 6, 1, 4, 1, 8072, 3, 2, 10},
+		".1.3.6.1.2.1.1.3.0": TimeTicksValue(360000),
+		".1.3.6.1.2.1.1.4.0": OctetStringValue("noc@example.com"),
+		".1.3.6.1.2.1.1.5.0": OctetStringValue("router1"),
+		".1.3.6.1.2.1.1.6.0": OctetStringValue("Rack 4"),
+		".1.3.6.1.2.1.1.7.0": Integer32Value(72),
+	})
+	info, err := agent.client().GetSystemInfo()
+	if err != nil {
+		t.Fatalf("GetSystemInfo: %s", err)
+	}
+
+	want := &SystemInfo{
+		Descr:    "Linux router 5.10",
+		ObjectID: OID{1, 3, 6, 1, 4, 1, 8072, 3, 2, 10},
+		UpTime:   time.Hour,
+		Contact:  "noc@example.com",
+		Name:     "router1",
+		Location: "Rack 4",
+		Services: 72,
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("SystemInfo:\n\twant: %+v\n\tgot : %+v", want, info)
+	}
+	if layers := info.Services.Layers(); !reflect.DeepEqual(layers, []int{4, 7}) {
+		t.Errorf("Services.Layers:\n\twant: %v\n\tgot : %v", []int{4, 7}, layers)
+	}
+	if s := info.Services.String(); s != "end-to-end, applications" {
+		t.Errorf("Services.String:\n\twant: %s\n\tgot : %s", "end-to-end, applications", s)
+	}
+}
+
+// Test that objects missing from the agent are left empty
+func TestGetSystemInfoMissing(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.1.1.0": OctetStringValue("switch"),
+		".1.3.6.1.2.1.1.3.0": TimeTicksValue(100),
+		".1.3.6.1.2.1.1.7.0": Integer32Value(2),
+	})
+	info, err := agent.client().GetSystemInfo()
+	if err != nil {
+		t.Fatalf("GetSystemInfo: %s", err)
+	}
+	want := &SystemInfo{Descr: "switch", UpTime: time.Second, Services: 2}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("SystemInfo:\n\twant: %+v\n\tgot : %+v", want, info)
+	}
+}
+
+// Test decoding the sysServices layers
+func TestServicesHas(t *testing.T) {
+	s := Services(6)
+	for layer, want := range map[int]bool{0: false, 1: false, 2: true, 3: true, 4: false, 8: false} {
+		if got := s.Has(layer); got != want {
+			t.Errorf("Has(%d):\n\twant: %v\n\tgot : %v", layer, want, got)
+		}
+	}
+	if s.String() != "datalink, internet" {
+		t.Errorf("String: %s", s)
+	}
+}
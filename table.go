@@ -0,0 +1,319 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Table is a conceptual SNMP table retrieved by GetTable
+type Table struct {
+	// OID of the table, such as ifTable (.1.3.6.1.2.1.2.2)
+	OID OID
+	// Columns holds the column numbers retrieved, in the order of the cells
+	// of each row
+	Columns []uint32
+	// Rows are sorted by index
+	Rows []*TableRow
+
+	rows map[string]*TableRow
+	// sysUpTime requests sysUpTime.0 alongside the columns, see
+	// TableRow.sysUpTime
+	sysUpTime bool
+}
+
+// TableRow is a row of a Table
+type TableRow struct {
+	// Index is the instance suffix shared by the cells of the row
+	Index OID
+	// Cells holds one cell per column of the table, in the same order
+	Cells []TableCell
+
+	// sysUpTime is the sysUpTime.0 of the last response with cells of the
+	// row, if requested
+	sysUpTime SnmpPDU
+}
+
+// TableCell is a single value of a TableRow. Missing is set for sparse cells
+// that the agent has no value for.
+type TableCell struct {
+	SnmpPDU
+	Missing bool
+}
+
+// Row returns the row with the given index, or nil if there is none
+func (t *Table) Row(index OID) *TableRow {
+	return t.rows[index.String()]
+}
+
+// Cell returns the value of a column of the row. The boolean is false if the
+// column was not retrieved or the cell is missing.
+func (t *Table) Cell(row *TableRow, column uint32) (SnmpPDU, bool) {
+	for i, c := range t.Columns {
+		if c == column && !row.Cells[i].Missing {
+			return row.Cells[i].SnmpPDU, true
+		}
+	}
+	return SnmpPDU{}, false
+}
+
+// GetTable retrieves the given columns of a table, such as 2 (ifDescr) and 8
+// (ifOperStatus) of ifTable, walking them in lockstep with one multi-variable
+// GetNext request per row. Without columns, the whole table is walked.
+//
+// If a request fails, the rows received up to that point are returned along
+// with the error.
+func (x *GoSNMP) GetTable(table string, columns ...uint32) (*Table, error) {
+	return x.getTable(table, false, 0, columns)
+}
+
+// BulkGetTable is like GetTable, but uses GetBulk requests fetching up to
+// maxRepetitions rows at a time
+func (x *GoSNMP) BulkGetTable(maxRepetitions uint8, table string, columns ...uint32) (*Table, error) {
+	return x.getTable(table, true, maxRepetitions, columns)
+}
+
+// GetTableOID is like GetTable, but takes the OID of the table
+func (x *GoSNMP) GetTableOID(table OID, columns ...uint32) (*Table, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("No OID given\n")
+	}
+	return x.fetchTable(&Table{OID: table}, false, 0, columns)
+}
+
+// BulkGetTableOID is like BulkGetTable, but takes the OID of the table
+func (x *GoSNMP) BulkGetTableOID(maxRepetitions uint8, table OID, columns ...uint32) (*Table, error) {
+	if len(table) == 0 {
+		return nil, fmt.Errorf("No OID given\n")
+	}
+	return x.fetchTable(&Table{OID: table}, true, maxRepetitions, columns)
+}
+
+func (x *GoSNMP) getTable(table string, bulk bool, maxRepetitions uint8, columns []uint32) (*Table, error) {
+	oid, err := walkRoot(x.mibs(), table)
+	if err != nil {
+		return nil, err
+	}
+	return x.fetchTable(&Table{OID: oid}, bulk, maxRepetitions, columns)
+}
+
+// bulkGetTableUpTime is like BulkGetTable, but requests sysUpTime.0 as a
+// non-repeater of each request, so that counters in the rows can be told
+// apart across agent restarts by the sysUpTime of their own response
+func (x *GoSNMP) bulkGetTableUpTime(table OID, columns ...uint32) (*Table, error) {
+	return x.fetchTable(&Table{OID: table, sysUpTime: true}, true, 0, columns)
+}
+
+func (x *GoSNMP) fetchTable(t *Table, bulk bool, maxRepetitions uint8, columns []uint32) (*Table, error) {
+	var err error
+	t.rows = make(map[string]*TableRow)
+	// The columns are defined under the table entry, always arc 1
+	entry := t.OID.Append(1)
+
+	if len(columns) == 0 {
+		err = x.walkTable(t, entry, bulk, maxRepetitions)
+	} else {
+		t.Columns = append(t.Columns, columns...)
+		err = x.lockstepTable(t, entry, bulk, maxRepetitions)
+	}
+	t.sortRows()
+	return t, err
+}
+
+// walkTable walks the whole table entry, discovering the columns
+func (x *GoSNMP) walkTable(t *Table, entry OID, bulk bool, maxRepetitions uint8) error {
+	var cells []SnmpPDU
+	err := stopped(x.newWalker(context.Background(), entry, bulk, maxRepetitions).run(func(pdu SnmpPDU) error {
+		if len(pdu.OID()) > len(entry)+1 {
+			cells = append(cells, pdu)
+		}
+		return nil
+	}))
+
+	seen := make(map[uint32]bool)
+	for _, pdu := range cells {
+		if column := pdu.OID()[len(entry)]; !seen[column] {
+			seen[column] = true
+			t.Columns = append(t.Columns, column)
+		}
+	}
+	sort.Slice(t.Columns, func(i, j int) bool { return t.Columns[i] < t.Columns[j] })
+
+	for _, pdu := range cells {
+		t.set(entry, pdu, SnmpPDU{})
+	}
+	return err
+}
+
+// lockstepTable walks the requested columns side by side, one varbind per
+// column in each request
+func (x *GoSNMP) lockstepTable(t *Table, entry OID, bulk bool, maxRepetitions uint8) error {
+	if maxRepetitions == 0 {
+		maxRepetitions = DefaultMaxRepetitions
+	}
+	if x.Version == Version1 {
+		bulk = false
+	}
+
+	columns := make([]OID, len(t.Columns))
+	cursors := make([]OID, len(t.Columns))
+	for i, c := range t.Columns {
+		columns[i] = entry.Append(c)
+		cursors[i] = columns[i]
+	}
+	done := make([]bool, len(columns))
+	// skips counts the consecutive responses skipped per column, see
+	// maxWalkSkips
+	skips := make([]int, len(columns))
+	varbinds := 0
+	// bulkAnswered is set once the agent answered a GetBulk request, see
+	// walker.fetch
+	bulkAnswered := false
+	// GetNext of sysUpTime is sysUpTime.0
+	var prefix []string
+	if t.sysUpTime {
+		prefix = []string{oidSysUpTime.Parent().String()}
+	}
+
+	for requests := 1; ; requests++ {
+		if x.MaxWalkRequests > 0 && requests > x.MaxWalkRequests {
+			return fmt.Errorf("%w: more than %d requests", ErrWalkLimit, x.MaxWalkRequests)
+		}
+
+		// Only the columns that have not reached their end are requested
+		var active []int
+		oids := append([]string(nil), prefix...)
+		for i := range columns {
+			if !done[i] {
+				active = append(active, i)
+				oids = append(oids, cursors[i].String())
+			}
+		}
+		if len(active) == 0 {
+			return nil
+		}
+
+		var res *SnmpPacket
+		var err error
+		if bulk {
+			res, err = x.GetBulk(uint8(len(prefix)), maxRepetitions, oids...)
+			if err == nil && SnmpError(res.Error) == TooBig && maxRepetitions > 1 {
+				maxRepetitions /= 2
+				x.Log.Debug("Response too big, lowering max-repetitions to %d\n", maxRepetitions)
+				continue
+			}
+			if !bulkAnswered && bulkUnsupported(res, err) {
+				x.Log.Debug("GetBulk failed, falling back to GetNext\n")
+				bulk = false
+				continue
+			}
+			bulkAnswered = err == nil
+		} else {
+			res, err = x.request(GetNextRequest, oids...)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch SnmpError(res.Error) {
+		case NoError:
+		case NoSuchName:
+			// SNMPv1 reports the end of the MIB view this way. The error
+			// index points at the column that ran out, or at sysUpTime if
+			// the agent has none.
+			i := int(res.ErrorIndex) - 1 - len(prefix)
+			if i >= 0 && i < len(active) {
+				done[active[i]] = true
+				continue
+			}
+			if i == -1 && len(prefix) > 0 {
+				prefix = nil
+				continue
+			}
+			return nil
+		default:
+			return fmt.Errorf("Agent returned %s at index %d", SnmpError(res.Error), res.ErrorIndex)
+		}
+
+		variables := res.Variables
+		var upTime SnmpPDU
+		if len(prefix) > 0 && len(variables) > 0 {
+			if variables[0].OID().Equal(oidSysUpTime) {
+				upTime = variables[0]
+			}
+			variables = variables[1:]
+		}
+
+		// skipped holds the columns whose remaining variables in the response
+		// follow an OID that went backwards
+		skipped := make(map[int]bool)
+		for j, pdu := range variables {
+			i := active[j%len(active)]
+			if done[i] || skipped[i] {
+				continue
+			}
+
+			oid := pdu.OID()
+			if pdu.Type == EndOfMibView || oid == nil || !oid.HasPrefix(columns[i]) || len(oid) == len(columns[i]) {
+				done[i] = true
+				continue
+			}
+			if oid.Compare(cursors[i]) <= 0 {
+				if x.NonIncreasing == WalkSkip && skips[i] < maxWalkSkips {
+					// Skip ahead past the subtree of the cursor, as walks do
+					x.Log.Debug("OID %s does not follow %s, skipping ahead\n", oid, cursors[i])
+					cursors[i] = successor(cursors[i])
+					skipped[i] = true
+					skips[i]++
+					if cursors[i] == nil || !cursors[i].HasPrefix(columns[i]) {
+						done[i] = true
+					}
+					continue
+				}
+				return fmt.Errorf("%w: %s returned after %s", ErrOIDNotIncreasing, oid, cursors[i])
+			}
+
+			if x.MaxWalkVarbinds > 0 && varbinds >= x.MaxWalkVarbinds {
+				return fmt.Errorf("%w: more than %d variables", ErrWalkLimit, x.MaxWalkVarbinds)
+			}
+			varbinds++
+			skips[i] = 0
+			t.set(entry, pdu, upTime)
+			cursors[i] = oid
+		}
+	}
+}
+
+// set stores a cell in the row it belongs to, creating the row if needed,
+// along with the sysUpTime of its response if requested
+func (t *Table) set(entry OID, pdu SnmpPDU, upTime SnmpPDU) {
+	oid := pdu.OID()
+	column, index := oid[len(entry)], oid[len(entry)+1:]
+
+	row, ok := t.rows[index.String()]
+	if !ok {
+		row = &TableRow{Index: index.Copy(), Cells: make([]TableCell, len(t.Columns))}
+		for i := range row.Cells {
+			row.Cells[i].Missing = true
+		}
+		t.rows[index.String()] = row
+		t.Rows = append(t.Rows, row)
+	}
+
+	for i, c := range t.Columns {
+		if c == column {
+			row.Cells[i] = TableCell{SnmpPDU: pdu}
+		}
+	}
+	if t.sysUpTime {
+		row.sysUpTime = upTime
+	}
+}
+
+func (t *Table) sortRows() {
+	sort.Slice(t.Rows, func(i, j int) bool { return t.Rows[i].Index.Compare(t.Rows[j].Index) < 0 })
+}
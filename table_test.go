@@ -0,0 +1,142 @@
+package gosnmp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Test lockstep retrieval of table columns, with sparse cells
+func TestGetTable(t *testing.T) {
+	mib := map[string]Value{".1.3.6.1.2.1.2.2.1.5.2": Gauge32Value(1000000000)}
+	for name, value := range testMIB {
+		mib[name] = value
+	}
+	agent := newTestAgent(t, mib)
+	s := agent.client()
+
+	for _, bulk := range []bool{false, true} {
+		var table *Table
+		var err error
+		before := agent.requestCount()
+		if bulk {
+			table, err = s.BulkGetTable(10, ".1.3.6.1.2.1.2.2", 2, 5, 10)
+		} else {
+			table, err = s.GetTable(".1.3.6.1.2.1.2.2", 2, 5, 10)
+		}
+		if err != nil {
+			t.Fatalf("Unable to get table (bulk %t): %s", bulk, err)
+		}
+		if bulk && agent.requestCount()-before != 1 {
+			t.Errorf("BulkGetTable requests:\n\twant: 1\n\tgot : %d", agent.requestCount()-before)
+		}
+
+		if len(table.Rows) != 2 {
+			t.Fatalf("Rows (bulk %t):\n\twant: 2\n\tgot : %d", bulk, len(table.Rows))
+		}
+		row := table.Row(OID{2})
+		if row == nil || row != table.Rows[1] {
+			t.Fatalf("Row 2 not found (bulk %t)", bulk)
+		}
+		if descr, ok := table.Cell(row, 2); !ok || descr.Value != "eth0" {
+			t.Errorf("ifDescr.2 (bulk %t):\n\twant: %q\n\tgot : %v", bulk, "eth0", descr.Value)
+		}
+		if speed, ok := table.Cell(row, 5); !ok || speed.Value != uint64(1000000000) {
+			t.Errorf("ifSpeed.2 (bulk %t):\n\twant: %d\n\tgot : %v", bulk, 1000000000, speed.Value)
+		}
+		if _, ok := table.Cell(table.Rows[0], 5); ok || !table.Rows[0].Cells[1].Missing {
+			t.Errorf("ifSpeed.1 should be missing (bulk %t): %+v", bulk, table.Rows[0].Cells[1])
+		}
+	}
+
+	// Without columns the whole table is walked
+	table, err := s.GetTable(".1.3.6.1.2.1.2.2")
+	if err != nil {
+		t.Fatalf("Unable to get table: %s", err)
+	}
+	if len(table.Columns) != 5 || len(table.Rows) != 2 {
+		t.Errorf("Discovered table:\n\twant: 5 columns, 2 rows\n\tgot : %v, %d rows", table.Columns, len(table.Rows))
+	}
+
+	// Requests with many columns need long form BER lengths
+	columns := []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	if table, err = s.GetTable(".1.3.6.1.2.1.2.2", columns...); err != nil || len(table.Rows) != 2 {
+		t.Errorf("Table with %d columns: %v", len(columns), err)
+	}
+}
+
+// Test retrieving a table by OID
+func TestGetTableOID(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	want, err := s.GetTable(".1.3.6.1.2.1.2.2", 2, 10)
+	if err != nil {
+		t.Fatalf("Unable to get table: %s", err)
+	}
+	table := MustParseOID(".1.3.6.1.2.1.2.2")
+	for _, bulk := range []bool{false, true} {
+		var got *Table
+		if bulk {
+			got, err = s.BulkGetTableOID(0, table, 2, 10)
+		} else {
+			got, err = s.GetTableOID(table, 2, 10)
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetTableOID (bulk %t):\n\twant: %+v\n\tgot : %+v, %v", bulk, want, got, err)
+		}
+	}
+	if _, err := s.GetTableOID(nil, 2); err == nil {
+		t.Errorf("Expected error for an empty table OID")
+	}
+}
+
+// Test the skip policy and walk limits apply to lockstep retrieval
+func TestGetTableSkip(t *testing.T) {
+	agent := newTestAgent(t, map[string]Value{
+		".1.3.6.1.2.1.2.2.1.2.1": OctetStringValue("lo"),
+		".1.3.6.1.2.1.2.2.1.2.2": OctetStringValue("eth0"),
+		".1.3.6.1.2.1.2.2.1.2.3": OctetStringValue("eth1"),
+		".1.3.6.1.2.1.2.2.1.2.4": OctetStringValue("eth2"),
+	})
+	s := agent.client()
+	s.NonIncreasing = WalkSkip
+
+	// The agent jumps back to the first row after ifDescr.2
+	back := MustParseOID(".1.3.6.1.2.1.2.2.1.2.2")
+	agent.setNext(func(oid OID) VarBind {
+		if oid.Equal(back) {
+			return agent.lookupNext(MustParseOID(".1.3.6.1.2.1.2.2.1.2"))
+		}
+		return agent.lookupNext(oid)
+	})
+
+	for _, bulk := range []bool{false, true} {
+		var table *Table
+		var err error
+		if bulk {
+			table, err = s.BulkGetTable(10, ".1.3.6.1.2.1.2.2", 2)
+		} else {
+			table, err = s.GetTable(".1.3.6.1.2.1.2.2", 2)
+		}
+		if err != nil {
+			t.Fatalf("Unable to get table (bulk %t): %s", bulk, err)
+		}
+		// The walk skips past ifDescr.2, as walks do, and continues
+		var indexes []string
+		for _, row := range table.Rows {
+			indexes = append(indexes, row.Index.String())
+		}
+		if len(indexes) != 3 || indexes[2] != ".4" {
+			t.Errorf("Rows (bulk %t):\n\twant: [.1 .2 .4]\n\tgot : %v", bulk, indexes)
+		}
+	}
+
+	s.NonIncreasing = WalkStop
+	s.MaxWalkVarbinds = 1
+	agent.setNext(nil)
+	table, err := s.BulkGetTable(10, ".1.3.6.1.2.1.2.2", 2)
+	if !errors.Is(err, ErrWalkLimit) || len(table.Rows) != 1 {
+		t.Errorf("Varbind limit:\n\twant: 1 row, %s\n\tgot : %d rows, %v", ErrWalkLimit, len(table.Rows), err)
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateAndTimeHint is the DISPLAY-HINT of the DateAndTime TC (RFC 2579)
+const dateAndTimeHint = "2d-1d-1d,1d:1d:1d.1d,1a1d:1d"
+
+// FormatValue renders the value of a variable binding according to the
+// syntax of its object: enumerations as "up(1)", BITS as their named bits,
+// and others by textual convention, DISPLAY-HINT or base type. Values of
+// unknown objects are rendered by Value.String.
+func (t *MIBTree) FormatValue(vb VarBind) string {
+	return formatSyntax(t.syntaxOf(vb.Name), vb.Value)
+}
+
+// DecodeValue converts the value of a variable binding to the Go type that
+// represents the textual convention of its object, as DecodeTC does
+func (t *MIBTree) DecodeValue(vb VarBind) (interface{}, error) {
+	return decodeSyntax(t.syntaxOf(vb.Name), vb.Value)
+}
+
+// syntaxOf returns the syntax of the object an instance OID belongs to, or
+// nil if it is unknown
+func (t *MIBTree) syntaxOf(oid OID) *MIBSyntax {
+	node, _ := t.Longest(oid)
+	if node == nil {
+		return nil
+	}
+	return node.Syntax
+}
+
+// typeSyntax resolves a type name, such as "MacAddress", as if it were the
+// syntax of an object. It returns nil if the type is unknown.
+func (t *MIBTree) typeSyntax(name string) *MIBSyntax {
+	s := &MIBSyntax{Type: name}
+	t.resolveSyntax(&mibModule{}, s)
+	if s.Base == "" {
+		return nil
+	}
+	return s
+}
+
+// FormatTC renders a value according to a textual convention, such as
+// "DisplayString", "MacAddress", "DateAndTime" or "InetAddress". The DISPLAY-
+// HINT of other TCs is taken from BuiltinMIBs. Values are rendered by
+// Value.String if the TC is unknown or does not apply to them.
+func FormatTC(tc string, v Value) string {
+	return formatSyntax(BuiltinMIBs().typeSyntax(tc), v)
+}
+
+// DecodeTC converts a value to the Go type that represents its textual
+// convention:
+//
+//	DisplayString, SnmpAdminString and other text   string
+//	PhysAddress, MacAddress                          net.HardwareAddr
+//	DateAndTime                                      time.Time
+//	InetAddress, InetAddressIPv4 and similar         InetAddress
+//	BITS                                             Bits
+//
+// The type of an InetAddress is inferred from its length; if the
+// InetAddressType object accompanying it is known, build the InetAddress
+// directly instead. Values of other TCs are returned unchanged.
+func DecodeTC(tc string, v Value) (interface{}, error) {
+	return decodeSyntax(BuiltinMIBs().typeSyntax(tc), v)
+}
+
+// formatSyntax renders a value according to a syntax, which may be nil
+func formatSyntax(s *MIBSyntax, v Value) string {
+	if v == nil {
+		return "Null"
+	}
+	if s == nil {
+		return v.String()
+	}
+
+	if n, ok := v.(Integer32Value); ok && len(s.Enums) > 0 {
+		name, _ := s.EnumName(int64(n))
+		return formatNamedNumber(name, int64(n))
+	}
+
+	decoded, err := decodeSyntax(s, v)
+	if err != nil {
+		return v.String()
+	}
+	switch d := decoded.(type) {
+	case Bits:
+		return d.String()
+	case string:
+		return d
+	case net.HardwareAddr:
+		return d.String()
+	case time.Time:
+		return d.Format("2006-01-02T15:04:05.0Z07:00")
+	case InetAddress:
+		return d.String()
+	}
+
+	if s.DisplayHint != "" {
+		if str, err := FormatHint(s.DisplayHint, v); err == nil {
+			return str
+		}
+	}
+	return v.String()
+}
+
+// decodeSyntax converts an octet string to the Go type of its textual
+// convention or to Bits, returning other values unchanged
+func decodeSyntax(s *MIBSyntax, v Value) (interface{}, error) {
+	octets, ok := v.(OctetStringValue)
+	if s == nil || !ok {
+		return v, nil
+	}
+	if s.Base == "BITS" {
+		return s.DecodeBits(octets), nil
+	}
+	if s.Base != "OCTET STRING" {
+		return v, nil
+	}
+
+	switch {
+	case s.TC == "DateAndTime" || s.DisplayHint == dateAndTimeHint:
+		return ParseDateAndTime(octets)
+	case s.TC == "MacAddress" || s.TC == "PhysAddress" || s.DisplayHint == "1x:":
+		return net.HardwareAddr(copyBytes(octets)), nil
+	case strings.HasPrefix(s.TC, "InetAddress"):
+		return inetAddressOf(s.TC, octets)
+	case isTextHint(s.DisplayHint):
+		return string(octets), nil
+	}
+	return v, nil
+}
+
+// inetAddressOf builds an InetAddress from the value of an InetAddress TC
+func inetAddressOf(tc string, octets []byte) (InetAddress, error) {
+	types := map[string]InetAddressType{
+		"InetAddressIPv4":  InetIPv4,
+		"InetAddressIPv6":  InetIPv6,
+		"InetAddressIPv4z": InetIPv4z,
+		"InetAddressIPv6z": InetIPv6z,
+		"InetAddressDNS":   InetDNS,
+	}
+	lengths := map[InetAddressType]int{InetIPv4: 4, InetIPv6: 16, InetIPv4z: 8, InetIPv6z: 20}
+
+	addr := InetAddress{Type: types[tc], Address: copyBytes(octets)}
+	if tc == "InetAddress" {
+		// Infer the type from the length
+		addr.Type = InetDNS
+		for typ, n := range lengths {
+			if len(octets) == n {
+				addr.Type = typ
+			}
+		}
+		if len(octets) == 0 {
+			addr.Type = InetUnknown
+		}
+	}
+	if n, ok := lengths[addr.Type]; ok && len(octets) != n {
+		return InetAddress{}, fmt.Errorf("Invalid %s length %d", tc, len(octets))
+	}
+	return addr, nil
+}
+
+// isTextHint returns true for a DISPLAY-HINT rendering a whole octet string
+// as text, such as "255a" or "255t"
+func isTextHint(hint string) bool {
+	if len(hint) < 2 || (hint[len(hint)-1] != 'a' && hint[len(hint)-1] != 't') {
+		return false
+	}
+	_, err := strconv.Atoi(hint[:len(hint)-1])
+	return err == nil
+}
+
+// ParseDateAndTime decodes an RFC 2579 DateAndTime of 8 or 11 octets. Without
+// the optional UTC offset, the time is returned in UTC.
+func ParseDateAndTime(data []byte) (time.Time, error) {
+	if len(data) != 8 && len(data) != 11 {
+		return time.Time{}, fmt.Errorf("Invalid DateAndTime length %d", len(data))
+	}
+	year := int(data[0])<<8 | int(data[1])
+	month, day, hour, min, sec, deci := data[2], data[3], data[4], data[5], data[6], data[7]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || min > 59 || sec > 60 || deci > 9 {
+		return time.Time{}, fmt.Errorf("Invalid DateAndTime %s", hexString(data))
+	}
+
+	loc := time.UTC
+	if len(data) == 11 {
+		dir, offHour, offMin := data[8], int(data[9]), int(data[10])
+		if (dir != '+' && dir != '-') || offHour > 13 || offMin > 59 {
+			return time.Time{}, fmt.Errorf("Invalid DateAndTime offset %s", hexString(data[8:]))
+		}
+		offset := (offHour*60 + offMin) * 60
+		if dir == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	return time.Date(year, time.Month(month), int(day), int(hour), int(min), int(sec), int(deci)*100000000, loc), nil
+}
+
+// hintSpec is one octet-format specification of a DISPLAY-HINT
+type hintSpec struct {
+	repeat     bool
+	length     int
+	format     byte
+	separator  byte
+	terminator byte
+}
+
+// FormatHint renders a value according to an RFC 2579 DISPLAY-HINT. Octet
+// string hints, such as "1x:" or "255a", apply to OCTET STRING and Opaque
+// values; integer hints, such as "d-2" or "x", to the integer types. Hex
+// octets are rendered with two digits each.
+func FormatHint(hint string, v Value) (string, error) {
+	switch v := v.(type) {
+	case OctetStringValue:
+		return formatOctetHint(hint, v)
+	case OpaqueValue:
+		return formatOctetHint(hint, v)
+	case Integer32Value:
+		return formatIntegerHint(hint, v < 0, uint64(abs64(int64(v))))
+	case Counter32Value:
+		return formatIntegerHint(hint, false, uint64(v))
+	case Gauge32Value:
+		return formatIntegerHint(hint, false, uint64(v))
+	case Uinteger32Value:
+		return formatIntegerHint(hint, false, uint64(v))
+	case TimeTicksValue:
+		return formatIntegerHint(hint, false, uint64(v))
+	case Counter64Value:
+		return formatIntegerHint(hint, false, uint64(v))
+	}
+	return "", fmt.Errorf("DISPLAY-HINT does not apply to %s", v.Type())
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// parseOctetHint parses an octet string DISPLAY-HINT into its specifications
+func parseOctetHint(hint string) ([]hintSpec, error) {
+	var specs []hintSpec
+	isDelim := func(i int) bool {
+		return i < len(hint) && hint[i] != '*' && (hint[i] < '0' || hint[i] > '9')
+	}
+
+	for i := 0; i < len(hint); {
+		var spec hintSpec
+		if hint[i] == '*' {
+			spec.repeat = true
+			i++
+		}
+		start := i
+		for i < len(hint) && hint[i] >= '0' && hint[i] <= '9' {
+			i++
+		}
+		n, err := strconv.Atoi(hint[start:i])
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("Invalid DISPLAY-HINT %q: missing length", hint)
+		}
+		spec.length = n
+		if i >= len(hint) || !strings.ContainsRune("dxoat", rune(hint[i])) {
+			return nil, fmt.Errorf("Invalid DISPLAY-HINT %q: missing format", hint)
+		}
+		spec.format = hint[i]
+		i++
+		if isDelim(i) {
+			spec.separator = hint[i]
+			i++
+			if spec.repeat && isDelim(i) {
+				spec.terminator = hint[i]
+				i++
+			}
+		}
+		specs = append(specs, spec)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("Empty DISPLAY-HINT")
+	}
+	return specs, nil
+}
+
+// formatOctetHint applies an octet string DISPLAY-HINT. The last
+// specification is repeated until the octets are exhausted.
+func formatOctetHint(hint string, data []byte) (string, error) {
+	specs, err := parseOctetHint(hint)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i := 0; len(data) > 0; {
+		spec := specs[i]
+		if i < len(specs)-1 {
+			i++
+		}
+
+		count := 1
+		if spec.repeat {
+			count = int(data[0])
+			data = data[1:]
+		}
+		for r := 0; r < count && len(data) > 0; r++ {
+			n := spec.length
+			if n > len(data) {
+				n = len(data)
+			}
+			chunk := data[:n]
+			data = data[n:]
+
+			switch spec.format {
+			case 'a', 't':
+				b.Write(chunk)
+			case 'x':
+				b.WriteString(hex.EncodeToString(chunk))
+			case 'd':
+				b.WriteString(new(big.Int).SetBytes(chunk).Text(10))
+			case 'o':
+				b.WriteString(new(big.Int).SetBytes(chunk).Text(8))
+			}
+
+			if len(data) == 0 {
+				break
+			}
+			if r == count-1 && spec.terminator != 0 {
+				b.WriteByte(spec.terminator)
+			} else if spec.separator != 0 {
+				b.WriteByte(spec.separator)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// formatIntegerHint applies an integer DISPLAY-HINT: "d", "d-N" for N
+// implied decimal places, "x", "o" or "b"
+func formatIntegerHint(hint string, negative bool, n uint64) (string, error) {
+	var s string
+	switch {
+	case hint == "x":
+		s = strconv.FormatUint(n, 16)
+	case hint == "o":
+		s = strconv.FormatUint(n, 8)
+	case hint == "b":
+		s = strconv.FormatUint(n, 2)
+	case hint == "d":
+		s = strconv.FormatUint(n, 10)
+	case strings.HasPrefix(hint, "d-"):
+		places, err := strconv.Atoi(hint[2:])
+		if err != nil || places < 0 {
+			return "", fmt.Errorf("Invalid DISPLAY-HINT %q", hint)
+		}
+		s = strconv.FormatUint(n, 10)
+		if places > 0 {
+			if len(s) <= places {
+				s = strings.Repeat("0", places-len(s)+1) + s
+			}
+			s = s[:len(s)-places] + "." + s[len(s)-places:]
+		}
+	default:
+		return "", fmt.Errorf("Invalid DISPLAY-HINT %q", hint)
+	}
+	if negative {
+		s = "-" + s
+	}
+	return s, nil
+}
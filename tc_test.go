@@ -0,0 +1,173 @@
+package gosnmp
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var displayHintTests = []struct {
+	hint  string
+	value Value
+	want  string
+}{
+	{"255a", OctetStringValue("router1"), "router1"},
+	{"1x:", OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 1}, "00:1b:21:ff:80:01"},
+	{"1d.1d.1d.1d", OctetStringValue{10, 0, 0, 1}, "10.0.0.1"},
+	{"2x:2x", OctetStringValue{0xfe, 0x80, 0, 1}, "fe80:0001"},
+	{"2d-1d-1d,1d:1d:1d.1d,1a1d:1d", OctetStringValue{0x07, 0xe8, 3, 5, 14, 30, 15, 0, '+', 5, 30}, "2024-3-5,14:30:15.0,+5:30"},
+	{"2d-1d-1d,1d:1d:1d.1d,1a1d:1d", OctetStringValue{0x07, 0xe8, 3, 5, 14, 30, 15, 0}, "2024-3-5,14:30:15.0"},
+	// A repeat count in the first octet, with a terminator after the group
+	{"*1x:/1a", OctetStringValue{2, 0xaa, 0xbb, 'z', 'y'}, "aa:bb/zy"},
+	{"1o", OpaqueValue{8, 9}, "1011"},
+	{"4d", OctetStringValue{0, 1, 0, 0}, "65536"},
+	{"d-2", Integer32Value(1234), "12.34"},
+	{"d-2", Integer32Value(-5), "-0.05"},
+	{"d-1", Gauge32Value(0), "0.0"},
+	{"d", Counter64Value(42), "42"},
+	{"x", Integer32Value(255), "ff"},
+	{"o", Counter32Value(8), "10"},
+	{"b", Uinteger32Value(5), "101"},
+}
+
+// Test rendering values with RFC 2579 DISPLAY-HINTs
+func TestFormatHint(t *testing.T) {
+	for _, test := range displayHintTests {
+		got, err := FormatHint(test.hint, test.value)
+		if err != nil {
+			t.Errorf("%q %v: %s", test.hint, test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q %v:\n\twant: %s\n\tgot : %s", test.hint, test.value, test.want, got)
+		}
+	}
+
+	for _, hint := range []string{"", "x1", "1q", "*x"} {
+		if got, err := FormatHint(hint, OctetStringValue("ab")); err == nil {
+			t.Errorf("Expected error for hint %q, got %s", hint, got)
+		}
+	}
+	if _, err := FormatHint("d-x", Integer32Value(1)); err == nil {
+		t.Errorf("Expected error for malformed integer hint")
+	}
+	if _, err := FormatHint("1x:", ObjectIdentifierValue{1, 3}); err == nil {
+		t.Errorf("Expected error for an OID")
+	}
+}
+
+// Test decoding RFC 2579 DateAndTime values
+func TestParseDateAndTime(t *testing.T) {
+	got, err := ParseDateAndTime([]byte{0x07, 0xe8, 3, 5, 14, 30, 15, 7, '-', 4, 30})
+	want := time.Date(2024, 3, 5, 14, 30, 15, 700000000, time.FixedZone("", -(4*3600+30*60)))
+	if err != nil || !got.Equal(want) {
+		t.Errorf("DateAndTime:\n\twant: %s\n\tgot : %s (%v)", want, got, err)
+	}
+	if _, offset := got.Zone(); offset != -(4*3600 + 30*60) {
+		t.Errorf("DateAndTime offset: %d", offset)
+	}
+
+	got, err = ParseDateAndTime([]byte{0x07, 0xe8, 3, 5, 14, 30, 15, 0})
+	if err != nil || !got.Equal(time.Date(2024, 3, 5, 14, 30, 15, 0, time.UTC)) || got.Location() != time.UTC {
+		t.Errorf("DateAndTime without offset: %s %v", got, err)
+	}
+
+	for _, data := range [][]byte{{0x07, 0xe8, 3}, {0x07, 0xe8, 13, 5, 14, 30, 15, 0}, {0x07, 0xe8, 3, 5, 14, 30, 15, 0, 'x', 0, 0}} {
+		if _, err := ParseDateAndTime(data); err == nil {
+			t.Errorf("Expected error for %v", data)
+		}
+	}
+}
+
+// Test formatting and decoding values by textual convention
+func TestTextualConventions(t *testing.T) {
+	mac := OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 1}
+	dateAndTime := OctetStringValue{0x07, 0xe8, 3, 5, 14, 30, 15, 0, '+', 1, 0}
+	formatTests := []struct {
+		tc    string
+		value Value
+		want  string
+	}{
+		{"DisplayString", OctetStringValue("eth0"), "eth0"},
+		{"MacAddress", mac, "00:1b:21:ff:80:01"},
+		{"PhysAddress", mac, "00:1b:21:ff:80:01"},
+		{"DateAndTime", dateAndTime, "2024-03-05T14:30:15.0+01:00"},
+		{"InetAddress", OctetStringValue{192, 0, 2, 1}, "192.0.2.1"},
+		{"InetAddress", OctetStringValue(net.ParseIP("2001:db8::1")), "2001:db8::1"},
+		{"InetAddress", OctetStringValue("ns.example.com"), "ns.example.com"},
+		{"InetAddressIPv4", OctetStringValue{192, 0, 2, 1}, "192.0.2.1"},
+		{"InterfaceIndex", Integer32Value(3), "3"},
+		{"SnmpAdminString", OctetStringValue("admin"), "admin"},
+		// Values that do not fit their TC fall back to Value.String
+		{"DateAndTime", OctetStringValue{1, 2}, "01:02"},
+		{"InetAddressIPv4", OctetStringValue{1, 2}, "01:02"},
+		{"NoSuchTC", OctetStringValue("x"), "x"},
+	}
+	for _, test := range formatTests {
+		if got := FormatTC(test.tc, test.value); got != test.want {
+			t.Errorf("FormatTC %s %v:\n\twant: %s\n\tgot : %s", test.tc, test.value, test.want, got)
+		}
+	}
+
+	decodeTests := []struct {
+		tc    string
+		value Value
+		want  interface{}
+	}{
+		{"DisplayString", OctetStringValue("eth0"), "eth0"},
+		{"MacAddress", mac, net.HardwareAddr(mac)},
+		{"InetAddress", OctetStringValue{192, 0, 2, 1}, InetAddress{InetIPv4, []byte{192, 0, 2, 1}}},
+		{"InetAddress", OctetStringValue{}, InetAddress{InetUnknown, []byte{}}},
+		{"InetAddressDNS", OctetStringValue("a.b"), InetAddress{InetDNS, []byte("a.b")}},
+		{"TruthValue", Integer32Value(1), Integer32Value(1)},
+	}
+	for _, test := range decodeTests {
+		got, err := DecodeTC(test.tc, test.value)
+		if err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("DecodeTC %s %v:\n\twant: %#v\n\tgot : %#v (%v)", test.tc, test.value, test.want, got, err)
+		}
+	}
+
+	got, err := DecodeTC("DateAndTime", dateAndTime)
+	if ts, ok := got.(time.Time); err != nil || !ok || !ts.Equal(time.Date(2024, 3, 5, 13, 30, 15, 0, time.UTC)) {
+		t.Errorf("DecodeTC DateAndTime: %v %v", got, err)
+	}
+}
+
+// Test formatting values by the syntax of their object
+func TestFormatValue(t *testing.T) {
+	tree := BuiltinMIBs()
+	tests := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{"ifPhysAddress.2", OctetStringValue{0, 0x1b, 0x21, 0xff, 0x80, 1}, "00:1b:21:ff:80:01"},
+		{"hrSystemDate.0", OctetStringValue{0x07, 0xe8, 3, 5, 14, 30, 15, 0}, "2024-03-05T14:30:15.0Z"},
+		{"ipAddressPrefixPrefix.1.1.4.10.0.0.0.8", OctetStringValue{10, 0, 0, 0}, "10.0.0.0"},
+		{"sysDescr.0", OctetStringValue("Linux"), "Linux"},
+		{"dot1dStpDesignatedRoot.0", OctetStringValue{0x80, 0, 0, 0x1b, 0x21, 0xff, 0x80, 1}, "80:00:00:1b:21:ff:80:01"},
+		{"ifInOctets.1", Counter32Value(10), "10"},
+		{"sysUpTime.0", TimeTicksValue(100), "1s"},
+	}
+	for _, test := range tests {
+		oid, err := tree.Resolve(test.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tree.FormatValue(VarBind{oid, test.value}); got != test.want {
+			t.Errorf("FormatValue %s:\n\twant: %s\n\tgot : %s", test.name, test.want, got)
+		}
+	}
+
+	if got := tree.FormatValue(VarBind{MustParseOID(".1.3.6.1.4.1.99999.1"), OctetStringValue{1, 2}}); got != "01:02" {
+		t.Errorf("FormatValue of unknown object: %s", got)
+	}
+
+	oid, _ := tree.Resolve("ifPhysAddress.2")
+	got, err := tree.DecodeValue(VarBind{oid, OctetStringValue{0, 1, 2, 3, 4, 5}})
+	if err != nil || got.(net.HardwareAddr).String() != "00:01:02:03:04:05" {
+		t.Errorf("DecodeValue: %v %v", got, err)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Topology is a network graph merged from the LLDP data of many devices.
+// Devices are identified by their chassis ID, and a link seen from both of
+// its ends is only recorded once. It marshals to JSON as its nodes and links,
+// and a decoded Topology can be added to.
+type Topology struct {
+	// Nodes are sorted by ID
+	Nodes []*TopologyNode `json:"nodes"`
+	// Links are sorted by their ends
+	Links []*TopologyLink `json:"links"`
+
+	// nodes indexes Nodes by ID. It is rebuilt when nil, as after decoding.
+	nodes map[string]*TopologyNode
+}
+
+// TopologyNode is a device of a Topology
+type TopologyNode struct {
+	// ID is the chassis ID
+	ID          string   `json:"id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Addresses   []string `json:"addresses,omitempty"`
+	// Polled is set for devices whose own LLDP data was added, rather than
+	// only seen as neighbours
+	Polled bool `json:"polled"`
+}
+
+// Label returns the name of the device, or its ID if it has no name
+func (n *TopologyNode) Label() string {
+	if n.Name != "" {
+		return n.Name
+	}
+	return n.ID
+}
+
+// TopologyLink connects a port of one device to a port of another. Ports
+// are identified by their LLDP port ID.
+type TopologyLink struct {
+	From     string `json:"from"`
+	FromPort string `json:"fromPort"`
+	To       string `json:"to"`
+	ToPort   string `json:"toPort"`
+	// FromPortIsNum and ToPortIsNum are set for an end whose port ID was
+	// unknown, which is then labelled with its lldpRemLocalPortNum. Such
+	// an end matches any port of the device when the link is seen from its
+	// other end, and takes that port ID.
+	FromPortIsNum bool `json:"fromPortIsNum,omitempty"`
+	ToPortIsNum   bool `json:"toPortIsNum,omitempty"`
+}
+
+// NewTopology returns an empty Topology
+func NewTopology() *Topology {
+	return &Topology{
+		Nodes: []*TopologyNode{},
+		Links: []*TopologyLink{},
+		nodes: make(map[string]*TopologyNode),
+	}
+}
+
+// Add merges the LLDP data of a device, as retrieved by GoSNMP.LLDP, into
+// the topology. A device without a chassis ID is identified by its SysName
+// instead, and left out if it has neither.
+func (t *Topology) Add(sys *LLDPSystem) {
+	id := sys.ChassisID
+	if id == "" {
+		id = sys.SysName
+	}
+	if id == "" {
+		return
+	}
+	local := t.node(id)
+	local.Polled = true
+	if sys.SysName != "" {
+		local.Name = sys.SysName
+	}
+	if sys.SysDesc != "" {
+		local.Description = sys.SysDesc
+	}
+
+	for _, n := range sys.Neighbors {
+		if n.ChassisID == "" {
+			continue
+		}
+		remote := t.node(n.ChassisID)
+		// A device's own data takes precedence over what its neighbours say
+		if !remote.Polled {
+			if n.SysName != "" {
+				remote.Name = n.SysName
+			}
+			if n.SysDesc != "" {
+				remote.Description = n.SysDesc
+			}
+		}
+		for _, a := range n.ManagementAddresses {
+			remote.addAddress(a.String())
+		}
+
+		from := &TopologyLink{From: local.ID, FromPort: n.LocalPortID, To: remote.ID, ToPort: n.PortID}
+		if from.FromPort == "" {
+			from.FromPort, from.FromPortIsNum = fmt.Sprint(n.LocalPortNum), true
+		}
+		t.link(from)
+	}
+
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].ID < t.Nodes[j].ID })
+	sort.Slice(t.Links, func(i, j int) bool {
+		a, b := t.Links[i], t.Links[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.FromPort != b.FromPort {
+			return a.FromPort < b.FromPort
+		}
+		return a.ToPort < b.ToPort
+	})
+}
+
+func (t *Topology) node(id string) *TopologyNode {
+	if t.nodes == nil {
+		t.nodes = make(map[string]*TopologyNode)
+		for _, n := range t.Nodes {
+			t.nodes[n.ID] = n
+		}
+	}
+	n := t.nodes[id]
+	if n == nil {
+		n = &TopologyNode{ID: id}
+		t.nodes[id] = n
+		t.Nodes = append(t.Nodes, n)
+	}
+	return n
+}
+
+// link records a link unless it was already seen from its other end. The
+// ends are ordered by device, so that the same link always has the same
+// From and To.
+func (t *Topology) link(l *TopologyLink) {
+	if l.To < l.From {
+		l = &TopologyLink{From: l.To, FromPort: l.ToPort, FromPortIsNum: l.ToPortIsNum,
+			To: l.From, ToPort: l.FromPort, ToPortIsNum: l.FromPortIsNum}
+	}
+	for _, seen := range t.Links {
+		if seen.From != l.From || seen.To != l.To {
+			continue
+		}
+		fromMatches := seen.FromPort == l.FromPort || seen.FromPortIsNum || l.FromPortIsNum
+		toMatches := seen.ToPort == l.ToPort || seen.ToPortIsNum || l.ToPortIsNum
+		if fromMatches && toMatches {
+			// Port IDs replace port numbers
+			if seen.FromPortIsNum && !l.FromPortIsNum {
+				seen.FromPort, seen.FromPortIsNum = l.FromPort, false
+			}
+			if seen.ToPortIsNum && !l.ToPortIsNum {
+				seen.ToPort, seen.ToPortIsNum = l.ToPort, false
+			}
+			return
+		}
+	}
+	t.Links = append(t.Links, l)
+}
+
+func (n *TopologyNode) addAddress(address string) {
+	for _, a := range n.Addresses {
+		if a == address {
+			return
+		}
+	}
+	n.Addresses = append(n.Addresses, address)
+}
+
+// WriteDOT writes the topology as an undirected Graphviz graph, with the
+// devices labelled by name and the links by port
+func (t *Topology) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "graph topology {")
+	for _, n := range t.Nodes {
+		fmt.Fprintf(b, "\t%s [label=%s];\n", dotQuote(n.ID), dotQuote(n.Label()))
+	}
+	for _, l := range t.Links {
+		fmt.Fprintf(b, "\t%s -- %s [taillabel=%s, headlabel=%s];\n",
+			dotQuote(l.From), dotQuote(l.To), dotQuote(l.FromPort), dotQuote(l.ToPort))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// dotQuote quotes a string as a DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
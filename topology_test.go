@@ -0,0 +1,131 @@
+package gosnmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Test merging the LLDP data of two devices that see each other
+func TestTopology(t *testing.T) {
+	switch1 := &LLDPSystem{
+		ChassisID: "00:00:00:00:00:01",
+		SysName:   "switch1",
+		Neighbors: []*LLDPNeighbor{
+			{LocalPortID: "Gi0/1", ChassisID: "00:00:00:00:00:02", PortID: "Gi0/2", SysName: "switch2",
+				ManagementAddresses: []InetAddress{{InetIPv4, []byte{192, 0, 2, 2}}}},
+			{LocalPortNum: 3, ChassisID: "phone", PortID: "eth0", SysName: "phone \"a\""},
+		},
+	}
+	switch2 := &LLDPSystem{
+		ChassisID: "00:00:00:00:00:02",
+		SysName:   "core",
+		Neighbors: []*LLDPNeighbor{
+			{LocalPortID: "Gi0/2", ChassisID: "00:00:00:00:00:01", PortID: "Gi0/1", SysName: "switch1"},
+		},
+	}
+
+	topology := NewTopology()
+	topology.Add(switch1)
+	topology.Add(switch2)
+
+	if len(topology.Nodes) != 3 || len(topology.Links) != 2 {
+		t.Fatalf("Expected 3 nodes and 2 links, got %d and %d", len(topology.Nodes), len(topology.Links))
+	}
+	if n := topology.Nodes[1]; n.Name != "core" || !n.Polled || len(n.Addresses) != 1 || n.Addresses[0] != "192.0.2.2" {
+		t.Errorf("Node: %+v", n)
+	}
+	if n := topology.Nodes[2]; n.Name != "phone \"a\"" || n.Polled {
+		t.Errorf("Neighbour node: %+v", n)
+	}
+
+	var dot bytes.Buffer
+	if err := topology.WriteDOT(&dot); err != nil {
+		t.Fatalf("WriteDOT: %s", err)
+	}
+	want := `graph topology {
+	"00:00:00:00:00:01" [label="switch1"];
+	"00:00:00:00:00:02" [label="core"];
+	"phone" [label="phone \"a\""];
+	"00:00:00:00:00:01" -- "00:00:00:00:00:02" [taillabel="Gi0/1", headlabel="Gi0/2"];
+	"00:00:00:00:00:01" -- "phone" [taillabel="3", headlabel="eth0"];
+}
+`
+	if dot.String() != want {
+		t.Errorf("WriteDOT:\n\twant: %s\n\tgot : %s", want, dot.String())
+	}
+
+	data, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	var decoded Topology
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded.Nodes) != 3 || decoded.Links[1].ToPort != "eth0" {
+		t.Errorf("JSON: %s", data)
+	}
+}
+
+// Test adding to a decoded topology, and merging a link whose local port ID
+// was unknown with the report of its other end
+func TestTopologyDecodedAdd(t *testing.T) {
+	topology := NewTopology()
+	topology.Add(&LLDPSystem{
+		ChassisID: "switch",
+		SysName:   "switch1",
+		Neighbors: []*LLDPNeighbor{
+			{LocalPortNum: 3, ChassisID: "phone", PortID: "eth0", SysName: "phone1"},
+		},
+	})
+	data, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	var decoded Topology
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+
+	// The phone reports no name and the port ID of the switch
+	decoded.Add(&LLDPSystem{
+		ChassisID: "phone",
+		Neighbors: []*LLDPNeighbor{
+			{LocalPortID: "eth0", ChassisID: "switch", PortID: "Gi0/3"},
+		},
+	})
+
+	if len(decoded.Nodes) != 2 || len(decoded.Links) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 link, got %d and %d", len(decoded.Nodes), len(decoded.Links))
+	}
+	if n := decoded.Nodes[0]; n.ID != "phone" || n.Name != "phone1" || !n.Polled {
+		t.Errorf("Node: %+v", n)
+	}
+	want := TopologyLink{From: "phone", FromPort: "eth0", To: "switch", ToPort: "Gi0/3"}
+	if l := *decoded.Links[0]; l != want {
+		t.Errorf("Link:\n\twant: %+v\n\tgot : %+v", want, l)
+	}
+}
+
+// Test devices without a chassis ID are not merged into one node
+func TestTopologyNoChassisID(t *testing.T) {
+	topology := NewTopology()
+	for _, name := range []string{"switch1", "switch2", ""} {
+		topology.Add(&LLDPSystem{
+			SysName: name,
+			Neighbors: []*LLDPNeighbor{
+				{LocalPortID: "Gi0/1", ChassisID: "core", PortID: "Gi0/" + name},
+			},
+		})
+	}
+
+	var ids []string
+	for _, n := range topology.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if want := []string{"core", "switch1", "switch2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Nodes:\n\twant: %v\n\tgot : %v", want, ids)
+	}
+	if len(topology.Links) != 2 {
+		t.Errorf("Links: %+v", topology.Links)
+	}
+}
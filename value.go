@@ -0,0 +1,466 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Value is a strongly typed SNMP value. The set of implementations is closed:
+// every SNMP data type has exactly one Value type in this package, and each
+// of them knows how to encode itself as BER.
+type Value interface {
+	// Type returns the ASN.1 BER type of the value
+	Type() Asn1BER
+	// String returns a human readable representation of the value
+	String() string
+	// marshalBER returns the BER encoded contents (without type and length)
+	marshalBER() ([]byte, error)
+}
+
+// VarBind is a variable binding carrying a typed Value. It is the typed
+// counterpart of SnmpPDU.
+type VarBind struct {
+	Name  OID
+	Value Value
+}
+
+// Integer32Value is an SNMP INTEGER / Integer32
+type Integer32Value int32
+
+// OctetStringValue is an SNMP OCTET STRING
+type OctetStringValue []byte
+
+// ObjectIdentifierValue is an SNMP OBJECT IDENTIFIER
+type ObjectIdentifierValue OID
+
+// IPAddressValue is an SNMP IpAddress
+type IPAddressValue net.IP
+
+// Counter32Value is an SNMP Counter32
+type Counter32Value uint32
+
+// Gauge32Value is an SNMP Gauge32 (also known as Unsigned32)
+type Gauge32Value uint32
+
+// TimeTicksValue is an SNMP TimeTicks, measured in hundredths of a second
+type TimeTicksValue uint32
+
+// OpaqueValue is an SNMP Opaque
+type OpaqueValue []byte
+
+// NsapAddressValue is an SNMPv1 NsapAddress
+type NsapAddressValue []byte
+
+// Counter64Value is an SNMP Counter64
+type Counter64Value uint64
+
+// Uinteger32Value is an SNMPv1 UInteger32
+type Uinteger32Value uint32
+
+// NullValue is the ASN.1 NULL, used as a placeholder in requests
+type NullValue struct{}
+
+// NoSuchObjectValue is the exception returned by an agent for an unknown object
+type NoSuchObjectValue struct{}
+
+// NoSuchInstanceValue is the exception returned by an agent for an unknown instance
+type NoSuchInstanceValue struct{}
+
+// EndOfMibViewValue is the exception returned by an agent at the end of its MIB view
+type EndOfMibViewValue struct{}
+
+func (v Integer32Value) Type() Asn1BER        { return Integer }
+func (v OctetStringValue) Type() Asn1BER      { return OctetString }
+func (v ObjectIdentifierValue) Type() Asn1BER { return ObjectIdentifier }
+func (v IPAddressValue) Type() Asn1BER        { return IpAddress }
+func (v Counter32Value) Type() Asn1BER        { return Counter32 }
+func (v Gauge32Value) Type() Asn1BER          { return Gauge32 }
+func (v TimeTicksValue) Type() Asn1BER        { return TimeTicks }
+func (v OpaqueValue) Type() Asn1BER           { return Opaque }
+func (v NsapAddressValue) Type() Asn1BER      { return NsapAddress }
+func (v Counter64Value) Type() Asn1BER        { return Counter64 }
+func (v Uinteger32Value) Type() Asn1BER       { return Uinteger32 }
+func (v BitStringValue) Type() Asn1BER        { return BitString }
+func (v NullValue) Type() Asn1BER             { return Null }
+func (v NoSuchObjectValue) Type() Asn1BER     { return NoSuchObject }
+func (v NoSuchInstanceValue) Type() Asn1BER   { return NoSuchInstance }
+func (v EndOfMibViewValue) Type() Asn1BER     { return EndOfMibView }
+
+func (v Integer32Value) String() string { return strconv.FormatInt(int64(v), 10) }
+
+// String returns the octet string as text if it is printable, otherwise as
+// colon separated hex bytes
+func (v OctetStringValue) String() string {
+	if isPrintable(v) {
+		return string(v)
+	}
+	return hexString(v)
+}
+
+func (v ObjectIdentifierValue) String() string { return OID(v).String() }
+func (v IPAddressValue) String() string        { return net.IP(v).String() }
+func (v Counter32Value) String() string        { return strconv.FormatUint(uint64(v), 10) }
+func (v Gauge32Value) String() string          { return strconv.FormatUint(uint64(v), 10) }
+func (v TimeTicksValue) String() string        { return v.Duration().String() }
+func (v OpaqueValue) String() string           { return hexString(v) }
+func (v NsapAddressValue) String() string      { return hexString(v) }
+func (v Counter64Value) String() string        { return strconv.FormatUint(uint64(v), 10) }
+func (v Uinteger32Value) String() string       { return strconv.FormatUint(uint64(v), 10) }
+func (v BitStringValue) String() string        { return hexString(v.Bytes) }
+func (v NullValue) String() string             { return "Null" }
+func (v NoSuchObjectValue) String() string     { return "noSuchObject" }
+func (v NoSuchInstanceValue) String() string   { return "noSuchInstance" }
+func (v EndOfMibViewValue) String() string     { return "endOfMibView" }
+
+// Duration returns the TimeTicks as a time.Duration
+func (v TimeTicksValue) Duration() time.Duration {
+	return time.Duration(v) * 10 * time.Millisecond
+}
+
+func (v Integer32Value) marshalBER() ([]byte, error)   { return marshalInt64(int64(v)), nil }
+func (v OctetStringValue) marshalBER() ([]byte, error) { return []byte(v), nil }
+func (v ObjectIdentifierValue) marshalBER() ([]byte, error) {
+	return marshalObjectIdentifier(OID(v))
+}
+
+func (v IPAddressValue) marshalBER() ([]byte, error) {
+	ip := net.IP(v).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("IpAddress %s is not an IPv4 address", net.IP(v))
+	}
+	return []byte(ip), nil
+}
+
+func (v Counter32Value) marshalBER() ([]byte, error)   { return marshalUint64(uint64(v)), nil }
+func (v Gauge32Value) marshalBER() ([]byte, error)     { return marshalUint64(uint64(v)), nil }
+func (v TimeTicksValue) marshalBER() ([]byte, error)   { return marshalUint64(uint64(v)), nil }
+func (v OpaqueValue) marshalBER() ([]byte, error)      { return []byte(v), nil }
+func (v NsapAddressValue) marshalBER() ([]byte, error) { return []byte(v), nil }
+func (v Counter64Value) marshalBER() ([]byte, error)   { return marshalUint64(uint64(v)), nil }
+func (v Uinteger32Value) marshalBER() ([]byte, error)  { return marshalUint64(uint64(v)), nil }
+
+func (v BitStringValue) marshalBER() ([]byte, error) {
+	padding := (8 - v.BitLength%8) % 8
+	return append([]byte{byte(padding)}, v.Bytes...), nil
+}
+
+func (v NullValue) marshalBER() ([]byte, error)           { return []byte{}, nil }
+func (v NoSuchObjectValue) marshalBER() ([]byte, error)   { return []byte{}, nil }
+func (v NoSuchInstanceValue) marshalBER() ([]byte, error) { return []byte{}, nil }
+func (v EndOfMibViewValue) marshalBER() ([]byte, error)   { return []byte{}, nil }
+
+// IsException returns true if the value is one of the SNMPv2 exception values
+// (noSuchObject, noSuchInstance or endOfMibView)
+func IsException(v Value) bool {
+	switch v.(type) {
+	case NoSuchObjectValue, NoSuchInstanceValue, EndOfMibViewValue:
+		return true
+	}
+	return false
+}
+
+// decodeBERValue decodes the contents of a BER field of the given type into
+// its typed Value
+func decodeBERValue(valueType Asn1BER, data []byte) (Value, error) {
+	switch valueType {
+	case Integer:
+		ret, err := parseInt64(data)
+		if err != nil {
+			return nil, err
+		}
+		if ret != int64(int32(ret)) {
+			return nil, fmt.Errorf("Integer32 out of range: %d", ret)
+		}
+		return Integer32Value(ret), nil
+	case OctetString:
+		return OctetStringValue(copyBytes(data)), nil
+	case ObjectIdentifier:
+		oid, err := parseObjectIdentifier(data)
+		if err != nil {
+			return nil, err
+		}
+		return ObjectIdentifierValue(oid), nil
+	case IpAddress:
+		if len(data) != 4 {
+			return nil, fmt.Errorf("Invalid IpAddress length %d", len(data))
+		}
+		return IPAddressValue(net.IPv4(data[0], data[1], data[2], data[3])), nil
+	case Counter32, Gauge32, TimeTicks, Uinteger32:
+		ret, err := parseUint64(data)
+		if err != nil {
+			return nil, err
+		}
+		if ret > 0xffffffff {
+			return nil, fmt.Errorf("%s out of range: %d", valueType, ret)
+		}
+		switch valueType {
+		case Counter32:
+			return Counter32Value(ret), nil
+		case Gauge32:
+			return Gauge32Value(ret), nil
+		case TimeTicks:
+			return TimeTicksValue(ret), nil
+		}
+		return Uinteger32Value(ret), nil
+	case Counter64:
+		ret, err := parseUint64(data)
+		if err != nil {
+			return nil, err
+		}
+		return Counter64Value(ret), nil
+	case Opaque:
+		return OpaqueValue(copyBytes(data)), nil
+	case NsapAddress:
+		return NsapAddressValue(copyBytes(data)), nil
+	case BitString:
+		ret, err := parseBitString(data)
+		if err != nil {
+			return nil, err
+		}
+		ret.Bytes = copyBytes(ret.Bytes)
+		return ret, nil
+	case Null:
+		return NullValue{}, nil
+	case NoSuchObject:
+		return NoSuchObjectValue{}, nil
+	case NoSuchInstance:
+		return NoSuchInstanceValue{}, nil
+	case EndOfMibView:
+		return EndOfMibViewValue{}, nil
+	}
+	return nil, fmt.Errorf("Unable to decode %s %#v - not implemented", valueType, valueType)
+}
+
+// marshalVarBind encodes a typed variable binding as a BER sequence
+func marshalVarBind(vb *VarBind) ([]byte, error) {
+	oid, err := marshalObjectIdentifier(vb.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal OID: %s", err.Error())
+	}
+
+	value := vb.Value
+	if value == nil {
+		value = NullValue{}
+	}
+	content, err := value.marshalBER()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to marshal %s value: %s", value.Type(), err.Error())
+	}
+
+	body := marshalTLV(ObjectIdentifier, oid)
+	body = append(body, marshalTLV(value.Type(), content)...)
+
+	return marshalTLV(Sequence, body), nil
+}
+
+// ToVarBind converts a legacy SnmpPDU into a typed VarBind
+func ToVarBind(pdu SnmpPDU) (VarBind, error) {
+	name, err := ParseOID(pdu.Name)
+	if err != nil {
+		return VarBind{}, err
+	}
+	vb := VarBind{Name: name}
+
+	switch pdu.Type {
+	case Integer:
+		if v, ok := pdu.Value.(int); ok {
+			vb.Value = Integer32Value(v)
+		}
+	case OctetString:
+		if v, ok := pdu.Value.(string); ok {
+			vb.Value = OctetStringValue(v)
+		}
+	case ObjectIdentifier:
+		if v, ok := pdu.Value.([]int); ok {
+			vb.Value = ObjectIdentifierValue(intsToOID(v))
+		}
+	case IpAddress:
+		if v, ok := pdu.Value.(net.IP); ok {
+			vb.Value = IPAddressValue(v)
+		}
+	case Counter32:
+		if v, ok := pdu.Value.(uint64); ok {
+			vb.Value = Counter32Value(v)
+		}
+	case Gauge32:
+		if v, ok := pdu.Value.(uint64); ok {
+			vb.Value = Gauge32Value(v)
+		}
+	case TimeTicks:
+		if v, ok := pdu.Value.(int); ok {
+			vb.Value = TimeTicksValue(v)
+		}
+	case Counter64:
+		if v, ok := pdu.Value.(uint64); ok {
+			vb.Value = Counter64Value(v)
+		}
+	case Uinteger32:
+		if v, ok := pdu.Value.(uint64); ok {
+			vb.Value = Uinteger32Value(v)
+		}
+	case Opaque:
+		if v, ok := pdu.Value.([]byte); ok {
+			vb.Value = OpaqueValue(v)
+		}
+	case NsapAddress:
+		if v, ok := pdu.Value.([]byte); ok {
+			vb.Value = NsapAddressValue(v)
+		}
+	case BitString:
+		if v, ok := pdu.Value.(BitStringValue); ok {
+			vb.Value = v
+		}
+	case Null:
+		vb.Value = NullValue{}
+	case NoSuchObject:
+		vb.Value = NoSuchObjectValue{}
+	case NoSuchInstance:
+		vb.Value = NoSuchInstanceValue{}
+	case EndOfMibView:
+		vb.Value = EndOfMibViewValue{}
+	}
+
+	if vb.Value == nil {
+		return VarBind{}, fmt.Errorf("Unable to convert %s value %#v", pdu.Type, pdu.Value)
+	}
+	return vb, nil
+}
+
+// PDU converts a typed VarBind into a legacy SnmpPDU, using the same value
+// representations as Unmarshal
+func (vb VarBind) PDU() SnmpPDU {
+	pdu := SnmpPDU{Name: vb.Name.String()}
+	if vb.Value == nil {
+		pdu.Type = Null
+		return pdu
+	}
+	pdu.Type = vb.Value.Type()
+
+	switch v := vb.Value.(type) {
+	case Integer32Value:
+		pdu.Value = int(v)
+	case OctetStringValue:
+		pdu.Value = string(v)
+	case ObjectIdentifierValue:
+		pdu.Value = OID(v).ints()
+	case IPAddressValue:
+		pdu.Value = net.IP(v)
+	case Counter32Value:
+		pdu.Value = uint64(v)
+	case Gauge32Value:
+		pdu.Value = uint64(v)
+	case TimeTicksValue:
+		pdu.Value = int(v)
+	case Counter64Value:
+		pdu.Value = uint64(v)
+	case Uinteger32Value:
+		pdu.Value = uint64(v)
+	case OpaqueValue:
+		pdu.Value = []byte(v)
+	case NsapAddressValue:
+		pdu.Value = []byte(v)
+	case BitStringValue:
+		pdu.Value = v
+	case EndOfMibViewValue:
+		pdu.Value = "endOfMib"
+	}
+	return pdu
+}
+
+// String returns the variable binding in "name = TYPE: value" form
+func (vb VarBind) String() string {
+	if vb.Value == nil {
+		return fmt.Sprintf("%s = Null", vb.Name)
+	}
+	return fmt.Sprintf("%s = %s: %s", vb.Name, vb.Value.Type(), vb.Value)
+}
+
+// marshalInt64 encodes a signed integer in the minimum number of two's
+// complement octets
+func marshalInt64(n int64) []byte {
+	length := 1
+	for i := n; i > 127 || i < -128; i >>= 8 {
+		length++
+	}
+
+	ret := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		ret[i] = byte(n)
+		n >>= 8
+	}
+	return ret
+}
+
+// marshalUint64 encodes an unsigned integer, adding a leading zero octet when
+// the most significant bit would otherwise flag a negative number
+func marshalUint64(n uint64) []byte {
+	length := 1
+	for i := n; i > 127; i >>= 8 {
+		length++
+	}
+
+	ret := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		ret[i] = byte(n)
+		n >>= 8
+	}
+	return ret
+}
+
+// parseUint64 treats the given bytes as a big-endian, unsigned integer. A
+// single leading zero octet is allowed so that 64 bit values fit.
+func parseUint64(bytes []byte) (ret uint64, err error) {
+	if len(bytes) == 9 && bytes[0] == 0 {
+		bytes = bytes[1:]
+	}
+	if len(bytes) > 8 {
+		err = fmt.Errorf("unsigned integer too large")
+		return
+	}
+	for _, b := range bytes {
+		ret = ret<<8 | uint64(b)
+	}
+	return
+}
+
+func copyBytes(data []byte) []byte {
+	ret := make([]byte, len(data))
+	copy(ret, data)
+	return ret
+}
+
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func hexString(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	h := hex.EncodeToString(data)
+	ret := make([]byte, 0, len(data)*3-1)
+	for i := 0; i < len(h); i += 2 {
+		if i > 0 {
+			ret = append(ret, ':')
+		}
+		ret = append(ret, h[i], h[i+1])
+	}
+	return string(ret)
+}
@@ -0,0 +1,122 @@
+package gosnmp
+
+import (
+	"encoding/hex"
+	"net"
+	"reflect"
+	"testing"
+)
+
+var testValues = []Value{
+	Integer32Value(0),
+	Integer32Value(-129),
+	Integer32Value(2147483647),
+	OctetStringValue("eth0"),
+	OctetStringValue{0x00, 0x1b, 0x21, 0xff, 0x80, 0x01},
+	ObjectIdentifierValue{1, 3, 6, 1, 4, 1, 9, 1, 1208},
+	IPAddressValue(net.IPv4(192, 168, 1, 254)),
+	Counter32Value(4294967295),
+	Gauge32Value(1000000000),
+	TimeTicksValue(123456),
+	Counter64Value(18446744073709551615),
+	OpaqueValue{0x9f, 0x78, 0x04},
+	NullValue{},
+	NoSuchObjectValue{},
+	NoSuchInstanceValue{},
+	EndOfMibViewValue{},
+}
+
+// Test typed values survive a BER round trip through a packet
+func TestTypedValueRoundTrip(t *testing.T) {
+	packet := &SnmpPacket{
+		Version:     Version2c,
+		Community:   "public",
+		RequestType: GetResponse,
+		RequestID:   0x8badf00d,
+	}
+	for i, v := range testValues {
+		packet.VarBinds = append(packet.VarBinds, VarBind{OID{1, 3, 6, 1, 2, 1, 1, uint32(i)}, v})
+	}
+
+	raw, err := packet.marshal()
+	if err != nil {
+		t.Fatalf("Unable to marshal packet: %s", err)
+	}
+
+	decoded, err := UnmarshalTyped(raw)
+	if err != nil {
+		t.Fatalf("Unable to unmarshal packet: %s", err)
+	}
+
+	if decoded.RequestID != packet.RequestID {
+		t.Errorf("Request ID:\n\twant: %d\n\tgot : %d", packet.RequestID, decoded.RequestID)
+	}
+	if len(decoded.VarBinds) != len(testValues) {
+		t.Fatalf("VarBinds:\n\twant: %d\n\tgot : %d", len(testValues), len(decoded.VarBinds))
+	}
+	for i, vb := range decoded.VarBinds {
+		if !reflect.DeepEqual(vb, packet.VarBinds[i]) {
+			t.Errorf("VarBind %d:\n\twant: %s\n\tgot : %s", i, packet.VarBinds[i], vb)
+		}
+	}
+}
+
+// Test decoding a captured response into typed values
+func TestUnmarshalTyped(t *testing.T) {
+	packet, _ := hex.DecodeString(TestPackets[0])
+
+	pckt, err := UnmarshalTyped(packet)
+	if err != nil {
+		t.Fatalf("Unable to decode packet: %s", err)
+	}
+
+	want := VarBind{OID{1, 3, 6, 1, 2, 1, 2, 2, 1, 10, 2}, Counter32Value(162791045)}
+	if len(pckt.VarBinds) != 1 || !reflect.DeepEqual(pckt.VarBinds[0], want) {
+		t.Errorf("Typed decode:\n\twant: %v\n\tgot : %v", want, pckt.VarBinds)
+	}
+}
+
+// Test conversion between legacy SnmpPDU and typed VarBind
+func TestVarBindConversion(t *testing.T) {
+	pdus := []SnmpPDU{
+		{".1.3.6.1.2.1.1.5.0", OctetString, "router1"},
+		{".1.3.6.1.2.1.1.3.0", TimeTicks, 4711},
+		{".1.3.6.1.2.1.2.2.1.10.2", Counter32, uint64(162791045)},
+		{".1.3.6.1.2.1.4.20.1.1.10.0.0.1", IpAddress, net.IPv4(10, 0, 0, 1)},
+		{".1.3.6.1.2.1.1.2.0", ObjectIdentifier, []int{1, 3, 6, 1, 4, 1, 8072}},
+		{".1.3.6.1.2.1.1.9.0", EndOfMibView, "endOfMib"},
+	}
+
+	for _, pdu := range pdus {
+		vb, err := ToVarBind(pdu)
+		if err != nil {
+			t.Errorf("Unable to convert %s: %s", pdu.Name, err)
+			continue
+		}
+		if back := vb.PDU(); !reflect.DeepEqual(back, pdu) {
+			t.Errorf("Conversion:\n\twant: %#v\n\tgot : %#v", pdu, back)
+		}
+	}
+}
+
+// Test every typed value survives a conversion to SnmpPDU and back
+func TestVarBindPDURoundTrip(t *testing.T) {
+	values := append([]Value{
+		Uinteger32Value(42),
+		NsapAddressValue{0x49, 0x00, 0x01},
+		BitStringValue{Bytes: []byte{0xa0}, BitLength: 3},
+	}, testValues...)
+
+	name := MustParseOID(".1.3.6.1.4.1.2021.1.0")
+	for _, value := range values {
+		vb := VarBind{Name: name, Value: value}
+		back, err := ToVarBind(vb.PDU())
+		if err != nil {
+			t.Errorf("Unable to convert %s back: %s", value.Type(), err)
+			continue
+		}
+		if !reflect.DeepEqual(back, vb) {
+			t.Errorf("Round trip of %s:\n\twant: %#v\n\tgot : %#v", value.Type(), vb, back)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gosnmp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// NonIncreasingPolicy selects how a walk reacts to an agent returning an OID
+// that does not sort after the previous one
+type NonIncreasingPolicy uint8
+
+const (
+	// WalkStop ends the walk with an error wrapping ErrOIDNotIncreasing
+	WalkStop NonIncreasingPolicy = iota
+	// WalkSkip discards the offending response and continues the walk from
+	// the OID following the requested one. Variables between the two may be
+	// missed, so the walk is not guaranteed to be complete. A walk that
+	// skips maxWalkSkips times in a row still ends with ErrOIDNotIncreasing,
+	// so that an agent stuck on one OID cannot hang it.
+	WalkSkip
+)
+
+// maxWalkSkips is the number of consecutive responses a WalkSkip walk skips
+// before giving up
+const maxWalkSkips = 16
+
+var (
+	// ErrOIDNotIncreasing is returned when an agent answers a walk request
+	// with an OID that is not lexicographically greater than the previous one
+	ErrOIDNotIncreasing = errors.New("OID not increasing")
+	// ErrWalkLimit is returned when a walk exceeds MaxWalkRequests or
+	// MaxWalkVarbinds
+	ErrWalkLimit = errors.New("walk limit exceeded")
+	// StopWalk can be returned by a WalkFunc callback to end the walk early.
+	// The walk then returns nil.
+	StopWalk = errors.New("stop walk")
+)
+
+// WalkFunc walks the subtree rooted at oid using GetNext requests, calling fn
+// for every variable as it is received. If fn returns an error the walk
+// stops and that error is returned, unless it is StopWalk.
+func (x *GoSNMP) WalkFunc(oid string, fn func(pdu SnmpPDU) error) error {
+	root, err := walkRoot(x.mibs(), oid)
+	if err != nil {
+		return err
+	}
+	return stopped(x.newWalker(context.Background(), root, false, 0).run(fn))
+}
+
+// BulkWalkFunc is like WalkFunc, but uses GetBulk requests fetching up to
+// maxRepetitions variables at a time
+func (x *GoSNMP) BulkWalkFunc(maxRepetitions uint8, oid string, fn func(pdu SnmpPDU) error) error {
+	root, err := walkRoot(x.mibs(), oid)
+	if err != nil {
+		return err
+	}
+	return stopped(x.newWalker(context.Background(), root, true, maxRepetitions).run(fn))
+}
+
+// WalkValues walks the subtree rooted at root using GetNext requests, like
+// Walk, with the variables decoded as typed VarBinds. If a request fails,
+// the variables received up to that point are returned along with the
+// error.
+func (x *GoSNMP) WalkValues(root OID) ([]VarBind, error) {
+	return x.walkValues(root, false, 0)
+}
+
+// BulkWalkValues is like WalkValues, but uses GetBulk requests fetching up
+// to maxRepetitions variables at a time
+func (x *GoSNMP) BulkWalkValues(maxRepetitions uint8, root OID) ([]VarBind, error) {
+	return x.walkValues(root, true, maxRepetitions)
+}
+
+// WalkValuesFunc is like WalkFunc, but walks from an OID and calls fn with
+// typed VarBinds
+func (x *GoSNMP) WalkValuesFunc(root OID, fn func(vb VarBind) error) error {
+	return x.walkValuesFunc(root, false, 0, fn)
+}
+
+// BulkWalkValuesFunc is like BulkWalkFunc, but walks from an OID and calls
+// fn with typed VarBinds
+func (x *GoSNMP) BulkWalkValuesFunc(maxRepetitions uint8, root OID, fn func(vb VarBind) error) error {
+	return x.walkValuesFunc(root, true, maxRepetitions, fn)
+}
+
+func (x *GoSNMP) walkValues(root OID, bulk bool, maxRepetitions uint8) ([]VarBind, error) {
+	var results []VarBind
+	err := x.walkValuesFunc(root, bulk, maxRepetitions, func(vb VarBind) error {
+		results = append(results, vb)
+		return nil
+	})
+	return results, err
+}
+
+func (x *GoSNMP) walkValuesFunc(root OID, bulk bool, maxRepetitions uint8, fn func(vb VarBind) error) error {
+	if len(root) == 0 {
+		return fmt.Errorf("No OID given\n")
+	}
+	return stopped(x.newWalker(context.Background(), root, bulk, maxRepetitions).runValues(fn))
+}
+
+// walkRoot parses the root of a walk, resolving symbolic names with mibs
+func walkRoot(mibs *MIBTree, oid string) (OID, error) {
+	if oid == "" {
+		return nil, fmt.Errorf("No OID given\n")
+	}
+	return mibs.Resolve(oid)
+}
+
+// stopped turns the StopWalk sentinel into a clean end of walk
+func stopped(err error) error {
+	if err == StopWalk {
+		return nil
+	}
+	return err
+}
+
+// walker holds the state of a walk in progress
+type walker struct {
+	x    *GoSNMP
+	ctx  context.Context
+	root OID
+	// last is the last OID returned to the caller, or the root before any
+	last OID
+	// next is the OID to send in the next request
+	next OID
+
+	bulk bool
+	// bulkAnswered is set once the agent answered a GetBulk request
+	bulkAnswered bool
+	// typed requests and hands over typed VarBinds rather than SnmpPDUs
+	typed          bool
+	maxRepetitions uint8
+	// repetitions is the current max-repetitions, adapted to the agent
+	repetitions uint8
+
+	requests int
+	varbinds int
+	// skips counts the responses skipped since the last variable returned
+	skips int
+}
+
+func (x *GoSNMP) newWalker(ctx context.Context, root OID, bulk bool, maxRepetitions uint8) *walker {
+	if maxRepetitions == 0 {
+		maxRepetitions = DefaultMaxRepetitions
+	}
+	if x.Version == Version1 {
+		// SNMPv1 has no GetBulk
+		bulk = false
+	}
+	return &walker{
+		x:              x,
+		ctx:            ctx,
+		root:           root,
+		last:           root,
+		next:           root,
+		bulk:           bulk,
+		maxRepetitions: maxRepetitions,
+		repetitions:    maxRepetitions,
+	}
+}
+
+// run walks the subtree, calling fn for every variable in order. It stops at
+// the end of the subtree, at the end of the MIB view or at the first error.
+func (w *walker) run(fn func(SnmpPDU) error) error {
+	return w.loop(func(res *SnmpPacket, i int) error {
+		return fn(res.Variables[i])
+	})
+}
+
+// runValues is like run, but decodes the responses into typed VarBinds
+func (w *walker) runValues(fn func(VarBind) error) error {
+	w.typed = true
+	return w.loop(func(res *SnmpPacket, i int) error {
+		return fn(res.VarBinds[i])
+	})
+}
+
+func (w *walker) loop(fn func(res *SnmpPacket, i int) error) error {
+	for {
+		done, err := w.step(fn)
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// step sends one request and hands its variables to fn, by position in the
+// response. It returns true when the walk is complete.
+func (w *walker) step(fn func(res *SnmpPacket, i int) error) (bool, error) {
+	x := w.x
+	if err := w.ctx.Err(); err != nil {
+		return true, err
+	}
+	if x.MaxWalkRequests > 0 && w.requests >= x.MaxWalkRequests {
+		return true, fmt.Errorf("%w: more than %d requests", ErrWalkLimit, x.MaxWalkRequests)
+	}
+	w.requests++
+
+	res, err := w.fetch()
+	if err != nil {
+		return true, err
+	}
+	if res == nil {
+		return true, nil
+	}
+
+	// Errors are checked first, as agents may send them without variables
+	switch SnmpError(res.Error) {
+	case NoError:
+	case NoSuchName:
+		// SNMPv1 agents signal the end of the MIB view this way
+		return true, nil
+	default:
+		return true, fmt.Errorf("Agent returned %s at index %d", SnmpError(res.Error), res.ErrorIndex)
+	}
+	if responseLen(res) == 0 {
+		return true, nil
+	}
+
+	for i := 0; i < responseLen(res); i++ {
+		var oid OID
+		if w.typed {
+			vb := res.VarBinds[i]
+			if vb.Value.Type() == EndOfMibView {
+				return true, nil
+			}
+			oid = vb.Name
+		} else {
+			v := res.Variables[i]
+			if v.Type == EndOfMibView {
+				return true, nil
+			}
+			if oid = v.OID(); oid == nil {
+				return true, fmt.Errorf("Unable to parse returned OID %q", v.Name)
+			}
+		}
+		if !oid.HasPrefix(w.root) {
+			x.Log.Debug("Root OID mismatch, stopping walk\n")
+			return true, nil
+		}
+
+		if oid.Compare(w.last) <= 0 {
+			if x.NonIncreasing == WalkSkip && w.skips < maxWalkSkips {
+				x.Log.Debug("OID %s does not follow %s, skipping ahead\n", oid, w.last)
+				w.skips++
+				w.next = successor(w.next)
+				return w.next == nil, nil
+			}
+			return true, fmt.Errorf("%w: %s returned after %s", ErrOIDNotIncreasing, oid, w.last)
+		}
+
+		if x.MaxWalkVarbinds > 0 && w.varbinds >= x.MaxWalkVarbinds {
+			return true, fmt.Errorf("%w: more than %d variables", ErrWalkLimit, x.MaxWalkVarbinds)
+		}
+		w.varbinds++
+		w.skips = 0
+
+		if err := fn(res, i); err != nil {
+			return true, err
+		}
+		w.last = oid
+		w.next = oid
+		x.Log.Debug("Moving to %s\n", oid)
+	}
+	return false, nil
+}
+
+// fetch sends the next GetNext or GetBulk request of the walk. GetBulk
+// requests answered with tooBig are retried with half the repetitions, and
+// fail once a single repetition is too big. If the agent fails the first
+// GetBulk request, as SNMPv1 agents do by timing out or with an error
+// without variables, the walk goes on with GetNext.
+func (w *walker) fetch() (*SnmpPacket, error) {
+	if w.bulk {
+		res, err := w.fetchBulk()
+		if w.bulkAnswered || !bulkUnsupported(res, err) {
+			if err == nil {
+				w.bulkAnswered = true
+			}
+			return res, err
+		}
+		w.x.Log.Debug("GetBulk failed, falling back to GetNext\n")
+		w.bulk = false
+	}
+	if w.typed {
+		return w.x.GetNextValues(w.next)
+	}
+	return w.x.GetNext(w.next.String())
+}
+
+// bulkUnsupported returns true if a GetBulk request failed in a way that
+// SNMPv1 agents fail it. Agents answering tooBig do know GetBulk.
+func bulkUnsupported(res *SnmpPacket, err error) bool {
+	if res != nil && SnmpError(res.Error) == TooBig {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return SnmpError(res.Error) != NoError && responseLen(res) == 0
+}
+
+// fetchBulk sends the next GetBulk request of the walk. The response is
+// returned along with the error when a single repetition is too big.
+func (w *walker) fetchBulk() (*SnmpPacket, error) {
+	for {
+		var res *SnmpPacket
+		var err error
+		if w.typed {
+			res, err = w.x.GetBulkValues(0, w.repetitions, w.next)
+		} else {
+			res, err = w.x.GetBulk(0, w.repetitions, w.next.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if SnmpError(res.Error) != TooBig {
+			w.adapt(res)
+			return res, nil
+		}
+		if w.repetitions == 1 {
+			return res, fmt.Errorf("Agent returned %s with max-repetitions 1", TooBig)
+		}
+		w.repetitions /= 2
+		w.x.Log.Debug("Response too big, lowering max-repetitions to %d\n", w.repetitions)
+	}
+}
+
+// adapt sizes max-repetitions so that responses stay below MaxResponseSize,
+// never exceeding the max-repetitions the walk was started with
+func (w *walker) adapt(res *SnmpPacket) {
+	if w.x.MaxResponseSize <= 0 || responseLen(res) == 0 || res.size == 0 {
+		return
+	}
+
+	perVariable := res.size / responseLen(res)
+	if perVariable < 1 {
+		perVariable = 1
+	}
+	fit := w.x.MaxResponseSize / perVariable
+	switch {
+	case fit < 1:
+		fit = 1
+	case fit > int(w.maxRepetitions):
+		fit = int(w.maxRepetitions)
+	}
+	if uint8(fit) != w.repetitions {
+		w.x.Log.Debug("Adjusting max-repetitions from %d to %d\n", w.repetitions, fit)
+		w.repetitions = uint8(fit)
+	}
+}
+
+// responseLen returns the number of variables of a response, legacy or typed
+func responseLen(res *SnmpPacket) int {
+	return len(res.Variables) + len(res.VarBinds)
+}
+
+// successor returns the first OID that sorts after oid and everything below
+// it, or nil if there is none
+func successor(oid OID) OID {
+	ret := oid.Copy()
+	for len(ret) > 0 {
+		if ret[len(ret)-1] < 1<<32-1 {
+			ret[len(ret)-1]++
+			return ret
+		}
+		ret = ret[:len(ret)-1]
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+package gosnmp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testMIB holds part of the system group and the interfaces table of two
+// interfaces, surrounded by subtrees whose string form shares a prefix
+var testMIB = map[string]Value{
+	".1.3.6.1.2.1.1.1.0":      OctetStringValue("Test agent"),
+	".1.3.6.1.2.1.1.3.0":      TimeTicksValue(4711),
+	".1.3.6.1.2.1.1.5.0":      OctetStringValue("router1"),
+	".1.3.6.1.2.1.2.1.0":      Integer32Value(2),
+	".1.3.6.1.2.1.2.2.1.1.1":  Integer32Value(1),
+	".1.3.6.1.2.1.2.2.1.1.2":  Integer32Value(2),
+	".1.3.6.1.2.1.2.2.1.2.1":  OctetStringValue("lo"),
+	".1.3.6.1.2.1.2.2.1.2.2":  OctetStringValue("eth0"),
+	".1.3.6.1.2.1.2.2.1.8.1":  Integer32Value(1),
+	".1.3.6.1.2.1.2.2.1.8.2":  Integer32Value(2),
+	".1.3.6.1.2.1.2.2.1.10.1": Counter32Value(1000),
+	".1.3.6.1.2.1.2.2.1.10.2": Counter32Value(2000),
+	".1.3.6.1.2.1.20.1.0":     Integer32Value(20),
+	".1.3.6.1.2.1.25.1.1.0":   TimeTicksValue(100),
+}
+
+func walkNames(pdus []SnmpPDU) []string {
+	names := make([]string, len(pdus))
+	for i, pdu := range pdus {
+		names[i] = pdu.Name
+	}
+	return names
+}
+
+// Test walks stay within the requested subtree, arc by arc
+func TestWalkSubtree(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	res, err := s.Walk(".1.3.6.1.2.1.2")
+	if err != nil {
+		t.Fatalf("Unable to perform walk: %s", err)
+	}
+	if len(res) != 9 {
+		t.Fatalf("Walk:\n\twant: %d variables\n\tgot : %v", 9, walkNames(res))
+	}
+	if res[8].Name != ".1.3.6.1.2.1.2.2.1.10.2" {
+		t.Errorf("Last variable:\n\twant: %s\n\tgot : %s", ".1.3.6.1.2.1.2.2.1.10.2", res[8].Name)
+	}
+
+	bulk, err := s.BulkWalk(4, ".1.3.6.1.2.1.2")
+	if err != nil {
+		t.Fatalf("Unable to perform bulk walk: %s", err)
+	}
+	if len(bulk) != len(res) {
+		t.Errorf("BulkWalk:\n\twant: %v\n\tgot : %v", walkNames(res), walkNames(bulk))
+	}
+
+	// Walking to the end of the MIB view terminates
+	res, err = s.Walk(".1.3.6.1.2.1.25")
+	if err != nil || len(res) != 1 {
+		t.Errorf("Walk to end of MIB: %v %v", walkNames(res), err)
+	}
+}
+
+// Test walks taking and returning OIDs
+func TestWalkValues(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	root := MustParseOID(".1.3.6.1.2.1.2")
+	want, err := s.Walk(root.String())
+	if err != nil {
+		t.Fatalf("Unable to perform walk: %s", err)
+	}
+	for _, bulk := range []bool{false, true} {
+		var res []VarBind
+		if bulk {
+			res, err = s.BulkWalkValues(4, root)
+		} else {
+			res, err = s.WalkValues(root)
+		}
+		if err != nil {
+			t.Fatalf("Unable to perform walk (bulk %t): %s", bulk, err)
+		}
+		if len(res) != len(want) {
+			t.Fatalf("Walk (bulk %t):\n\twant: %v\n\tgot : %v", bulk, walkNames(want), res)
+		}
+		for i, vb := range res {
+			if !vb.Name.Equal(want[i].OID()) || !reflect.DeepEqual(vb.PDU(), want[i]) {
+				t.Errorf("Variable %d (bulk %t):\n\twant: %v\n\tgot : %v", i, bulk, want[i], vb)
+			}
+		}
+
+		var names []string
+		collect := func(vb VarBind) error {
+			names = append(names, vb.Name.String())
+			if len(names) == 2 {
+				return StopWalk
+			}
+			return nil
+		}
+		if bulk {
+			err = s.BulkWalkValuesFunc(4, root, collect)
+		} else {
+			err = s.WalkValuesFunc(root, collect)
+		}
+		if err != nil || len(names) != 2 || names[1] != want[1].Name {
+			t.Errorf("WalkValuesFunc (bulk %t): %v %v", bulk, names, err)
+		}
+	}
+	if err := s.WalkValuesFunc(nil, func(VarBind) error { return nil }); err == nil {
+		t.Errorf("Expected error for an empty root")
+	}
+}
+
+// Test walks detect agents returning the same or lower OIDs
+func TestWalkNonIncreasing(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	// The agent is stuck returning the same OID
+	stuck := MustParseOID(".1.3.6.1.2.1.2.2.1.2.1")
+	agent.setNext(func(oid OID) VarBind {
+		return VarBind{stuck, OctetStringValue("lo")}
+	})
+
+	res, err := s.Walk(".1.3.6.1.2.1.2")
+	if !errors.Is(err, ErrOIDNotIncreasing) {
+		t.Fatalf("Walk:\n\twant: %s\n\tgot : %v", ErrOIDNotIncreasing, err)
+	}
+	if len(res) != 1 {
+		t.Errorf("Partial walk results:\n\twant: 1\n\tgot : %v", walkNames(res))
+	}
+
+	_, err = s.BulkWalk(4, ".1.3.6.1.2.1.2")
+	if !errors.Is(err, ErrOIDNotIncreasing) {
+		t.Errorf("BulkWalk:\n\twant: %s\n\tgot : %v", ErrOIDNotIncreasing, err)
+	}
+}
+
+// Test the skip policy continues past an agent going backwards
+func TestWalkSkip(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+	s.NonIncreasing = WalkSkip
+
+	// The agent jumps back to the start of the table after ifDescr.2
+	back := MustParseOID(".1.3.6.1.2.1.2.2.1.2.2")
+	first := MustParseOID(".1.3.6.1.2.1.2.2.1.1.1")
+	agent.setNext(func(oid OID) VarBind {
+		if oid.Equal(back) {
+			return VarBind{first, Integer32Value(1)}
+		}
+		return agent.lookupNext(oid)
+	})
+
+	res, err := s.Walk(".1.3.6.1.2.1.2")
+	if err != nil {
+		t.Fatalf("Unable to perform walk: %s", err)
+	}
+	if len(res) != 9 {
+		t.Errorf("Walk:\n\twant: %d variables\n\tgot : %v", 9, walkNames(res))
+	}
+}
+
+// Test the skip policy gives up on an agent stuck on one OID, without any
+// walk limits configured
+func TestWalkSkipLoop(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+	s.NonIncreasing = WalkSkip
+
+	stuck := MustParseOID(".1.3.6.1.2.1.2.2.1.1.1")
+	agent.setNext(func(oid OID) VarBind {
+		return VarBind{stuck, Integer32Value(1)}
+	})
+
+	for _, bulk := range []bool{false, true} {
+		before := agent.requestCount()
+		var err error
+		if bulk {
+			_, err = s.BulkWalk(0, ".1.3.6.1.2.1.2")
+		} else {
+			_, err = s.Walk(".1.3.6.1.2.1.2")
+		}
+		if !errors.Is(err, ErrOIDNotIncreasing) {
+			t.Errorf("Bulk %v:\n\twant: %s\n\tgot : %v", bulk, ErrOIDNotIncreasing, err)
+		}
+		// The first variable is returned, then maxWalkSkips responses are
+		// skipped before the next one ends the walk
+		if n := agent.requestCount() - before; n > maxWalkSkips+2 {
+			t.Errorf("Bulk %v requests:\n\twant: at most %d\n\tgot : %d", bulk, maxWalkSkips+2, n)
+		}
+	}
+
+	if _, err := s.GetTable(".1.3.6.1.2.1.2.2", 1); !errors.Is(err, ErrOIDNotIncreasing) {
+		t.Errorf("GetTable:\n\twant: %s\n\tgot : %v", ErrOIDNotIncreasing, err)
+	}
+}
+
+// Test the walk limits
+func TestWalkLimits(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	s.MaxWalkVarbinds = 3
+	res, err := s.Walk(".1.3.6.1.2.1.2")
+	if !errors.Is(err, ErrWalkLimit) || len(res) != 3 {
+		t.Errorf("Varbind limit:\n\twant: 3 variables, %s\n\tgot : %v, %v", ErrWalkLimit, walkNames(res), err)
+	}
+
+	s.MaxWalkVarbinds = 0
+	s.MaxWalkRequests = 2
+	res, err = s.Walk(".1.3.6.1.2.1.2")
+	if !errors.Is(err, ErrWalkLimit) || len(res) != 2 {
+		t.Errorf("Request limit:\n\twant: 2 variables, %s\n\tgot : %v, %v", ErrWalkLimit, walkNames(res), err)
+	}
+}
+
+// Test callback walks stop early on StopWalk
+func TestWalkFunc(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	for _, bulk := range []bool{false, true} {
+		var found SnmpPDU
+		fn := func(pdu SnmpPDU) error {
+			if pdu.Value == "eth0" {
+				found = pdu
+				return StopWalk
+			}
+			return nil
+		}
+
+		before := agent.requestCount()
+		var err error
+		if bulk {
+			err = s.BulkWalkFunc(2, ".1.3.6.1.2.1.2.2", fn)
+		} else {
+			err = s.WalkFunc(".1.3.6.1.2.1.2.2", fn)
+		}
+		if err != nil {
+			t.Fatalf("Unable to perform walk (bulk %t): %s", bulk, err)
+		}
+		if found.Name != ".1.3.6.1.2.1.2.2.1.2.2" {
+			t.Errorf("Found (bulk %t):\n\twant: %s\n\tgot : %s", bulk, ".1.3.6.1.2.1.2.2.1.2.2", found.Name)
+		}
+		if n := agent.requestCount() - before; n > 4 {
+			t.Errorf("Walk continued after StopWalk (bulk %t): %d requests", bulk, n)
+		}
+	}
+
+	// Other errors are passed through
+	stop := errors.New("test error")
+	if err := s.WalkFunc(".1.3.6.1.2.1.2", func(pdu SnmpPDU) error { return stop }); err != stop {
+		t.Errorf("WalkFunc error:\n\twant: %v\n\tgot : %v", stop, err)
+	}
+}
+
+// Test bulk walks adapt max-repetitions and fall back to GetNext for v1
+func TestBulkWalkAdaptive(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	// The agent answers tooBig above 3 variables
+	agent.setMaxVarbinds(3)
+	res, err := s.BulkWalk(20, ".1.3.6.1.2.1.2")
+	if err != nil {
+		t.Fatalf("Unable to perform bulk walk: %s", err)
+	}
+	if len(res) != 9 {
+		t.Errorf("BulkWalk with tooBig:\n\twant: %d variables\n\tgot : %v", 9, walkNames(res))
+	}
+	requests := agent.received()
+	for _, req := range requests[len(requests)-2:] {
+		if req.RequestType != GetBulkRequest || req.ErrorIndex > 3 {
+			t.Errorf("Max-repetitions not lowered: %s with %d", req.RequestType, req.ErrorIndex)
+		}
+	}
+
+	// Responses are kept below MaxResponseSize
+	agent.setMaxVarbinds(0)
+	s.MaxResponseSize = 100
+	if res, err = s.BulkWalk(5, ".1.3.6.1.2.1.2"); err != nil || len(res) != 9 {
+		t.Errorf("BulkWalk with MaxResponseSize: %v %v", walkNames(res), err)
+	}
+	requests = agent.received()
+	if last := requests[len(requests)-1]; last.ErrorIndex >= 5 {
+		t.Errorf("Max-repetitions not adapted to response size: %d", last.ErrorIndex)
+	}
+
+	// SNMPv1 has no GetBulk
+	s.Version = Version1
+	before := agent.requestCount()
+	if res, err = s.BulkWalk(20, ".1.3.6.1.2.1.2"); err != nil || len(res) != 9 {
+		t.Errorf("BulkWalk against v1: %v %v", walkNames(res), err)
+	}
+	for _, req := range agent.received()[before:] {
+		if req.RequestType != GetNextRequest {
+			t.Errorf("BulkWalk against v1 sent %s", req.RequestType)
+		}
+	}
+}
+
+// Test bulk walks and tables fall back to GetNext against agents that do
+// not know GetBulk, whether they drop it or answer genErr
+func TestBulkWalkFallback(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+	s.Timeout = 100 * time.Millisecond
+
+	for _, drop := range []bool{true, false} {
+		agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+			if req.RequestType != GetBulkRequest {
+				return agent.handle(req)
+			}
+			if drop {
+				return nil
+			}
+			return &SnmpPacket{Error: uint8(GenErr)}
+		})
+
+		res, err := s.BulkWalk(10, ".1.3.6.1.2.1.2")
+		if err != nil || len(res) != 9 {
+			t.Errorf("BulkWalk (drop %t):\n\twant: 9 variables\n\tgot : %v %v", drop, walkNames(res), err)
+		}
+		values, err := s.BulkWalkValues(10, MustParseOID(".1.3.6.1.2.1.2"))
+		if err != nil || len(values) != 9 {
+			t.Errorf("BulkWalkValues (drop %t): %v %v", drop, values, err)
+		}
+		table, err := s.BulkGetTable(10, ".1.3.6.1.2.1.2.2", 2, 10)
+		if err != nil || len(table.Rows) != 2 {
+			t.Errorf("BulkGetTable (drop %t): %+v %v", drop, table, err)
+		}
+	}
+}
+
+// Test walks fail on errors sent without variables
+func TestWalkEmptyError(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	// The agent answers tooBig with no variables whatever the repetitions
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if req.RequestType == GetBulkRequest {
+			return &SnmpPacket{Error: uint8(TooBig)}
+		}
+		return &SnmpPacket{Error: uint8(GenErr)}
+	})
+	res, err := s.BulkWalk(8, ".1.3.6.1.2.1.2")
+	if err == nil || len(res) != 0 {
+		t.Errorf("BulkWalk with tooBig:\n\twant: error\n\tgot : %v %v", walkNames(res), err)
+	}
+	if last := agent.received()[agent.requestCount()-1]; last.ErrorIndex != 1 {
+		t.Errorf("Max-repetitions of the last request:\n\twant: 1\n\tgot : %d", last.ErrorIndex)
+	}
+
+	res, err = s.Walk(".1.3.6.1.2.1.2")
+	if err == nil || len(res) != 0 {
+		t.Errorf("Walk with genErr:\n\twant: error\n\tgot : %v %v", walkNames(res), err)
+	}
+}
+
+// Test bulk walks return partial results when a request fails
+func TestBulkWalkPartial(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+	s.Timeout = 100 * time.Millisecond
+
+	agent.setRespond(func(req *SnmpPacket) *SnmpPacket {
+		if len(agent.requests) > 1 {
+			return nil
+		}
+		return agent.handle(req)
+	})
+
+	res, err := s.BulkWalk(4, ".1.3.6.1.2.1.2")
+	if err == nil {
+		t.Fatalf("Expected error when the agent stops responding")
+	}
+	if len(res) != 4 {
+		t.Errorf("Partial results:\n\twant: 4 variables\n\tgot : %v", walkNames(res))
+	}
+}
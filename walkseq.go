@@ -0,0 +1,85 @@
+// Copyright 2012 Andreas Louca. All rights reserved.
+// Use of this source code is goverend by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+
+package gosnmp
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// WalkSeq returns an iterator over the subtree rooted at oid, fetched lazily
+// with GetNext requests as the loop advances. Breaking out of the loop stops
+// the walk. An error is yielded once, as the last element; cancelling ctx
+// ends the walk with ctx.Err().
+//
+//	for pdu, err := range s.WalkSeq(ctx, ".1.3.6.1.2.1.2.2.1.2") {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("%s -> %v\n", pdu.Name, pdu.Value)
+//	}
+func (x *GoSNMP) WalkSeq(ctx context.Context, oid string) iter.Seq2[SnmpPDU, error] {
+	return x.walkSeq(ctx, oid, false, 0)
+}
+
+// BulkWalkSeq is like WalkSeq, but uses GetBulk requests fetching up to
+// maxRepetitions variables at a time
+func (x *GoSNMP) BulkWalkSeq(ctx context.Context, maxRepetitions uint8, oid string) iter.Seq2[SnmpPDU, error] {
+	return x.walkSeq(ctx, oid, true, maxRepetitions)
+}
+
+func (x *GoSNMP) walkSeq(ctx context.Context, oid string, bulk bool, maxRepetitions uint8) iter.Seq2[SnmpPDU, error] {
+	return func(yield func(SnmpPDU, error) bool) {
+		root, err := walkRoot(x.mibs(), oid)
+		if err != nil {
+			yield(SnmpPDU{}, err)
+			return
+		}
+
+		err = x.newWalker(ctx, root, bulk, maxRepetitions).run(func(pdu SnmpPDU) error {
+			if !yield(pdu, nil) {
+				return StopWalk
+			}
+			return nil
+		})
+		if err != nil && err != StopWalk {
+			yield(SnmpPDU{}, err)
+		}
+	}
+}
+
+// WalkValuesSeq is like WalkSeq, but walks from an OID and yields typed
+// VarBinds
+func (x *GoSNMP) WalkValuesSeq(ctx context.Context, root OID) iter.Seq2[VarBind, error] {
+	return x.walkValuesSeq(ctx, root, false, 0)
+}
+
+// BulkWalkValuesSeq is like BulkWalkSeq, but walks from an OID and yields
+// typed VarBinds
+func (x *GoSNMP) BulkWalkValuesSeq(ctx context.Context, maxRepetitions uint8, root OID) iter.Seq2[VarBind, error] {
+	return x.walkValuesSeq(ctx, root, true, maxRepetitions)
+}
+
+func (x *GoSNMP) walkValuesSeq(ctx context.Context, root OID, bulk bool, maxRepetitions uint8) iter.Seq2[VarBind, error] {
+	return func(yield func(VarBind, error) bool) {
+		if len(root) == 0 {
+			yield(VarBind{}, fmt.Errorf("No OID given\n"))
+			return
+		}
+
+		err := x.newWalker(ctx, root, bulk, maxRepetitions).runValues(func(vb VarBind) error {
+			if !yield(vb, nil) {
+				return StopWalk
+			}
+			return nil
+		})
+		if err != nil && err != StopWalk {
+			yield(VarBind{}, err)
+		}
+	}
+}
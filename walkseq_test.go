@@ -0,0 +1,76 @@
+//go:build go1.23
+
+package gosnmp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// Test iterator walks fetch lazily and stop when the loop breaks
+func TestWalkSeq(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	var names []string
+	for pdu, err := range s.WalkSeq(context.Background(), ".1.3.6.1.2.1.2") {
+		if err != nil {
+			t.Fatalf("Unable to perform walk: %s", err)
+		}
+		names = append(names, pdu.Name)
+	}
+	if len(names) != 9 {
+		t.Errorf("WalkSeq:\n\twant: %d variables\n\tgot : %v", 9, names)
+	}
+
+	before := agent.requestCount()
+	for pdu, err := range s.BulkWalkSeq(context.Background(), 2, ".1.3.6.1.2.1.2") {
+		if err != nil {
+			t.Fatalf("Unable to perform bulk walk: %s", err)
+		}
+		if pdu.Name == ".1.3.6.1.2.1.2.2.1.1.1" {
+			break
+		}
+	}
+	if n := agent.requestCount() - before; n != 1 {
+		t.Errorf("BulkWalkSeq requests after break:\n\twant: 1\n\tgot : %d", n)
+	}
+
+	// A cancelled context ends the walk with its error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range s.WalkSeq(ctx, ".1.3.6.1.2.1.2") {
+		if err != context.Canceled {
+			t.Errorf("Cancelled walk:\n\twant: %v\n\tgot : %v", context.Canceled, err)
+		}
+	}
+}
+
+// Test iterator walks taking and yielding OIDs
+func TestWalkValuesSeq(t *testing.T) {
+	agent := newTestAgent(t, testMIB)
+	s := agent.client()
+
+	root := MustParseOID(".1.3.6.1.2.1.2")
+	want, err := s.WalkValues(root)
+	if err != nil {
+		t.Fatalf("Unable to perform walk: %s", err)
+	}
+	for _, bulk := range []bool{false, true} {
+		seq := s.WalkValuesSeq(context.Background(), root)
+		if bulk {
+			seq = s.BulkWalkValuesSeq(context.Background(), 3, root)
+		}
+		var got []VarBind
+		for vb, err := range seq {
+			if err != nil {
+				t.Fatalf("Unable to perform walk (bulk %t): %s", bulk, err)
+			}
+			got = append(got, vb)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("WalkValuesSeq (bulk %t):\n\twant: %v\n\tgot : %v", bulk, want, got)
+		}
+	}
+}